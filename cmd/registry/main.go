@@ -13,10 +13,12 @@ import (
 
 	"github.com/modelcontextprotocol/registry/internal/api"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/importer"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/snapshot"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
 
@@ -56,13 +58,17 @@ func main() {
 
 	// Initialize configuration
 	cfg := config.NewConfig()
+	if _, err := cfg.TrustedProxies(); err != nil {
+		log.Printf("Invalid rate limit trusted proxies: %v", err)
+		return
+	}
 
 	// Create a context with timeout for PostgreSQL connection
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	// Connect to PostgreSQL
-	db, err = database.NewPostgreSQL(ctx, cfg.DatabaseURL)
+	db, err = database.NewPostgreSQL(ctx, cfg.DatabaseURL, cfg.DatabaseOptions())
 	if err != nil {
 		log.Printf("Failed to connect to PostgreSQL: %v", err)
 		return
@@ -86,6 +92,15 @@ func main() {
 		defer cancel()
 
 		importerService := importer.NewService(registryService)
+		if cfg.SeedPublicKey != "" {
+			// Verify mode: only import seed data matching a manifest signed by this key
+			publicKey, err := snapshot.ParsePublicKey(cfg.SeedPublicKey)
+			if err != nil {
+				log.Printf("Invalid seed public key: %v", err)
+				return
+			}
+			importerService = importer.NewVerifyingService(registryService, publicKey, cfg.SeedManifest)
+		}
 		if err := importerService.ImportFromPath(ctx, cfg.SeedFrom); err != nil {
 			log.Printf("Failed to import seed data: %v", err)
 		}
@@ -103,6 +118,48 @@ func main() {
 		}
 	}()
 
+	if err := metrics.ObservePools(db.PoolStats); err != nil {
+		log.Printf("Failed to observe database pool metrics: %v", err)
+		return
+	}
+
+	// Load the namespace denylist checked at token exchange, and keep it in sync with blocks made on any replica
+	loadCtx, cancelLoad := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelLoad()
+	if err := service.RefreshBlockedNamespaces(loadCtx, registryService, auth.BlockedNamespaces); err != nil {
+		log.Printf("Failed to load blocked namespaces: %v", err)
+		return
+	}
+	denylistCtx, stopRefreshingDenylist := context.WithCancel(context.Background())
+	defer stopRefreshingDenylist()
+	go service.WatchBlockedNamespaces(denylistCtx, registryService, auth.BlockedNamespaces, cfg.BlockedNamespacesRefreshInterval)
+
+	// Cache reads in memory, invalidated by writes on any replica
+	if cfg.CacheEnabled {
+		cachingService := service.NewCachingRegistryService(registryService, cfg.CacheMaxEntries, cfg.CacheTTL, metrics)
+		registryService = cachingService
+
+		watchCtx, stopWatching := context.WithCancel(context.Background())
+		defer stopWatching()
+		go cachingService.WatchInvalidations(watchCtx, db)
+	}
+
+	// Generate signed snapshot manifests in the background, so that requests are served the last one
+	var snapshots *snapshot.Publisher
+	if signingKey, err := snapshot.SigningKey(cfg); err != nil {
+		log.Printf("Snapshot manifests are disabled: %v", err)
+	} else {
+		if cfg.SnapshotInterval <= 0 {
+			log.Printf("Invalid snapshot interval %s: must be positive", cfg.SnapshotInterval)
+			return
+		}
+		snapshots = snapshot.NewPublisher(registryService, signingKey, cfg.SnapshotInterval)
+
+		snapshotCtx, stopSnapshots := context.WithCancel(context.Background())
+		defer stopSnapshots()
+		go snapshots.Run(snapshotCtx)
+	}
+
 	// Prepare version information
 	versionInfo := &v0.VersionBody{
 		Version:   Version,
@@ -111,7 +168,7 @@ func main() {
 	}
 
 	// Initialize HTTP server
-	server := api.NewServer(cfg, registryService, metrics, versionInfo)
+	server := api.NewServer(cfg, registryService, snapshots, metrics, versionInfo)
 
 	// Start server in a goroutine so it doesn't block signal handling
 	go func() {
@@ -0,0 +1,67 @@
+// Command snapshot renders the registry read API as a static file tree for read-only mirrors
+// served from object storage or a CDN.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/snapshot"
+)
+
+func main() {
+	outputDir := flag.String("output", "", "Directory to write the snapshot to (must be empty or not exist)")
+	prefix := flag.String("prefix", "v0", "API version path prefix to render (e.g. v0 or v0.1)")
+	pageSize := flag.Int("page-size", 100, "Number of entries per /servers page")
+	flag.Parse()
+
+	if *outputDir == "" {
+		log.Println("Usage: snapshot -output <dir> [-prefix v0] [-page-size 100]")
+		os.Exit(2)
+	}
+
+	if err := run(*outputDir, *prefix, *pageSize); err != nil {
+		log.Printf("Failed to generate snapshot: %v", err)
+		os.Exit(1)
+	}
+}
+
+func run(outputDir, prefix string, pageSize int) error {
+	// Connection settings and the signing key come from the same MCP_REGISTRY_* environment as the registry
+	cfg := config.NewConfig()
+	signingKey, err := snapshot.SigningKey(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db, err := database.NewPostgreSQL(ctx, cfg.DatabaseURL, cfg.DatabaseOptions())
+	if err != nil {
+		return fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Printf("Error closing PostgreSQL connection: %v", err)
+		}
+	}()
+
+	generator := snapshot.NewGenerator(service.NewRegistryService(db, cfg), prefix, pageSize, signingKey)
+
+	started := time.Now()
+	manifest, err := generator.Generate(context.Background(), outputDir)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Wrote %d files to %s in %s", len(manifest.Manifest.Files), outputDir, time.Since(started).Round(time.Millisecond))
+	return nil
+}
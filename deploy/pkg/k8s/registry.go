@@ -168,6 +168,12 @@ func DeployMCPRegistry(ctx *pulumi.Context, cluster *providers.ProviderInfo, env
 									Name:  pulumi.String("MCP_REGISTRY_OIDC_PUBLISH_PERMISSIONS"),
 									Value: pulumi.String("*"),
 								},
+								// Only the ingress controller's addresses may be trusted to forward client
+								// addresses: any other trusted peer could spoof X-Forwarded-For
+								&corev1.EnvVarArgs{
+									Name:  pulumi.String("MCP_REGISTRY_RATE_LIMIT_TRUSTED_PROXIES"),
+									Value: pulumi.String(conf.Get("rateLimitTrustedProxies")),
+								},
 							},
 							LivenessProbe: &corev1.ProbeArgs{
 								HttpGet: &corev1.HTTPGetActionArgs{
@@ -0,0 +1,257 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/golang-jwt/jwt/v5"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// errAPITokenRejected is wrapped by exchange errors caused by the API token itself, as opposed
+// to failures of the registry
+var errAPITokenRejected = errors.New("API token rejected")
+
+// APITokenExchangeInput represents the input for API token exchange
+type APITokenExchangeInput struct {
+	Body struct {
+		APIToken string `json:"api_token" doc:"API token created through the API token endpoints" required:"true"`
+	}
+}
+
+// CreateAPITokenInput represents the input for creating an API token
+type CreateAPITokenInput struct {
+	Authorization string                      `header:"Authorization" doc:"Registry JWT token of the user creating the API token" required:"true"`
+	Body          apiv0.APITokenCreateRequest `body:""`
+}
+
+// ListAPITokensInput represents the input for listing API tokens
+type ListAPITokensInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of the user owning the API tokens" required:"true"`
+}
+
+// RevokeAPITokenInput represents the input for revoking an API token
+type RevokeAPITokenInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of the user owning the API token" required:"true"`
+	ID            string `path:"id" doc:"Identifier of the API token" example:"3f2a9c4b1d8e7f60"`
+}
+
+// APITokenHandler handles long-lived API tokens
+type APITokenHandler struct {
+	config     *config.Config
+	jwtManager *auth.JWTManager
+	registry   service.RegistryService
+}
+
+// NewAPITokenHandler creates a new API token handler
+func NewAPITokenHandler(cfg *config.Config, registry service.RegistryService) *APITokenHandler {
+	return &APITokenHandler{
+		config:     cfg,
+		jwtManager: auth.NewJWTManager(cfg),
+		registry:   registry,
+	}
+}
+
+// RegisterAPITokenEndpoints registers the API token management and exchange endpoints with a custom path prefix
+func RegisterAPITokenEndpoints(api huma.API, pathPrefix string, cfg *config.Config, registry service.RegistryService) {
+	handler := NewAPITokenHandler(cfg, registry)
+
+	// API token exchange endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "exchange-api-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/api-token",
+		Summary:     "Exchange API token for Registry JWT",
+		Description: "Exchange a long-lived API token for a short-lived Registry JWT token with the permissions of the API token",
+		Tags:        []string{"auth"},
+	}, func(ctx context.Context, input *APITokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
+		response, err := handler.ExchangeToken(ctx, input.Body.APIToken)
+		if err != nil {
+			if errors.Is(err, errAPITokenRejected) {
+				return nil, huma.Error401Unauthorized("Token exchange failed", err)
+			}
+			return nil, huma.Error500InternalServerError("Token exchange failed", err)
+		}
+
+		return &v0.Response[auth.TokenResponse]{
+			Body: *response,
+		}, nil
+	})
+
+	// Create API token endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "create-api-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/api-tokens",
+		Summary:     "Create API token",
+		Description: "Create a long-lived API token with a subset of the permissions of the Registry JWT token, for use in CI systems. The token is only returned in this response.",
+		Tags:        []string{"auth"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *CreateAPITokenInput) (*v0.Response[apiv0.APITokenCreateResponse], error) {
+		claims, err := handler.authorizeOwner(ctx, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		requested := make([]auth.Permission, 0, len(input.Body.Permissions))
+		for _, perm := range input.Body.Permissions {
+			requested = append(requested, auth.Permission{Action: auth.PermissionAction(perm.Action), ResourcePattern: perm.Resource})
+		}
+		if !handler.jwtManager.HasPermissions(requested, claims.Permissions) {
+			return nil, huma.Error403Forbidden("API tokens can only be granted permissions that your Registry JWT token has")
+		}
+
+		created, err := registry.CreateAPIToken(ctx, apiTokenOwner(claims), &input.Body)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Failed to create API token", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to create API token", err)
+		}
+
+		return &v0.Response[apiv0.APITokenCreateResponse]{
+			Body: *created,
+		}, nil
+	})
+
+	// List API tokens endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "list-api-tokens" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/auth/api-tokens",
+		Summary:     "List API tokens",
+		Description: "List the API tokens created by the authenticated user. Token secrets are never returned.",
+		Tags:        []string{"auth"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListAPITokensInput) (*v0.Response[apiv0.APITokenListResponse], error) {
+		claims, err := handler.authorizeOwner(ctx, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens, err := registry.ListAPITokens(ctx, apiTokenOwner(claims))
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list API tokens", err)
+		}
+
+		body := apiv0.APITokenListResponse{Tokens: make([]apiv0.APIToken, 0, len(tokens))}
+		for _, token := range tokens {
+			body.Tokens = append(body.Tokens, *token)
+		}
+
+		return &v0.Response[apiv0.APITokenListResponse]{
+			Body: body,
+		}, nil
+	})
+
+	// Revoke API token endpoint
+	huma.Register(api, huma.Operation{
+		OperationID:   "revoke-api-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/auth/api-tokens/{id}",
+		DefaultStatus: http.StatusNoContent,
+		Summary:       "Revoke API token",
+		Description:   "Revoke an API token created by the authenticated user, so that it can no longer be exchanged. Registry JWT tokens already issued for it stay valid until they expire.",
+		Tags:          []string{"auth"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *RevokeAPITokenInput) (*struct{}, error) {
+		claims, err := handler.authorizeOwner(ctx, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := registry.RevokeAPIToken(ctx, input.ID, apiTokenOwner(claims)); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("API token not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to revoke API token", err)
+		}
+
+		return nil, nil
+	})
+}
+
+// ExchangeToken exchanges an API token for a Registry JWT token with the permissions the API token
+// was created with. Errors caused by the API token wrap errAPITokenRejected.
+func (h *APITokenHandler) ExchangeToken(ctx context.Context, apiToken string) (*auth.TokenResponse, error) {
+	token, err := h.registry.ExchangeAPIToken(ctx, apiToken)
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) || errors.Is(err, database.ErrInvalidInput) {
+			return nil, fmt.Errorf("%w: invalid API token: %w", errAPITokenRejected, err)
+		}
+		return nil, fmt.Errorf("failed to look up API token: %w", err)
+	}
+
+	permissions := make([]auth.Permission, 0, len(token.Permissions))
+	for _, perm := range token.Permissions {
+		permissions = append(permissions, auth.Permission{Action: auth.PermissionAction(perm.Action), ResourcePattern: perm.Resource})
+	}
+
+	// Create JWT claims for the owner of the API token
+	claims := auth.JWTClaims{
+		AuthMethod:        auth.MethodAPIToken,
+		AuthMethodSubject: token.Owner,
+		Permissions:       permissions,
+	}
+
+	// Registry JWT tokens do not outlive the API token they were issued for
+	if time.Until(token.ExpiresAt) < h.jwtManager.TokenDuration() {
+		claims.ExpiresAt = jwt.NewNumericDate(token.ExpiresAt)
+	}
+
+	// Generate Registry JWT token, which is refused for blocked namespaces
+	tokenResponse, err := h.jwtManager.GenerateTokenResponse(ctx, claims)
+	if err != nil {
+		if errors.Is(err, auth.ErrNamespaceBlocked) {
+			return nil, fmt.Errorf("%w: %w", errAPITokenRejected, err)
+		}
+		return nil, fmt.Errorf("failed to generate JWT token: %w", err)
+	}
+
+	return tokenResponse, nil
+}
+
+// authorizeOwner validates the Registry JWT token of a user managing their API tokens
+func (h *APITokenHandler) authorizeOwner(ctx context.Context, authHeader string) (*auth.JWTClaims, error) {
+	// Extract bearer token
+	const bearerPrefix = "Bearer "
+	if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
+	}
+	token := authHeader[len(bearerPrefix):]
+
+	// Validate Registry JWT token
+	claims, err := h.jwtManager.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+	}
+
+	// API tokens belong to a logged-in user. Tokens issued for API tokens cannot manage them,
+	// so that a leaked API token cannot be used to extend its own lifetime.
+	if claims.AuthMethod == auth.MethodNone || claims.AuthMethod == auth.MethodAPIToken {
+		return nil, huma.Error403Forbidden(fmt.Sprintf("API tokens cannot be managed with %s Registry JWT tokens", claims.AuthMethod))
+	}
+
+	return claims, nil
+}
+
+// apiTokenOwner identifies the user owning API tokens by auth method and subject
+func apiTokenOwner(claims *auth.JWTClaims) string {
+	return string(claims.AuthMethod) + ":" + claims.AuthMethodSubject
+}
@@ -0,0 +1,179 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPITokenEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey:       hex.EncodeToString(testSeed),
+		APITokenMaxLifetime: 365 * 24 * time.Hour,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0auth.RegisterAPITokenEndpoints(api, "/v0", cfg, registryService)
+
+	jwtManager := auth.NewJWTManager(cfg)
+	userToken := func(t *testing.T, method auth.Method) string {
+		t.Helper()
+		response, err := jwtManager.GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod:        method,
+			AuthMethodSubject: "octocat",
+			Permissions: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.octocat/*"},
+			},
+		})
+		require.NoError(t, err)
+		return response.RegistryToken
+	}
+	githubToken := userToken(t, auth.MethodGitHubAT)
+
+	request := func(t *testing.T, method, path, token string, body any) *httptest.ResponseRecorder {
+		t.Helper()
+		var payload []byte
+		if body != nil {
+			payload, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	createRequest := func(resource string) apiv0.APITokenCreateRequest {
+		return apiv0.APITokenCreateRequest{
+			Description: "CI publishing",
+			Permissions: []apiv0.APITokenPermission{{Action: "publish", Resource: resource}},
+			ExpiresAt:   time.Now().Add(30 * 24 * time.Hour),
+		}
+	}
+
+	var created apiv0.APITokenCreateResponse
+	t.Run("create API token", func(t *testing.T) {
+		w := request(t, http.MethodPost, "/v0/auth/api-tokens", githubToken, createRequest("io.github.octocat/tools-*"))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		assert.NotEmpty(t, created.Token)
+		assert.Equal(t, "github-at:octocat", created.Owner)
+	})
+
+	t.Run("permissions cannot exceed those of the creator", func(t *testing.T) {
+		w := request(t, http.MethodPost, "/v0/auth/api-tokens", githubToken, createRequest("io.github.*"))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("anonymous and API token JWTs cannot manage API tokens", func(t *testing.T) {
+		w := request(t, http.MethodPost, "/v0/auth/api-tokens", userToken(t, auth.MethodNone), createRequest("io.github.octocat/*"))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		w = request(t, http.MethodGet, "/v0/auth/api-tokens", userToken(t, auth.MethodAPIToken), nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		w = request(t, http.MethodGet, "/v0/auth/api-tokens", "", nil)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("exchange API token for Registry JWT", func(t *testing.T) {
+		w := request(t, http.MethodPost, "/v0/auth/api-token", "", map[string]string{"api_token": created.Token})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response auth.TokenResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		claims, err := jwtManager.ValidateToken(context.Background(), response.RegistryToken)
+		require.NoError(t, err)
+		assert.Equal(t, auth.MethodAPIToken, claims.AuthMethod)
+		assert.Equal(t, "github-at:octocat", claims.AuthMethodSubject)
+		assert.Equal(t, []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.octocat/tools-*"},
+		}, claims.Permissions)
+	})
+
+	t.Run("API tokens for blocked namespaces cannot be exchanged", func(t *testing.T) {
+		originalBlocked := auth.BlockedNamespaces.Namespaces()
+		auth.BlockedNamespaces.Replace([]string{"io.github.octocat"})
+		defer auth.BlockedNamespaces.Replace(originalBlocked)
+
+		w := request(t, http.MethodPost, "/v0/auth/api-token", "", map[string]string{"api_token": created.Token})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "your namespace is blocked")
+	})
+
+	t.Run("list API tokens", func(t *testing.T) {
+		w := request(t, http.MethodGet, "/v0/auth/api-tokens", githubToken, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var list apiv0.APITokenListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Tokens, 1)
+		assert.Equal(t, created.ID, list.Tokens[0].ID)
+		assert.NotNil(t, list.Tokens[0].LastUsedAt)
+		assert.NotContains(t, w.Body.String(), created.Token)
+	})
+
+	t.Run("revoked API tokens cannot be exchanged", func(t *testing.T) {
+		w := request(t, http.MethodDelete, "/v0/auth/api-tokens/"+created.ID, githubToken, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = request(t, http.MethodPost, "/v0/auth/api-token", "", map[string]string{"api_token": created.Token})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		w = request(t, http.MethodDelete, "/v0/auth/api-tokens/"+created.ID, githubToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+// failingAPITokenRegistry fails every API token exchange as if the database were unavailable
+type failingAPITokenRegistry struct {
+	service.RegistryService
+}
+
+func (failingAPITokenRegistry) ExchangeAPIToken(context.Context, string) (*apiv0.APIToken, error) {
+	return nil, errors.New("connection reset by peer")
+}
+
+func TestAPITokenExchangeRegistryFailure(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0auth.RegisterAPITokenEndpoints(api, "/v0", cfg, failingAPITokenRegistry{})
+
+	req := httptest.NewRequest(http.MethodPost, "/v0/auth/api-token", bytes.NewReader([]byte(`{"api_token":"mcpr_test"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
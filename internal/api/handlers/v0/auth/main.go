@@ -3,10 +3,11 @@ package auth
 import (
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/service"
 )
 
 // RegisterAuthEndpoints registers all authentication endpoints with a custom path prefix
-func RegisterAuthEndpoints(api huma.API, pathPrefix string, cfg *config.Config) {
+func RegisterAuthEndpoints(api huma.API, pathPrefix string, cfg *config.Config, registry service.RegistryService) {
 	// Register GitHub access token authentication endpoint
 	RegisterGitHubATEndpoint(api, pathPrefix, cfg)
 
@@ -22,6 +23,9 @@ func RegisterAuthEndpoints(api huma.API, pathPrefix string, cfg *config.Config)
 	// Register HTTP-based authentication endpoint
 	RegisterHTTPEndpoint(api, pathPrefix, cfg)
 
+	// Register API token management and exchange endpoints
+	RegisterAPITokenEndpoints(api, pathPrefix, cfg, registry)
+
 	// Register anonymous authentication endpoint
 	RegisterNoneEndpoint(api, pathPrefix, cfg)
 }
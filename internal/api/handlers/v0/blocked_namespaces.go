@@ -0,0 +1,129 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListBlockedNamespacesInput represents the input for listing blocked namespaces
+type ListBlockedNamespacesInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with registry-wide edit permissions" required:"true"`
+}
+
+// BlockNamespaceInput represents the input for blocking a namespace
+type BlockNamespaceInput struct {
+	Authorization string                      `header:"Authorization" doc:"Registry JWT token with registry-wide edit permissions" required:"true"`
+	Namespace     string                      `path:"namespace" doc:"Namespace to block" example:"io.github.spammer"`
+	Body          apiv0.BlockNamespaceRequest `body:""`
+}
+
+// UnblockNamespaceInput represents the input for unblocking a namespace
+type UnblockNamespaceInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with registry-wide edit permissions" required:"true"`
+	Namespace     string `path:"namespace" doc:"Namespace to unblock" example:"io.github.spammer"`
+}
+
+// RegisterBlockedNamespaceEndpoints registers the namespace denylist endpoints with a custom path prefix.
+// Changes apply to this replica's denylist at once, and to other replicas on their next refresh.
+func RegisterBlockedNamespaceEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	// List blocked namespaces endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "list-blocked-namespaces" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/blocked-namespaces",
+		Summary:     "List blocked namespaces",
+		Description: "List the namespaces that are blocked from publishing (admin only).",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListBlockedNamespacesInput) (*Response[apiv0.BlockedNamespaceListResponse], error) {
+		if _, err := authorizeRegistryAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		blocked, err := registry.ListBlockedNamespaces(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list blocked namespaces", err)
+		}
+
+		body := apiv0.BlockedNamespaceListResponse{Namespaces: make([]apiv0.BlockedNamespace, 0, len(blocked))}
+		for _, namespace := range blocked {
+			body.Namespaces = append(body.Namespaces, *namespace)
+		}
+
+		return &Response[apiv0.BlockedNamespaceListResponse]{
+			Body: body,
+		}, nil
+	})
+
+	// Block namespace endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "block-namespace" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/blocked-namespaces/{namespace}",
+		Summary:     "Block namespace",
+		Description: "Block a namespace and its sub-namespaces from publishing (admin only). Tokens that can publish to the namespace are no longer issued, and publishes to it are refused. Existing servers are not changed.",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *BlockNamespaceInput) (*Response[apiv0.BlockedNamespace], error) {
+		claims, err := authorizeRegistryAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		blocked, err := registry.BlockNamespace(ctx, input.Namespace, input.Body.Reason, editorIdentity(claims))
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Failed to block namespace", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to block namespace", err)
+		}
+		auth.BlockedNamespaces.Add(blocked.Namespace)
+
+		return &Response[apiv0.BlockedNamespace]{
+			Body: *blocked,
+		}, nil
+	})
+
+	// Unblock namespace endpoint
+	huma.Register(api, huma.Operation{
+		OperationID:   "unblock-namespace" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/blocked-namespaces/{namespace}",
+		DefaultStatus: http.StatusNoContent,
+		Summary:       "Unblock namespace",
+		Description:   "Allow a blocked namespace to publish again (admin only).",
+		Tags:          []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *UnblockNamespaceInput) (*struct{}, error) {
+		if _, err := authorizeRegistryAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		if err := registry.UnblockNamespace(ctx, input.Namespace); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Namespace is not blocked")
+			}
+			return nil, huma.Error500InternalServerError("Failed to unblock namespace", err)
+		}
+		auth.BlockedNamespaces.Remove(input.Namespace)
+
+		return nil, nil
+	})
+}
@@ -0,0 +1,136 @@
+package v0_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestBlockedNamespaceEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+	}
+
+	originalBlocked := auth.BlockedNamespaces.Namespaces()
+	defer auth.BlockedNamespaces.Replace(originalBlocked)
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, cfg)
+	v0.RegisterBlockedNamespaceEndpoints(api, "/v0", registryService, cfg)
+
+	adminToken, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubOIDC,
+		AuthMethodSubject: "admin",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionEdit, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+
+	// Issued before the block, so only the publish-time check can stop it
+	spammerClaims := auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "spammer",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.spammer/*"},
+		},
+	}
+	spammerToken, err := generateTestJWTToken(cfg, spammerClaims)
+	require.NoError(t, err)
+
+	request := func(t *testing.T, method, path, token string, body any) *httptest.ResponseRecorder {
+		t.Helper()
+		var payload []byte
+		if body != nil {
+			payload, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	publish := func(t *testing.T, version string) *httptest.ResponseRecorder {
+		t.Helper()
+		return request(t, http.MethodPost, "/v0/publish", spammerToken, apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.spammer/server",
+			Description: "Spam",
+			Version:     version,
+		})
+	}
+
+	t.Run("require registry-wide edit permissions", func(t *testing.T) {
+		w := request(t, http.MethodPut, "/v0/blocked-namespaces/io.github.other", spammerToken, apiv0.BlockNamespaceRequest{})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("blocking refuses publishes and token exchanges", func(t *testing.T) {
+		w := request(t, http.MethodPut, "/v0/blocked-namespaces/io.github.spammer", adminToken, apiv0.BlockNamespaceRequest{Reason: "Spam"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var blocked apiv0.BlockedNamespace
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &blocked))
+		assert.Equal(t, "io.github.spammer", blocked.Namespace)
+		assert.Equal(t, "Spam", blocked.Reason)
+		assert.Equal(t, "github-oidc:admin", blocked.BlockedBy)
+
+		w = publish(t, "1.0.0")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "namespace is blocked")
+
+		_, err := generateTestJWTToken(cfg, spammerClaims)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "your namespace is blocked")
+	})
+
+	t.Run("list blocked namespaces", func(t *testing.T) {
+		w := request(t, http.MethodGet, "/v0/blocked-namespaces", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var list apiv0.BlockedNamespaceListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Namespaces, 1)
+		assert.Equal(t, "io.github.spammer", list.Namespaces[0].Namespace)
+	})
+
+	t.Run("unblocking allows publishing again", func(t *testing.T) {
+		w := request(t, http.MethodDelete, "/v0/blocked-namespaces/io.github.spammer", adminToken, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.NotContains(t, auth.BlockedNamespaces.Namespaces(), "io.github.spammer")
+
+		w = publish(t, "1.0.0")
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = request(t, http.MethodDelete, "/v0/blocked-namespaces/io.github.spammer", adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,100 @@
+package v0
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// Cache-Control policies of the read endpoints. Responses that name a specific version change
+// rarely (admin edits and status changes); aliases such as "latest", dist-tags and version
+// ranges, and listings, change whenever a version is published.
+const (
+	cacheControlVersion = "public, max-age=300"
+	cacheControlAlias   = "public, max-age=60"
+	cacheControlList    = "public, max-age=60"
+)
+
+// ConditionalInput holds the conditional request headers of cacheable read endpoints
+type ConditionalInput struct {
+	IfNoneMatch     string `header:"If-None-Match" doc:"Respond with 304 Not Modified if the response would have one of these ETags" required:"false"`
+	IfModifiedSince string `header:"If-Modified-Since" doc:"Respond with 304 Not Modified if the response has not changed since this HTTP date. Ignored when If-None-Match is set." required:"false"`
+}
+
+// CachedResponse is a Response with HTTP caching headers. Its status is 304 Not Modified,
+// and its body is not sent, when the client already has the response.
+type CachedResponse[T any] struct {
+	Status       int
+	ETag         string `header:"ETag"`
+	LastModified string `header:"Last-Modified"`
+	CacheControl string `header:"Cache-Control"`
+	Body         T
+}
+
+// cachedResponse wraps a response body with its ETag, Last-Modified and Cache-Control headers,
+// answering 304 Not Modified if the conditional request headers show the client has it already.
+// lastModified is the zero time for responses without a reliable modification time, such as
+// listings, which can change without any of their entries being modified, and aliases like
+// "latest", which can move to a version modified earlier.
+func cachedResponse[T any](conditional *ConditionalInput, body T, lastModified time.Time, cacheControl string) (*CachedResponse[T], error) {
+	etag, err := entityTag(body)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to compute ETag", err)
+	}
+
+	response := &CachedResponse[T]{
+		Status:       http.StatusOK,
+		ETag:         etag,
+		CacheControl: cacheControl,
+		Body:         body,
+	}
+	if !lastModified.IsZero() {
+		response.LastModified = lastModified.UTC().Format(http.TimeFormat)
+	}
+	if notModified(conditional, etag, lastModified) {
+		response.Status = http.StatusNotModified
+	}
+	return response, nil
+}
+
+// entityTag returns the strong ETag of a response body: the quoted SHA-256 of its JSON
+// encoding, which includes the updatedAt of every server version in it
+func entityTag(body any) (string, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:]) + `"`, nil
+}
+
+// notModified evaluates If-None-Match, or If-Modified-Since in its absence, as RFC 9110
+// section 13.2.2 defines for GET requests
+func notModified(conditional *ConditionalInput, etag string, lastModified time.Time) bool {
+	if conditional.IfNoneMatch != "" {
+		for _, tag := range strings.Split(conditional.IfNoneMatch, ",") {
+			// If-None-Match uses weak comparison
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if conditional.IfModifiedSince == "" || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(conditional.IfModifiedSince)
+	if err != nil {
+		// Invalid dates are ignored
+		return false
+	}
+	// HTTP dates have a resolution of one second
+	return !lastModified.Truncate(time.Second).After(since)
+}
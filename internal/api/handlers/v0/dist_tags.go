@@ -0,0 +1,135 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// DistTagsInput represents the input for listing the dist-tags of a server
+type DistTagsInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+}
+
+// SetDistTagInput represents the input for creating or moving a dist-tag
+type SetDistTagInput struct {
+	Authorization string               `header:"Authorization" doc:"Registry JWT token with publish permissions for the server" required:"true"`
+	ServerName    string               `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Tag           string               `path:"tag" doc:"Dist-tag name" example:"next"`
+	Body          apiv0.DistTagRequest `body:""`
+}
+
+// DeleteDistTagInput represents the input for removing a dist-tag
+type DeleteDistTagInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permissions for the server" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Tag           string `path:"tag" doc:"Dist-tag name" example:"next"`
+}
+
+// RegisterDistTagEndpoints registers the dist-tag endpoints with a custom path prefix
+func RegisterDistTagEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	// List dist-tags endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-dist-tags" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/dist-tags",
+		Summary:     "Get MCP server dist-tags",
+		Description: "Get the named dist-tags of an MCP server (e.g. latest, next, beta) and the versions they point at.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *DistTagsInput) (*Response[apiv0.DistTagsResponse], error) {
+		// URL-decode the server name
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		tags, err := registry.GetDistTags(ctx, serverName)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server dist-tags", err)
+		}
+
+		return &Response[apiv0.DistTagsResponse]{
+			Body: apiv0.DistTagsResponse{DistTags: tags},
+		}, nil
+	})
+
+	// Set dist-tag endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "set-server-dist-tag" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/servers/{serverName}/dist-tags/{tag}",
+		Summary:     "Set MCP server dist-tag",
+		Description: "Create or move a named dist-tag so that it points at a published version. Setting 'latest' changes which version the registry reports as latest.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *SetDistTagInput) (*Response[apiv0.DistTagsResponse], error) {
+		serverName, err := authorizePublisher(ctx, jwtManager, input.Authorization, input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+
+		tags, err := registry.SetDistTag(ctx, serverName, input.Tag, input.Body.Version)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server version not found")
+			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Failed to set dist-tag", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to set dist-tag", err)
+		}
+
+		return &Response[apiv0.DistTagsResponse]{
+			Body: apiv0.DistTagsResponse{DistTags: tags},
+		}, nil
+	})
+
+	// Delete dist-tag endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-server-dist-tag" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/servers/{serverName}/dist-tags/{tag}",
+		Summary:     "Delete MCP server dist-tag",
+		Description: "Remove a named dist-tag from an MCP server. The 'latest' tag cannot be removed.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *DeleteDistTagInput) (*Response[apiv0.DistTagsResponse], error) {
+		serverName, err := authorizePublisher(ctx, jwtManager, input.Authorization, input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+
+		tags, err := registry.DeleteDistTag(ctx, serverName, input.Tag)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Dist-tag not found")
+			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Failed to delete dist-tag", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to delete dist-tag", err)
+		}
+
+		return &Response[apiv0.DistTagsResponse]{
+			Body: apiv0.DistTagsResponse{DistTags: tags},
+		}, nil
+	})
+}
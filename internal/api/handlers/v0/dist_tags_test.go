@@ -0,0 +1,182 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestDistTagEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	serverName := "io.github.testuser/tagged-server"
+	for _, version := range []string{"1.0.0", "2.0.0-beta.1"} {
+		_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        serverName,
+			Description: "Server with dist-tags",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterDistTagEndpoints(api, "/v0", registryService, cfg)
+
+	ownerToken, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "testuser",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.testuser/*"},
+		},
+	})
+	require.NoError(t, err)
+
+	otherToken, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "otheruser",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.otheruser/*"},
+		},
+	})
+	require.NoError(t, err)
+
+	tagURL := func(tag string) string {
+		return "/v0/servers/" + url.PathEscape(serverName) + "/dist-tags/" + tag
+	}
+
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		token          string
+		body           any
+		expectedStatus int
+		expectedTags   map[string]string
+	}{
+		{
+			name:           "list tags includes latest",
+			method:         http.MethodGet,
+			path:           "/v0/servers/" + url.PathEscape(serverName) + "/dist-tags",
+			expectedStatus: http.StatusOK,
+			expectedTags:   map[string]string{"latest": "1.0.0"},
+		},
+		{
+			name:           "set tag without permission",
+			method:         http.MethodPut,
+			path:           tagURL("next"),
+			token:          otherToken,
+			body:           apiv0.DistTagRequest{Version: "2.0.0-beta.1"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "set tag to unknown version",
+			method:         http.MethodPut,
+			path:           tagURL("next"),
+			token:          ownerToken,
+			body:           apiv0.DistTagRequest{Version: "3.0.0"},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "set tag that looks like a version",
+			method:         http.MethodPut,
+			path:           tagURL("v1.0.0"),
+			token:          ownerToken,
+			body:           apiv0.DistTagRequest{Version: "1.0.0"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "set tag",
+			method:         http.MethodPut,
+			path:           tagURL("next"),
+			token:          ownerToken,
+			body:           apiv0.DistTagRequest{Version: "2.0.0-beta.1"},
+			expectedStatus: http.StatusOK,
+			expectedTags:   map[string]string{"latest": "1.0.0", "next": "2.0.0-beta.1"},
+		},
+		{
+			name:           "delete latest tag",
+			method:         http.MethodDelete,
+			path:           tagURL("latest"),
+			token:          ownerToken,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "delete unknown tag",
+			method:         http.MethodDelete,
+			path:           tagURL("beta"),
+			token:          ownerToken,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var body []byte
+			if tc.body != nil {
+				body, err = json.Marshal(tc.body)
+				require.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(tc.method, tc.path, bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+
+			if tc.expectedTags != nil {
+				var resp apiv0.DistTagsResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Equal(t, tc.expectedTags, resp.DistTags)
+			}
+		})
+	}
+
+	t.Run("resolve tag through version endpoint", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape(serverName)+"/versions/next", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp apiv0.ServerResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, "2.0.0-beta.1", resp.Server.Version)
+		assert.False(t, resp.Meta.Official.IsLatest)
+	})
+}
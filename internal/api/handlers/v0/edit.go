@@ -22,6 +22,7 @@ type EditServerInput struct {
 	ServerName    string           `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
 	Version       string           `path:"version" doc:"URL-encoded version to edit" example:"1.0.0"`
 	Status        string           `query:"status" doc:"New status for the server (active, deprecated, deleted)" required:"false" enum:"active,deprecated,deleted"`
+	IfMatch       string           `header:"If-Match" doc:"Only apply the edit if this is still the ETag or the quoted content digest of the server version, to prevent lost updates" required:"false" example:"\"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08\""`
 	Body          apiv0.ServerJSON `body:""`
 }
 
@@ -35,7 +36,7 @@ func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.Reg
 		Method:      http.MethodPut,
 		Path:        pathPrefix + "/servers/{serverName}/versions/{version}",
 		Summary:     "Edit MCP server",
-		Description: "Update a specific version of an existing MCP server (admin only).",
+		Description: "Update a specific version of an existing MCP server (admin only). The previous content stays retrievable as a revision.",
 		Tags:        []string{"admin"},
 		Security: []map[string][]string{
 			{"bearer": {}},
@@ -67,8 +68,9 @@ func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.Reg
 			return nil, huma.Error400BadRequest("Invalid version encoding", err)
 		}
 
-		// Get current server to check permissions against existing name
-		currentServer, err := registry.GetServerByNameAndVersion(ctx, serverName, version)
+		// Get current server to check permissions against existing name. It is read from the
+		// primary, as the status checks below must not act on a replica's stale copy.
+		currentServer, err := registry.GetServerByNameAndVersion(database.WithPrimaryReads(ctx), serverName, version)
 		if err != nil {
 			if errors.Is(err, database.ErrNotFound) {
 				return nil, huma.Error404NotFound("Server not found")
@@ -102,9 +104,8 @@ func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.Reg
 				return nil, huma.Error400BadRequest("Cannot change status of deleted server. Deleted servers cannot be undeleted.")
 			}
 
-			// For now, only allow status changes for admins
-			// Future: Implement logic to allow server authors to change active <-> deprecated
-			// but only admins can set to deleted
+			// Publishers can deprecate and undeprecate their own versions through the
+			// status endpoint; this admin edit endpoint can also delete versions
 		}
 
 		// Update the server using the service
@@ -112,11 +113,14 @@ func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.Reg
 		if input.Status != "" {
 			statusPtr = &input.Status
 		}
-		updatedServer, err := registry.UpdateServer(ctx, serverName, version, &input.Body, statusPtr)
+		updatedServer, err := registry.UpdateServer(ctx, serverName, version, &input.Body, statusPtr, editorIdentity(claims), ifMatchDigest(input.IfMatch, currentServer))
 		if err != nil {
 			if errors.Is(err, database.ErrNotFound) {
 				return nil, huma.Error404NotFound("Server not found")
 			}
+			if errors.Is(err, database.ErrPreconditionFailed) {
+				return nil, huma.Error412PreconditionFailed("Server has been modified since the If-Match content digest was read")
+			}
 			return nil, huma.Error400BadRequest("Failed to edit server", err)
 		}
 
@@ -125,3 +129,27 @@ func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.Reg
 		}, nil
 	})
 }
+
+// ifMatchDigest returns the content digest an If-Match header requires, or "" for any content.
+// The header holds the quoted content digest, or the ETag of the server version as read by a
+// GET, which stands for the content digest of current if it is still current. A weak tag
+// never matches, as If-Match uses strong comparison.
+func ifMatchDigest(header string, current *apiv0.ServerResponse) string {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return ""
+	}
+	if etag, err := entityTag(current); err == nil && header == etag && current.Meta.Official != nil {
+		return current.Meta.Official.ContentDigest
+	}
+	return strings.Trim(header, `"`)
+}
+
+// editorIdentity identifies the holder of a Registry JWT in the edit history,
+// e.g. "github-at:octocat"
+func editorIdentity(claims *auth.JWTClaims) string {
+	if claims.AuthMethodSubject == "" {
+		return string(claims.AuthMethod)
+	}
+	return string(claims.AuthMethod) + ":" + claims.AuthMethodSubject
+}
@@ -87,7 +87,7 @@ func TestEditServerEndpoint(t *testing.T) {
 	require.NoError(t, err)
 
 	// Set the server to deleted status
-	_, err = registryService.UpdateServer(context.Background(), deletedServer.Name, deletedServer.Version, deletedServer, stringPtr(string(model.StatusDeleted)))
+	_, err = registryService.UpdateServer(context.Background(), deletedServer.Name, deletedServer.Version, deletedServer, stringPtr(string(model.StatusDeleted)), "", "")
 	require.NoError(t, err)
 
 	// Create a server with build metadata for URL encoding test
@@ -461,7 +461,7 @@ func TestEditServerEndpointEdgeCases(t *testing.T) {
 				Name:        server.name,
 				Description: "Test server for editing",
 				Version:     server.version,
-			}, stringPtr(string(server.status)))
+			}, stringPtr(string(server.status)), "", "")
 			require.NoError(t, err)
 		}
 	}
@@ -657,6 +657,93 @@ func TestEditServerEndpointEdgeCases(t *testing.T) {
 	})
 }
 
+func TestEditServerEndpointIfMatch(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	server := &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.testuser/if-match-server",
+		Description: "Original description",
+		Version:     "1.0.0",
+	}
+	created, err := registryService.CreateServer(context.Background(), server)
+	require.NoError(t, err)
+	originalDigest := created.Meta.Official.ContentDigest
+	require.NotEmpty(t, originalDigest)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterEditEndpoints(api, "/v0", registryService, cfg)
+	v0.RegisterServersEndpoints(api, "/v0", registryService)
+
+	tokenResponse, err := auth.NewJWTManager(cfg).GenerateTokenResponse(context.Background(), auth.JWTClaims{
+		AuthMethod: auth.MethodNone,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionEdit, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+
+	edit := func(t *testing.T, description, ifMatch string) *httptest.ResponseRecorder {
+		t.Helper()
+		body := *server
+		body.Description = description
+		requestBody, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPut, "/v0/servers/"+url.PathEscape(server.Name)+"/versions/1.0.0", bytes.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+tokenResponse.RegistryToken)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	// The first admin edits the content they read
+	w := edit(t, "First admin's edit", `"`+originalDigest+`"`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response apiv0.ServerResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.NotEqual(t, originalDigest, response.Meta.Official.ContentDigest)
+
+	// The second admin read the same content, so their edit would overwrite the first one
+	w = edit(t, "Second admin's edit", `"`+originalDigest+`"`)
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+	current, err := registryService.GetServerByNameAndVersion(context.Background(), server.Name, "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "First admin's edit", current.Server.Description)
+
+	// Edits based on the current content, or without a precondition, apply
+	w = edit(t, "Second admin's edit", `"`+response.Meta.Official.ContentDigest+`"`)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = edit(t, "Unconditional edit", "*")
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// The ETag of a GET response works the same way
+	req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape(server.Name)+"/versions/1.0.0", nil)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+
+	w = edit(t, "Edit based on a GET", etag)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = edit(t, "Edit based on a stale GET", etag)
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+}
+
 // Helper function
 func stringPtr(s string) *string {
 	return &s
@@ -0,0 +1,55 @@
+package v0
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// unavailableRetryAfter is the Retry-After, in seconds, of 503 Service Unavailable responses
+const unavailableRetryAfter = 5
+
+// newError is huma's default error constructor, which reportDatabaseTimeouts extends
+var newError = huma.NewError
+
+// huma only allows customizing its errors through its NewError package variable
+func init() {
+	huma.NewError = reportDatabaseTimeouts
+}
+
+// reportDatabaseTimeouts creates huma errors like huma.NewError, except that errors caused by
+// database statement or lock timeouts become 503 Service Unavailable with a Retry-After header
+// instead of the status the handler chose, usually 500 or 400: the request was valid, and is
+// worth retrying once the database is less busy.
+func reportDatabaseTimeouts(status int, msg string, errs ...error) huma.StatusError {
+	for _, err := range errs {
+		if database.IsTimeout(err) {
+			return serviceUnavailable("The database timed out, please retry later")
+		}
+	}
+	return newError(status, msg, errs...)
+}
+
+// serviceUnavailable creates a 503 Service Unavailable error with a Retry-After header, for
+// requests that failed because the registry is busy and can be retried unchanged
+func serviceUnavailable(detail string) huma.StatusError {
+	return &unavailableError{
+		ErrorModel: &huma.ErrorModel{
+			Title:  http.StatusText(http.StatusServiceUnavailable),
+			Status: http.StatusServiceUnavailable,
+			Detail: detail,
+		},
+	}
+}
+
+// unavailableError is a 503 Service Unavailable error with a Retry-After header
+type unavailableError struct {
+	*huma.ErrorModel
+}
+
+// GetHeaders returns the Retry-After header
+func (e *unavailableError) GetHeaders() http.Header {
+	return http.Header{"Retry-After": []string{strconv.Itoa(unavailableRetryAfter)}}
+}
@@ -0,0 +1,72 @@
+package v0_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+
+	// Imported for its customization of huma errors
+	_ "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+)
+
+func TestDatabaseTimeoutErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{
+			name:           "statement timeout",
+			err:            huma.Error500InternalServerError("Failed to get registry list", fmt.Errorf("failed to query servers: %w", &pgconn.PgError{Code: "57014"})),
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "lock timeout",
+			err:            huma.Error400BadRequest("Failed to publish server", fmt.Errorf("failed to acquire publish lock: %w", &pgconn.PgError{Code: "55P03"})),
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "other database error",
+			err:            huma.Error500InternalServerError("Failed to get registry list", &pgconn.PgError{Code: "42P01"}),
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "other error",
+			err:            huma.Error400BadRequest("Failed to publish server", fmt.Errorf("invalid version")),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+			huma.Register(api, huma.Operation{
+				OperationID: "fail",
+				Method:      http.MethodGet,
+				Path:        "/fail",
+			}, func(_ context.Context, _ *struct{}) (*struct{}, error) {
+				return nil, tt.err
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/fail", nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusServiceUnavailable {
+				assert.Equal(t, "5", w.Header().Get("Retry-After"))
+				assert.NotContains(t, w.Body.String(), "57014")
+			} else {
+				assert.Empty(t, w.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
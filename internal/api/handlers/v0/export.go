@@ -0,0 +1,155 @@
+package v0
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ExportServersInput represents the input for exporting servers
+type ExportServersInput struct {
+	ServerFilterInput
+	AcceptEncoding string `header:"Accept-Encoding" doc:"Send gzip to receive a gzip-compressed export" required:"false"`
+}
+
+// RegisterExportEndpoint registers the bulk server export endpoint with a custom path prefix
+func RegisterExportEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	// Each export holds a database connection and snapshot for as long as the client reads it
+	var exports chan struct{}
+	if cfg.ExportMaxConcurrent > 0 {
+		exports = make(chan struct{}, cfg.ExportMaxConcurrent)
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "export-servers" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/export",
+		Summary:     "Export MCP servers",
+		Description: "Stream every server version matching the same filters as the server list, with registry metadata, as newline-delimited JSON (one server response per line), gzip-compressed if the client accepts it. Use updated_since for incremental exports.",
+		Tags:        []string{"servers"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "Newline-delimited JSON, one server response per line",
+				Content: map[string]*huma.MediaType{
+					"application/x-ndjson": {},
+				},
+			},
+		},
+	}, func(_ context.Context, input *ExportServersInput) (*huma.StreamResponse, error) {
+		filter, err := buildServerFilter(&input.ServerFilterInput)
+		if err != nil {
+			return nil, err
+		}
+
+		if exports != nil {
+			select {
+			case exports <- struct{}{}:
+			default:
+				return nil, serviceUnavailable("Too many exports are in progress, please retry later")
+			}
+		}
+		compress := acceptsGzip(input.AcceptEncoding)
+
+		return &huma.StreamResponse{
+			Body: func(hctx huma.Context) {
+				if exports != nil {
+					defer func() { <-exports }()
+				}
+
+				// Bound the export, including writes to clients that read slowly, so that it
+				// releases its database connection
+				ctx := hctx.Context()
+				if cfg.ExportTimeout > 0 {
+					var cancel context.CancelFunc
+					ctx, cancel = context.WithTimeout(ctx, cfg.ExportTimeout)
+					defer cancel()
+					_, w := humago.Unwrap(hctx)
+					if err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(cfg.ExportTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+						log.Printf("failed to set server export write deadline: %v", err)
+					}
+				}
+
+				var body io.Writer = hctx.BodyWriter()
+				var gz *gzip.Writer
+				if compress {
+					gz = gzip.NewWriter(body)
+					body = gz
+				}
+				encoder := json.NewEncoder(body)
+				encoder.SetEscapeHTML(false)
+
+				exported := 0
+				err := registry.ExportServers(ctx, filter, func(server *apiv0.ServerResponse) error {
+					if exported == 0 {
+						setExportHeaders(hctx, compress)
+					}
+					exported++
+					return encoder.Encode(server)
+				})
+				if err != nil {
+					log.Printf("server export failed after %d server versions: %v", exported, err)
+					if exported == 0 {
+						// Nothing has been written yet, so the status can still report the failure
+						hctx.SetStatus(http.StatusInternalServerError)
+						return
+					}
+					if compress {
+						// Leave the gzip stream unterminated so clients notice the export is incomplete
+						return
+					}
+					// Abort the response so clients notice the export is incomplete
+					panic(http.ErrAbortHandler)
+				}
+
+				if exported == 0 {
+					setExportHeaders(hctx, compress)
+				}
+				if gz != nil {
+					if err := gz.Close(); err != nil {
+						log.Printf("failed to finish server export: %v", err)
+					}
+				}
+			},
+		}, nil
+	})
+}
+
+// acceptsGzip is whether an Accept-Encoding header allows a gzip-encoded response
+func acceptsGzip(acceptEncoding string) bool {
+	for _, coding := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(coding, ";")
+		name = strings.TrimSpace(name)
+		if !strings.EqualFold(name, "gzip") && name != "*" {
+			continue
+		}
+		// A q-value of 0 rules the coding out
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if weight, err := strconv.ParseFloat(q, 64); err != nil || weight == 0 {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func setExportHeaders(hctx huma.Context, compress bool) {
+	hctx.SetHeader("Content-Type", "application/x-ndjson")
+	hctx.SetHeader("Vary", "Accept-Encoding")
+	if compress {
+		hctx.SetHeader("Content-Encoding", "gzip")
+	}
+}
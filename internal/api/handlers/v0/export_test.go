@@ -0,0 +1,165 @@
+package v0_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportServersEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/export-server",
+			Description: "Export test server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterExportEndpoint(api, "/v0", registryService, config.NewConfig())
+
+	exportWithEncoding := func(t *testing.T, query, acceptEncoding string) []apiv0.ServerResponse {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/export"+query, nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+		assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+
+		var body io.Reader = w.Body
+		if acceptEncoding == "gzip" {
+			assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+			gz, err := gzip.NewReader(w.Body)
+			require.NoError(t, err)
+			body = gz
+		} else {
+			assert.Empty(t, w.Header().Get("Content-Encoding"))
+		}
+
+		var servers []apiv0.ServerResponse
+		scanner := bufio.NewScanner(body)
+		for scanner.Scan() {
+			var server apiv0.ServerResponse
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &server))
+			servers = append(servers, server)
+		}
+		require.NoError(t, scanner.Err())
+		return servers
+	}
+	export := func(t *testing.T, query string) []apiv0.ServerResponse {
+		t.Helper()
+		return exportWithEncoding(t, query, "gzip")
+	}
+
+	t.Run("full export", func(t *testing.T) {
+		servers := export(t, "")
+		require.Len(t, servers, 2)
+		assert.Equal(t, "1.0.0", servers[0].Server.Version)
+		assert.Equal(t, "1.1.0", servers[1].Server.Version)
+		require.NotNil(t, servers[1].Meta.Official)
+		assert.True(t, servers[1].Meta.Official.IsLatest)
+	})
+
+	t.Run("incremental export", func(t *testing.T) {
+		since := time.Now().Add(time.Minute).UTC().Format(time.RFC3339)
+		assert.Empty(t, export(t, "?updated_since="+url.QueryEscape(since)))
+	})
+
+	t.Run("uncompressed unless gzip is accepted", func(t *testing.T) {
+		assert.Len(t, exportWithEncoding(t, "", ""), 2)
+		assert.Len(t, exportWithEncoding(t, "", "gzip;q=0, identity"), 2)
+	})
+
+	t.Run("invalid updated_since", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/export?updated_since=yesterday", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+// blockingExportRegistry holds every export open until release is closed
+type blockingExportRegistry struct {
+	service.RegistryService
+	started chan struct{}
+	release chan struct{}
+}
+
+func (r *blockingExportRegistry) ExportServers(ctx context.Context, _ *database.ServerFilter, _ func(*apiv0.ServerResponse) error) error {
+	r.started <- struct{}{}
+	select {
+	case <-r.release:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestExportServersEndpointLimits(t *testing.T) {
+	t.Run("concurrent exports", func(t *testing.T) {
+		registry := &blockingExportRegistry{started: make(chan struct{}, 1), release: make(chan struct{})}
+		mux := http.NewServeMux()
+		api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+		v0.RegisterExportEndpoint(api, "/v0", registry, &config.Config{ExportMaxConcurrent: 1})
+
+		done := make(chan int)
+		go func() {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/servers/export", nil))
+			done <- w.Code
+		}()
+		<-registry.started
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/servers/export", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+		// Once the first export finishes, the next one may start
+		close(registry.release)
+		assert.Equal(t, http.StatusOK, <-done)
+		w = httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/servers/export", nil))
+		<-registry.started
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		registry := &blockingExportRegistry{started: make(chan struct{}, 1), release: make(chan struct{})}
+		mux := http.NewServeMux()
+		api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+		v0.RegisterExportEndpoint(api, "/v0", registry, &config.Config{ExportTimeout: 50 * time.Millisecond})
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/servers/export", nil))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
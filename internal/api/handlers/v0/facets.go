@@ -0,0 +1,42 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ServerFacetsInput represents the input for counting servers per facet
+type ServerFacetsInput struct {
+	ServerFilterInput
+}
+
+// RegisterFacetsEndpoint registers the server facet counts endpoint with a custom path prefix
+func RegisterFacetsEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-facets" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/facets",
+		Summary:     "Get MCP server facet counts",
+		Description: "Count the servers matching the same filters as the server list per status, namespace, package registry type and transport type, e.g. to render filter sidebars.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerFacetsInput) (*Response[apiv0.ServerFacetsResponse], error) {
+		filter, err := buildServerFilter(&input.ServerFilterInput)
+		if err != nil {
+			return nil, err
+		}
+
+		facets, err := registry.GetServerFacets(ctx, filter)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get server facets", err)
+		}
+
+		return &Response[apiv0.ServerFacetsResponse]{
+			Body: *facets,
+		}, nil
+	})
+}
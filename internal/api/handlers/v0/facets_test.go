@@ -0,0 +1,127 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetServerFacetsEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	servers := []*apiv0.ServerJSON{
+		{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/facets-npm",
+			Description: "Facets test server",
+			Version:     "1.0.0",
+			Packages: []model.Package{
+				{RegistryType: model.RegistryTypeNPM, Identifier: "@example/facets-npm", Version: "1.0.0", Transport: model.Transport{Type: model.TransportTypeStdio}},
+			},
+		},
+		{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/facets-remote",
+			Description: "Facets test server",
+			Version:     "1.0.0",
+			Remotes: []model.Transport{
+				{Type: model.TransportTypeStreamableHTTP, URL: "https://example.com/mcp"},
+			},
+		},
+		{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "org.example/facets-deleted",
+			Description: "Facets test server",
+			Version:     "1.0.0",
+		},
+	}
+	for _, server := range servers {
+		_, err := registryService.CreateServer(ctx, server)
+		require.NoError(t, err)
+	}
+	_, err := registryService.UpdateServer(ctx, "org.example/facets-deleted", "1.0.0", servers[2], stringPtr(string(model.StatusDeleted)), "", "")
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterFacetsEndpoint(api, "/v0", registryService)
+
+	tests := []struct {
+		name           string
+		queryParams    string
+		expectedStatus int
+		expected       *apiv0.ServerFacetsResponse
+	}{
+		{
+			name:           "deleted versions are hidden by default",
+			queryParams:    "",
+			expectedStatus: http.StatusOK,
+			expected: &apiv0.ServerFacetsResponse{
+				Total:         2,
+				Status:        map[string]int{"active": 2},
+				Namespace:     map[string]int{"com.example": 2},
+				RegistryType:  map[string]int{"npm": 1},
+				TransportType: map[string]int{"stdio": 1, "streamable-http": 1},
+			},
+		},
+		{
+			name:           "list filters apply",
+			queryParams:    "?has_remotes=true&include_deleted=true",
+			expectedStatus: http.StatusOK,
+			expected: &apiv0.ServerFacetsResponse{
+				Total:         1,
+				Status:        map[string]int{"active": 1},
+				Namespace:     map[string]int{"com.example": 1},
+				RegistryType:  map[string]int{},
+				TransportType: map[string]int{"streamable-http": 1},
+			},
+		},
+		{
+			name:           "include deleted",
+			queryParams:    "?include_deleted=true&namespace=org.example",
+			expectedStatus: http.StatusOK,
+			expected: &apiv0.ServerFacetsResponse{
+				Total:         1,
+				Status:        map[string]int{"deleted": 1},
+				Namespace:     map[string]int{"org.example": 1},
+				RegistryType:  map[string]int{},
+				TransportType: map[string]int{},
+			},
+		},
+		{
+			name:           "invalid filter",
+			queryParams:    "?transport_type=carrier-pigeon",
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/servers/facets"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+
+			if tt.expected == nil {
+				return
+			}
+			var facets apiv0.ServerFacetsResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &facets))
+			assert.Equal(t, *tt.expected, facets)
+		})
+	}
+}
@@ -2,12 +2,17 @@ package v0
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 )
@@ -54,8 +59,15 @@ func RegisterPublishEndpoint(api huma.API, pathPrefix string, registry service.R
 		}
 
 		// Publish the server with extensions
-		publishedServer, err := registry.CreateServer(ctx, &input.Body)
+		publishedServer, err := registry.PublishServer(ctx, &input.Body, editorIdentity(claims))
 		if err != nil {
+			var quotaErr *service.QuotaExceededError
+			if errors.As(err, &quotaErr) {
+				return nil, quotaExceeded(quotaErr)
+			}
+			if errors.Is(err, database.ErrNamespaceBlocked) {
+				return nil, huma.Error403Forbidden("Failed to publish server", err)
+			}
 			return nil, huma.Error400BadRequest("Failed to publish server", err)
 		}
 
@@ -66,6 +78,17 @@ func RegisterPublishEndpoint(api huma.API, pathPrefix string, registry service.R
 	})
 }
 
+// quotaExceeded reports an exceeded publish quota as 429 Too Many Requests, with a Retry-After
+// header if the quota resets over time
+func quotaExceeded(quotaErr *service.QuotaExceededError) error {
+	err := huma.Error429TooManyRequests(quotaErr.Error())
+	if quotaErr.ResetAt.IsZero() {
+		return err
+	}
+	retryAfter := max(int(time.Until(quotaErr.ResetAt).Seconds())+1, 1)
+	return huma.ErrorWithHeaders(err, http.Header{"Retry-After": []string{strconv.Itoa(retryAfter)}})
+}
+
 // buildPermissionErrorMessage creates a detailed error message showing what permissions
 // the user has and what they're trying to publish
 func buildPermissionErrorMessage(attemptedResource string, permissions []auth.Permission) string {
@@ -91,3 +114,32 @@ func buildPermissionErrorMessage(attemptedResource string, permissions []auth.Pe
 
 	return errorMsg
 }
+
+// authorizePublisher validates the bearer token and checks that it grants publish
+// permission for the server, returning the URL-decoded server name
+func authorizePublisher(ctx context.Context, jwtManager *auth.JWTManager, authHeader, encodedServerName string) (string, error) {
+	// Extract bearer token
+	const bearerPrefix = "Bearer "
+	if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		return "", huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
+	}
+	token := authHeader[len(bearerPrefix):]
+
+	// Validate Registry JWT token
+	claims, err := jwtManager.ValidateToken(ctx, token)
+	if err != nil {
+		return "", huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+	}
+
+	// URL-decode the server name
+	serverName, err := url.PathUnescape(encodedServerName)
+	if err != nil {
+		return "", huma.Error400BadRequest("Invalid server name encoding", err)
+	}
+
+	if !jwtManager.HasPermission(serverName, auth.PermissionActionPublish, claims.Permissions) {
+		return "", huma.Error403Forbidden(buildPermissionErrorMessage(serverName, claims.Permissions))
+	}
+
+	return serverName, nil
+}
@@ -0,0 +1,162 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListPublishQuotaOverridesInput represents the input for listing publish quota overrides
+type ListPublishQuotaOverridesInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with registry-wide edit permissions" required:"true"`
+}
+
+// PublishQuotaOverrideInput represents the input for removing a publish quota override
+type PublishQuotaOverrideInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with registry-wide edit permissions" required:"true"`
+	Quota         string `path:"quota" doc:"Quota to override" enum:"servers_per_namespace,versions_per_day,publishes_per_hour"`
+	Subject       string `path:"subject" doc:"URL-encoded namespace (servers_per_namespace), server name (versions_per_day) or publisher identity (publishes_per_hour)" example:"com.example%2Fmy-server"`
+}
+
+// SetPublishQuotaOverrideInput represents the input for overriding a publish quota
+type SetPublishQuotaOverrideInput struct {
+	Authorization string                            `header:"Authorization" doc:"Registry JWT token with registry-wide edit permissions" required:"true"`
+	Quota         string                            `path:"quota" doc:"Quota to override" enum:"servers_per_namespace,versions_per_day,publishes_per_hour"`
+	Subject       string                            `path:"subject" doc:"URL-encoded namespace (servers_per_namespace), server name (versions_per_day) or publisher identity (publishes_per_hour)" example:"com.example%2Fmy-server"`
+	Body          apiv0.PublishQuotaOverrideRequest `body:""`
+}
+
+// RegisterQuotaEndpoints registers the publish quota override endpoints with a custom path prefix
+func RegisterQuotaEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	// List publish quota overrides endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "list-publish-quota-overrides" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/quotas",
+		Summary:     "List publish quota overrides",
+		Description: "List the publish quotas that admins have overridden for specific namespaces, servers and publishers (admin only).",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListPublishQuotaOverridesInput) (*Response[apiv0.PublishQuotaOverrideListResponse], error) {
+		if _, err := authorizeRegistryAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		overrides, err := registry.ListPublishQuotaOverrides(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list publish quota overrides", err)
+		}
+
+		body := apiv0.PublishQuotaOverrideListResponse{Overrides: make([]apiv0.PublishQuotaOverride, 0, len(overrides))}
+		for _, override := range overrides {
+			body.Overrides = append(body.Overrides, *override)
+		}
+
+		return &Response[apiv0.PublishQuotaOverrideListResponse]{
+			Body: body,
+		}, nil
+	})
+
+	// Set publish quota override endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "set-publish-quota-override" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/quotas/{quota}/{subject}",
+		Summary:     "Override publish quota",
+		Description: "Replace the configured publish quota for a namespace, server or publisher (admin only). A limit of 0 makes the quota unlimited for the subject.",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *SetPublishQuotaOverrideInput) (*Response[apiv0.PublishQuotaOverride], error) {
+		if _, err := authorizeRegistryAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		subject, err := url.PathUnescape(input.Subject)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid subject encoding", err)
+		}
+
+		override, err := registry.SetPublishQuotaOverride(ctx, database.PublishQuota(input.Quota), subject, &input.Body)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Failed to override publish quota", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to override publish quota", err)
+		}
+
+		return &Response[apiv0.PublishQuotaOverride]{
+			Body: *override,
+		}, nil
+	})
+
+	// Delete publish quota override endpoint
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-publish-quota-override" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/quotas/{quota}/{subject}",
+		DefaultStatus: http.StatusNoContent,
+		Summary:       "Remove publish quota override",
+		Description:   "Restore the configured publish quota for a namespace, server or publisher (admin only).",
+		Tags:          []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *PublishQuotaOverrideInput) (*struct{}, error) {
+		if _, err := authorizeRegistryAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		subject, err := url.PathUnescape(input.Subject)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid subject encoding", err)
+		}
+
+		if err := registry.DeletePublishQuotaOverride(ctx, database.PublishQuota(input.Quota), subject); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Publish quota override not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to remove publish quota override", err)
+		}
+
+		return nil, nil
+	})
+}
+
+// authorizeRegistryAdmin validates the bearer token and checks that it grants edit permission
+// for every server, as registry-wide settings are reserved for registry admins
+func authorizeRegistryAdmin(ctx context.Context, jwtManager *auth.JWTManager, authHeader string) (*auth.JWTClaims, error) {
+	// Extract bearer token
+	const bearerPrefix = "Bearer "
+	if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
+	}
+	token := authHeader[len(bearerPrefix):]
+
+	// Validate Registry JWT token
+	claims, err := jwtManager.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+	}
+
+	// Only a "*" edit permission matches the "*" resource
+	if !jwtManager.HasPermission("*", auth.PermissionActionEdit, claims.Permissions) {
+		return nil, huma.Error403Forbidden("You do not have registry-wide edit permissions")
+	}
+
+	return claims, nil
+}
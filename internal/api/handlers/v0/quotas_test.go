@@ -0,0 +1,148 @@
+package v0_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestPublishQuotaEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey:              hex.EncodeToString(testSeed),
+		EnableRegistryValidation:   false,
+		PublishQuotaVersionsPerDay: 5,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, cfg)
+	v0.RegisterQuotaEndpoints(api, "/v0", registryService, cfg)
+
+	adminToken, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubOIDC,
+		AuthMethodSubject: "admin",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionEdit, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+
+	publisherToken, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "testuser",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.testuser/*"},
+			{Action: auth.PermissionActionEdit, ResourcePattern: "io.github.testuser/*"},
+		},
+	})
+	require.NoError(t, err)
+
+	serverName := "io.github.testuser/quota-server"
+	quotaPath := "/v0/quotas/versions_per_day/" + url.PathEscape(serverName)
+
+	request := func(t *testing.T, method, path, token string, body any) *httptest.ResponseRecorder {
+		t.Helper()
+		var payload []byte
+		if body != nil {
+			payload, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	publish := func(t *testing.T, version string) *httptest.ResponseRecorder {
+		t.Helper()
+		return request(t, http.MethodPost, "/v0/publish", publisherToken, apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        serverName,
+			Description: "Quota test server",
+			Version:     version,
+		})
+	}
+
+	t.Run("require registry-wide edit permissions", func(t *testing.T) {
+		w := request(t, http.MethodGet, "/v0/quotas", publisherToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = request(t, http.MethodPut, quotaPath, publisherToken, apiv0.PublishQuotaOverrideRequest{Limit: 0})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("override lowers the quota and publishes over it get 429", func(t *testing.T) {
+		w := request(t, http.MethodPut, quotaPath, adminToken, apiv0.PublishQuotaOverrideRequest{Limit: 1, Reason: "Testing"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var override apiv0.PublishQuotaOverride
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &override))
+		assert.Equal(t, "versions_per_day", override.Quota)
+		assert.Equal(t, serverName, override.Subject)
+		assert.Equal(t, 1, override.Limit)
+
+		w = publish(t, "1.0.0")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = publish(t, "1.0.1")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Contains(t, w.Body.String(), "The quota resets at")
+		retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+		require.NoError(t, err)
+		assert.InDelta(t, 24*60*60, retryAfter, 60)
+	})
+
+	t.Run("list overrides", func(t *testing.T) {
+		w := request(t, http.MethodGet, "/v0/quotas", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var list apiv0.PublishQuotaOverrideListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Overrides, 1)
+		assert.Equal(t, serverName, list.Overrides[0].Subject)
+		assert.Equal(t, "Testing", list.Overrides[0].Reason)
+	})
+
+	t.Run("invalid subject", func(t *testing.T) {
+		w := request(t, http.MethodPut, "/v0/quotas/versions_per_day/io.github.testuser", adminToken, apiv0.PublishQuotaOverrideRequest{Limit: 1})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("deleting the override restores the configured quota", func(t *testing.T) {
+		w := request(t, http.MethodDelete, quotaPath, adminToken, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = publish(t, "1.0.1")
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = request(t, http.MethodDelete, quotaPath, adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,173 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ServerRevisionsInput represents the input for listing the revisions of a server version
+type ServerRevisionsInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version    string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+}
+
+// ServerRevisionInput represents the input for getting a specific revision of a server version
+type ServerRevisionInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version    string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+	Revision   int    `path:"revision" minimum:"1" doc:"Revision number" example:"1"`
+}
+
+// RestoreServerRevisionInput represents the input for restoring a revision of a server version
+type RestoreServerRevisionInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with edit permissions" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version       string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+	Revision      int    `path:"revision" minimum:"1" doc:"Revision number to restore" example:"1"`
+}
+
+// RegisterRevisionEndpoints registers the edit history endpoints with a custom path prefix
+func RegisterRevisionEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	// List revisions endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-revisions" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/revisions",
+		Summary:     "Get MCP server version revisions",
+		Description: "Get the edit history of a specific version of an MCP server, oldest first. Revision 1 is the original publication.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerRevisionsInput) (*Response[apiv0.ServerRevisionListResponse], error) {
+		serverName, version, err := decodeServerVersionPath(input.ServerName, input.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		revisions, err := registry.ListServerRevisions(ctx, serverName, version)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server revisions", err)
+		}
+
+		revisionValues := make([]apiv0.ServerRevision, len(revisions))
+		for i, revision := range revisions {
+			revisionValues[i] = *revision
+		}
+
+		return &Response[apiv0.ServerRevisionListResponse]{
+			Body: apiv0.ServerRevisionListResponse{
+				Revisions: revisionValues,
+				Metadata: apiv0.Metadata{
+					Count: len(revisions),
+				},
+			},
+		}, nil
+	})
+
+	// Get specific revision endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-revision" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/revisions/{revision}",
+		Summary:     "Get MCP server version revision",
+		Description: "Get a specific revision of a specific version of an MCP server.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerRevisionInput) (*Response[apiv0.ServerRevision], error) {
+		serverName, version, err := decodeServerVersionPath(input.ServerName, input.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		revision, err := registry.GetServerRevision(ctx, serverName, version, input.Revision)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Revision not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server revision", err)
+		}
+
+		return &Response[apiv0.ServerRevision]{
+			Body: *revision,
+		}, nil
+	})
+
+	// Restore revision endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "restore-server-revision" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/revisions/{revision}/restore",
+		Summary:     "Restore MCP server version revision",
+		Description: "Make an earlier revision the current content of a specific version of an MCP server (admin only). The restore is recorded as a new revision.",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *RestoreServerRevisionInput) (*Response[apiv0.ServerResponse], error) {
+		// Extract bearer token
+		const bearerPrefix = "Bearer "
+		authHeader := input.Authorization
+		if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+			return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
+		}
+		token := authHeader[len(bearerPrefix):]
+
+		// Validate Registry JWT token
+		claims, err := jwtManager.ValidateToken(ctx, token)
+		if err != nil {
+			return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+		}
+
+		serverName, version, err := decodeServerVersionPath(input.ServerName, input.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		// Restoring is an edit, so it needs the same permissions as the edit endpoint
+		if !jwtManager.HasPermission(serverName, auth.PermissionActionEdit, claims.Permissions) {
+			return nil, huma.Error403Forbidden("You do not have edit permissions for this server")
+		}
+
+		restoredServer, err := registry.RestoreServerRevision(ctx, serverName, version, input.Revision, editorIdentity(claims))
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Revision not found")
+			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Failed to restore server revision", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to restore server revision", err)
+		}
+
+		return &Response[apiv0.ServerResponse]{
+			Body: *restoredServer,
+		}, nil
+	})
+}
+
+// decodeServerVersionPath URL-decodes the server name and version path parameters
+func decodeServerVersionPath(encodedServerName, encodedVersion string) (string, string, error) {
+	serverName, err := url.PathUnescape(encodedServerName)
+	if err != nil {
+		return "", "", huma.Error400BadRequest("Invalid server name encoding", err)
+	}
+
+	version, err := url.PathUnescape(encodedVersion)
+	if err != nil {
+		return "", "", huma.Error400BadRequest("Invalid version encoding", err)
+	}
+
+	return serverName, version, nil
+}
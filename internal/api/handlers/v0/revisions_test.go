@@ -0,0 +1,205 @@
+package v0_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestRevisionEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	serverName := "io.github.testuser/revised-server"
+	_, err = registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        serverName,
+		Description: "Original description",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	_, err = registryService.UpdateServer(context.Background(), serverName, "1.0.0", &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        serverName,
+		Description: "Edited description",
+		Version:     "1.0.0",
+	}, nil, "github-at:admin", "")
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterRevisionEndpoints(api, "/v0", registryService, cfg)
+
+	adminToken, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "admin",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionEdit, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+
+	publisherToken, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "testuser",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.testuser/*"},
+		},
+	})
+	require.NoError(t, err)
+
+	basePath := "/v0/servers/" + url.PathEscape(serverName) + "/versions/1.0.0/revisions"
+
+	t.Run("list revisions", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, basePath, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp apiv0.ServerRevisionListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Len(t, resp.Revisions, 2)
+		assert.Equal(t, 2, resp.Metadata.Count)
+
+		assert.Equal(t, 1, resp.Revisions[0].Revision)
+		assert.Equal(t, "Original description", resp.Revisions[0].Server.Description)
+		assert.Empty(t, resp.Revisions[0].EditedBy)
+
+		assert.Equal(t, 2, resp.Revisions[1].Revision)
+		assert.Equal(t, "Edited description", resp.Revisions[1].Server.Description)
+		assert.Equal(t, "github-at:admin", resp.Revisions[1].EditedBy)
+	})
+
+	t.Run("get revision", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, basePath+"/1", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp apiv0.ServerRevision
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, 1, resp.Revision)
+		assert.Equal(t, "Original description", resp.Server.Description)
+	})
+
+	t.Run("get unknown revision", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, basePath+"/99", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("list revisions of unknown version", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape(serverName)+"/versions/9.9.9/revisions", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("restore requires authentication", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, basePath+"/1/restore", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("restore requires edit permission", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, basePath+"/1/restore", nil)
+		req.Header.Set("Authorization", "Bearer "+publisherToken)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("restore unknown revision", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, basePath+"/99/restore", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("restore revision", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, basePath+"/1/restore", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp apiv0.ServerResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, "Original description", resp.Server.Description)
+
+		// The current content is restored
+		current, err := registryService.GetServerByNameAndVersion(context.Background(), serverName, "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, "Original description", current.Server.Description)
+
+		// The restore is appended to the history
+		revisions, err := registryService.ListServerRevisions(context.Background(), serverName, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, revisions, 3)
+		assert.Equal(t, "Original description", revisions[2].Server.Description)
+		assert.Equal(t, "github-at:admin", revisions[2].EditedBy)
+	})
+
+	t.Run("restore revision that is no longer valid", func(t *testing.T) {
+		ctx := context.Background()
+		remoteServer := func(name, remoteURL string) *apiv0.ServerJSON {
+			server := &apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        name,
+				Description: "Remote server",
+				Version:     "1.0.0",
+			}
+			if remoteURL != "" {
+				server.Remotes = []model.Transport{{Type: "streamable-http", URL: remoteURL}}
+			}
+			return server
+		}
+
+		// The remote URL of the first revision has since been taken by another server
+		remoteName := "io.github.testuser/remote-server"
+		_, err := registryService.CreateServer(ctx, remoteServer(remoteName, "https://mcp.example.com/revised"))
+		require.NoError(t, err)
+		_, err = registryService.UpdateServer(ctx, remoteName, "1.0.0", remoteServer(remoteName, ""), nil, "github-at:admin", "")
+		require.NoError(t, err)
+		_, err = registryService.CreateServer(ctx, remoteServer("io.github.testuser/other-server", "https://mcp.example.com/revised"))
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/v0/servers/"+url.PathEscape(remoteName)+"/versions/1.0.0/revisions/1/restore", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "already used by server")
+	})
+}
@@ -12,17 +12,35 @@ import (
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
 const errRecordNotFound = "record not found"
 
 // ListServersInput represents the input for listing servers
 type ListServersInput struct {
-	Cursor       string `query:"cursor" doc:"Pagination cursor" required:"false" example:"server-cursor-123"`
+	Cursor       string `query:"cursor" doc:"Opaque pagination cursor from metadata.nextCursor. Only valid with the same filters and ordering." required:"false" example:"eyJ2IjoxfQ"`
 	Limit        int    `query:"limit" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100" example:"50"`
-	UpdatedSince string `query:"updated_since" doc:"Filter servers updated since timestamp (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
-	Search       string `query:"search" doc:"Search servers by name (substring match)" required:"false" example:"filesystem"`
-	Version      string `query:"version" doc:"Filter by version ('latest' for latest version, or an exact version like '1.2.3')" required:"false" example:"latest"`
+	OrderBy      string `query:"order_by" doc:"Field to order servers by: 'name' (then version precedence), 'published_at' or 'updated_at'" default:"name" enum:"name,published_at,updated_at"`
+	Order        string `query:"order" doc:"Sort direction" default:"asc" enum:"asc,desc"`
+	IncludeTotal bool   `query:"include_total" doc:"Include the total number of matching server versions in metadata.total" required:"false" default:"false"`
+	ServerFilterInput
+	ConditionalInput
+}
+
+// ServerFilterInput represents the filters shared by server listings and facet counts
+type ServerFilterInput struct {
+	UpdatedSince   string   `query:"updated_since" doc:"Filter servers updated since timestamp (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
+	Search         string   `query:"search" doc:"Search servers by name (substring match)" required:"false" example:"filesystem"`
+	Version        string   `query:"version" doc:"Filter by version ('latest' for latest version, or an exact version like '1.2.3')" required:"false" example:"latest"`
+	Status         []string `query:"status" doc:"Filter by lifecycle status (comma-separated). Overrides include_deleted." required:"false" enum:"active,deprecated,deleted" example:"active,deprecated"`
+	IncludeDeleted bool     `query:"include_deleted" doc:"Include deleted versions. Deleted versions are hidden by default, except with updated_since, where they are returned as tombstones." required:"false" default:"false"`
+	Namespace      string   `query:"namespace" doc:"Filter by namespace (the part of the server name before '/'), including sub-namespaces" required:"false" example:"com.example"`
+	RegistryType   string   `query:"registry_type" doc:"Filter servers with a package from this registry type" required:"false" enum:"npm,pypi,oci,nuget,mcpb" example:"oci"`
+	PackageID      string   `query:"package_identifier" doc:"Filter servers with a package with this identifier" required:"false" example:"@modelcontextprotocol/server-filesystem"`
+	TransportType  string   `query:"transport_type" doc:"Filter servers with a package or remote using this transport" required:"false" enum:"stdio,streamable-http,sse" example:"streamable-http"`
+	RepositoryURL  string   `query:"repository_url" doc:"Filter servers by source repository URL (exact match)" required:"false" example:"https://github.com/modelcontextprotocol/servers"`
+	HasRemotes     string   `query:"has_remotes" doc:"Filter servers with ('true') or without ('false') remotes" required:"false" enum:"true,false"`
 }
 
 // ServerDetailInput represents the input for getting server details
@@ -33,12 +51,113 @@ type ServerDetailInput struct {
 // ServerVersionDetailInput represents the input for getting a specific version
 type ServerVersionDetailInput struct {
 	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
-	Version    string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+	Version    string `path:"version" doc:"URL-encoded server version, 'latest', or a dist-tag name" example:"1.0.0"`
+	ConditionalInput
+}
+
+// ServerDigestInput represents the input for looking up a server version by content digest
+type ServerDigestInput struct {
+	Digest string `path:"digest" doc:"Hex-encoded SHA-256 of the canonical JSON encoding of a server.json" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	ConditionalInput
 }
 
 // ServerVersionsInput represents the input for listing all versions of a server
 type ServerVersionsInput struct {
 	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Cursor     string `query:"cursor" doc:"Opaque pagination cursor from metadata.nextCursor. Only valid with the same ordering." required:"false" example:"eyJ2IjoxfQ"`
+	Limit      int    `query:"limit" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100" example:"50"`
+	OrderBy    string `query:"order_by" doc:"Field to order versions by: 'version' (semantic version precedence, falling back to publish time for non-semver versions) or 'published_at'" default:"version" enum:"version,published_at"`
+	Order      string `query:"order" doc:"Sort direction" default:"desc" enum:"asc,desc"`
+	ConditionalInput
+}
+
+// ResolveServerVersionInput represents the input for resolving a version range
+type ResolveServerVersionInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Range      string `query:"range" required:"true" doc:"Semantic version range: caret (^1.2.0), tilde (~1.2.0), comparators (>=1.0.0 <2.0.0), x-ranges (1.x), hyphen ranges (1.0.0 - 1.5.0), or unions joined with ||" example:"^1.2.0"`
+	ConditionalInput
+}
+
+// ServerDiffInput represents the input for comparing two versions of a server
+type ServerDiffInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	From       string `query:"from" required:"true" doc:"Version (or dist-tag) to compare from" example:"1.0.0"`
+	To         string `query:"to" required:"true" doc:"Version (or dist-tag) to compare to" example:"1.1.0"`
+	ConditionalInput
+}
+
+// buildServerFilter builds the database filter from the server filter query parameters
+func buildServerFilter(input *ServerFilterInput) (*database.ServerFilter, error) {
+	filter := &database.ServerFilter{}
+
+	// Parse updated_since parameter
+	if input.UpdatedSince != "" {
+		// Parse RFC3339 format
+		if updatedTime, err := time.Parse(time.RFC3339, input.UpdatedSince); err == nil {
+			filter.UpdatedSince = &updatedTime
+		} else {
+			return nil, huma.Error400BadRequest("Invalid updated_since format: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04.280Z)")
+		}
+	}
+
+	// Handle search parameter
+	if input.Search != "" {
+		filter.SubstringName = &input.Search
+	}
+
+	// Handle version parameter
+	if input.Version != "" {
+		if input.Version == "latest" {
+			// Special case: filter for latest versions
+			isLatest := true
+			filter.IsLatest = &isLatest
+		} else {
+			// Future: exact version matching
+			filter.Version = &input.Version
+		}
+	}
+
+	// Handle package, transport and repository filters
+	if input.Namespace != "" {
+		filter.Namespace = &input.Namespace
+	}
+	if input.RegistryType != "" {
+		filter.RegistryType = &input.RegistryType
+	}
+	if input.PackageID != "" {
+		filter.PackageID = &input.PackageID
+	}
+	if input.TransportType != "" {
+		filter.TransportType = &input.TransportType
+	}
+	if input.RepositoryURL != "" {
+		filter.RepositoryURL = &input.RepositoryURL
+	}
+	if input.HasRemotes != "" {
+		hasRemotes := input.HasRemotes == "true"
+		filter.HasRemotes = &hasRemotes
+	}
+
+	filter.Status = listStatusFilter(input)
+
+	return filter, nil
+}
+
+// listStatusFilter returns the statuses a server listing is restricted to:
+// the requested statuses if any, otherwise everything but deleted versions.
+// Incremental syncs (updated_since) also get deleted versions, so mirrors learn about deletions.
+func listStatusFilter(input *ServerFilterInput) []model.Status {
+	if len(input.Status) > 0 {
+		statuses := make([]model.Status, len(input.Status))
+		for i, status := range input.Status {
+			statuses[i] = model.Status(status)
+		}
+		return statuses
+	}
+	if input.IncludeDeleted || input.UpdatedSince != "" {
+		return nil
+	}
+	return []model.Status{model.StatusActive, model.StatusDeprecated}
 }
 
 // RegisterServersEndpoints registers all server-related endpoints with a custom path prefix
@@ -51,58 +170,49 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		Summary:     "List MCP servers",
 		Description: "Get a paginated list of MCP servers from the registry",
 		Tags:        []string{"servers"},
-	}, func(ctx context.Context, input *ListServersInput) (*Response[apiv0.ServerListResponse], error) {
-		// Build filter from input parameters
-		filter := &database.ServerFilter{}
-
-		// Parse updated_since parameter
-		if input.UpdatedSince != "" {
-			// Parse RFC3339 format
-			if updatedTime, err := time.Parse(time.RFC3339, input.UpdatedSince); err == nil {
-				filter.UpdatedSince = &updatedTime
-			} else {
-				return nil, huma.Error400BadRequest("Invalid updated_since format: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04.280Z)")
-			}
-		}
-
-		// Handle search parameter
-		if input.Search != "" {
-			filter.SubstringName = &input.Search
+	}, func(ctx context.Context, input *ListServersInput) (*CachedResponse[apiv0.ServerListResponse], error) {
+		filter, err := buildServerFilter(&input.ServerFilterInput)
+		if err != nil {
+			return nil, err
 		}
 
-		// Handle version parameter
-		if input.Version != "" {
-			if input.Version == "latest" {
-				// Special case: filter for latest versions
-				isLatest := true
-				filter.IsLatest = &isLatest
-			} else {
-				// Future: exact version matching
-				filter.Version = &input.Version
-			}
+		options := &database.ServerListOptions{
+			OrderBy:    database.ServerOrderBy(input.OrderBy),
+			Descending: input.Order == "desc",
 		}
 
 		// Get paginated results with filtering
-		servers, nextCursor, err := registry.ListServers(ctx, filter, input.Cursor, input.Limit)
+		servers, nextCursor, err := registry.ListServers(ctx, filter, options, input.Cursor, input.Limit)
 		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Invalid cursor", err)
+			}
 			return nil, huma.Error500InternalServerError("Failed to get registry list", err)
 		}
 
+		var total *int
+		if input.IncludeTotal {
+			count, err := registry.CountServers(ctx, filter)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to count servers", err)
+			}
+			total = &count
+		}
+
 		// Convert []*ServerResponse to []ServerResponse
 		serverValues := make([]apiv0.ServerResponse, len(servers))
 		for i, server := range servers {
 			serverValues[i] = *server
 		}
 
-		return &Response[apiv0.ServerListResponse]{
-			Body: apiv0.ServerListResponse{
-				Servers: serverValues,
-				Metadata: apiv0.Metadata{
-					NextCursor: nextCursor,
-					Count:      len(servers),
-				},
+		return cachedResponse(&input.ConditionalInput, apiv0.ServerListResponse{
+			Servers: serverValues,
+			Metadata: apiv0.Metadata{
+				NextCursor: nextCursor,
+				Count:      len(servers),
+				Total:      total,
 			},
-		}, nil
+		}, time.Time{}, cacheControlList)
 	})
 
 	// Get specific server version endpoint (supports "latest" as special version)
@@ -111,9 +221,9 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		Method:      http.MethodGet,
 		Path:        pathPrefix + "/servers/{serverName}/versions/{version}",
 		Summary:     "Get specific MCP server version",
-		Description: "Get detailed information about a specific version of an MCP server. Use the special version 'latest' to get the latest version.",
+		Description: "Get detailed information about a specific version of an MCP server. Use the special version 'latest' to get the latest version, or a dist-tag name (e.g. 'next') to get the version it points at.",
 		Tags:        []string{"servers"},
-	}, func(ctx context.Context, input *ServerVersionDetailInput) (*Response[apiv0.ServerResponse], error) {
+	}, func(ctx context.Context, input *ServerVersionDetailInput) (*CachedResponse[apiv0.ServerResponse], error) {
 		// URL-decode the server name
 		serverName, err := url.PathUnescape(input.ServerName)
 		if err != nil {
@@ -128,10 +238,17 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 
 		var serverResponse *apiv0.ServerResponse
 		// Handle "latest" as a special version
+		isAlias := false
 		if version == "latest" {
 			serverResponse, err = registry.GetServerByName(ctx, serverName)
+			isAlias = true
 		} else {
 			serverResponse, err = registry.GetServerByNameAndVersion(ctx, serverName, version)
+			// Fall back to resolving the path segment as a dist-tag (e.g. "next", "beta")
+			if errors.Is(err, database.ErrNotFound) {
+				serverResponse, err = registry.GetServerByDistTag(ctx, serverName, version)
+				isAlias = true
+			}
 		}
 
 		if err != nil {
@@ -141,9 +258,92 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 			return nil, huma.Error500InternalServerError("Failed to get server details", err)
 		}
 
-		return &Response[apiv0.ServerResponse]{
-			Body: *serverResponse,
-		}, nil
+		// Aliases can move to an older version, whose modification time would wrongly satisfy
+		// If-Modified-Since, so their responses are only validated by ETag
+		if isAlias {
+			return cachedResponse(&input.ConditionalInput, *serverResponse, time.Time{}, cacheControlAlias)
+		}
+		return cachedResponse(&input.ConditionalInput, *serverResponse, serverLastModified(serverResponse), cacheControlVersion)
+	})
+
+	// Get server version by content digest endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-by-digest" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/digests/{digest}",
+		Summary:     "Get MCP server version by content digest",
+		Description: "Get the server version whose current server.json has this content digest, as returned in `contentDigest` of the official registry metadata. Edited content has a new digest, so earlier digests of a version are not found.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerDigestInput) (*CachedResponse[apiv0.ServerResponse], error) {
+		serverResponse, err := registry.GetServerByContentDigest(ctx, strings.ToLower(input.Digest))
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Invalid content digest", err)
+			}
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server by content digest", err)
+		}
+
+		return cachedResponse(&input.ConditionalInput, *serverResponse, serverLastModified(serverResponse), cacheControlVersion)
+	})
+
+	// Resolve version range endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "resolve-server-version" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/resolve",
+		Summary:     "Resolve MCP server version range",
+		Description: "Get the highest non-deleted version of an MCP server that satisfies a semantic version range. Prerelease versions only match ranges that explicitly include a prerelease of the same major.minor.patch.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ResolveServerVersionInput) (*CachedResponse[apiv0.ServerResponse], error) {
+		// URL-decode the server name
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		serverResponse, err := registry.ResolveVersionRange(ctx, serverName, input.Range)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Invalid version range", err)
+			}
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("No version of the server matches the range")
+			}
+			return nil, huma.Error500InternalServerError("Failed to resolve server version", err)
+		}
+
+		// The range can resolve to an older version once the newest match is deleted, so the
+		// response is only validated by ETag
+		return cachedResponse(&input.ConditionalInput, *serverResponse, time.Time{}, cacheControlAlias)
+	})
+
+	// Diff server versions endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "diff-server-versions" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/diff",
+		Summary:     "Compare two versions of an MCP server",
+		Description: "Get the field-level differences between the server.json of two versions of an MCP server, including packages, remotes, environment variables and arguments, as structured changes and as text.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerDiffInput) (*CachedResponse[apiv0.ServerDiffResponse], error) {
+		// URL-decode the server name
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		diff, err := registry.DiffServerVersions(ctx, serverName, input.From, input.To)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server version not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to compare server versions", err)
+		}
+
+		return cachedResponse(&input.ConditionalInput, *diff, time.Time{}, cacheControlAlias)
 	})
 
 	// Get server versions endpoint
@@ -152,21 +352,29 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		Method:      http.MethodGet,
 		Path:        pathPrefix + "/servers/{serverName}/versions",
 		Summary:     "Get all versions of an MCP server",
-		Description: "Get all available versions for a specific MCP server",
+		Description: "Get a paginated list of the available versions for a specific MCP server. By default, versions are ordered from the highest semantic version to the lowest.",
 		Tags:        []string{"servers"},
-	}, func(ctx context.Context, input *ServerVersionsInput) (*Response[apiv0.ServerListResponse], error) {
+	}, func(ctx context.Context, input *ServerVersionsInput) (*CachedResponse[apiv0.ServerListResponse], error) {
 		// URL-decode the server name
 		serverName, err := url.PathUnescape(input.ServerName)
 		if err != nil {
 			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
 		}
 
-		// Get all versions for this server
-		servers, err := registry.GetAllVersionsByServerName(ctx, serverName)
+		options := &database.VersionListOptions{
+			OrderBy:    database.VersionOrderBy(input.OrderBy),
+			Descending: input.Order != "asc",
+		}
+
+		// Get a page of versions for this server
+		servers, nextCursor, err := registry.ListServerVersions(ctx, serverName, options, input.Cursor, input.Limit)
 		if err != nil {
 			if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) {
 				return nil, huma.Error404NotFound("Server not found")
 			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Invalid cursor", err)
+			}
 			return nil, huma.Error500InternalServerError("Failed to get server versions", err)
 		}
 
@@ -176,13 +384,20 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 			serverValues[i] = *server
 		}
 
-		return &Response[apiv0.ServerListResponse]{
-			Body: apiv0.ServerListResponse{
-				Servers: serverValues,
-				Metadata: apiv0.Metadata{
-					Count: len(servers),
-				},
+		return cachedResponse(&input.ConditionalInput, apiv0.ServerListResponse{
+			Servers: serverValues,
+			Metadata: apiv0.Metadata{
+				NextCursor: nextCursor,
+				Count:      len(servers),
 			},
-		}, nil
+		}, time.Time{}, cacheControlList)
 	})
 }
+
+// serverLastModified returns when a server version was last modified, for Last-Modified headers
+func serverLastModified(server *apiv0.ServerResponse) time.Time {
+	if server.Meta.Official == nil {
+		return time.Time{}
+	}
+	return server.Meta.Official.UpdatedAt
+}
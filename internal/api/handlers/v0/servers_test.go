@@ -6,7 +6,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
@@ -15,6 +17,7 @@ import (
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/canonicaljson"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -77,12 +80,48 @@ func TestListServersEndpoint(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectedCount:  2,
 		},
+		{
+			name:           "filter by namespace",
+			queryParams:    "?namespace=com.example",
+			expectedStatus: http.StatusOK,
+			expectedCount:  2,
+		},
+		{
+			name:           "filter by other namespace",
+			queryParams:    "?namespace=com.other",
+			expectedStatus: http.StatusOK,
+			expectedCount:  0,
+		},
+		{
+			name:           "filter by has_remotes",
+			queryParams:    "?has_remotes=false",
+			expectedStatus: http.StatusOK,
+			expectedCount:  2,
+		},
+		{
+			name:           "invalid registry type",
+			queryParams:    "?registry_type=maven",
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "validation failed",
+		},
 		{
 			name:           "invalid limit",
 			queryParams:    "?limit=abc",
 			expectedStatus: http.StatusUnprocessableEntity,
 			expectedError:  "validation failed",
 		},
+		{
+			name:           "invalid order_by",
+			queryParams:    "?order_by=downloads",
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "validation failed",
+		},
+		{
+			name:           "invalid cursor",
+			queryParams:    "?cursor=com.example%2Fserver-alpha%3A1.0.0",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid cursor",
+		},
 	}
 
 	for _, tt := range tests {
@@ -114,6 +153,163 @@ func TestListServersEndpoint(t *testing.T) {
 	}
 }
 
+func TestListServersPagination(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	for _, name := range []string{"com.example/page-alpha", "com.example/page-beta", "com.example/page-gamma"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Pagination test server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService)
+
+	list := func(t *testing.T, query string) (int, apiv0.ServerListResponse) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers?"+query, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		var resp apiv0.ServerListResponse
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		}
+		return w.Code, resp
+	}
+
+	t.Run("follow cursors newest first with total", func(t *testing.T) {
+		baseQuery := "search=page-&order_by=published_at&order=desc&limit=2&include_total=true"
+
+		status, first := list(t, baseQuery)
+		require.Equal(t, http.StatusOK, status)
+		require.NotNil(t, first.Metadata.Total)
+		assert.Equal(t, 3, *first.Metadata.Total)
+		require.NotEmpty(t, first.Metadata.NextCursor)
+
+		status, second := list(t, baseQuery+"&cursor="+url.QueryEscape(first.Metadata.NextCursor))
+		require.Equal(t, http.StatusOK, status)
+		assert.Empty(t, second.Metadata.NextCursor)
+
+		var names []string
+		for _, server := range append(first.Servers, second.Servers...) {
+			names = append(names, server.Server.Name)
+		}
+		assert.Equal(t, []string{"com.example/page-gamma", "com.example/page-beta", "com.example/page-alpha"}, names)
+	})
+
+	t.Run("total is omitted unless requested", func(t *testing.T) {
+		status, resp := list(t, "search=page-")
+		require.Equal(t, http.StatusOK, status)
+		assert.Nil(t, resp.Metadata.Total)
+	})
+
+	t.Run("cursor is rejected with different filters", func(t *testing.T) {
+		status, first := list(t, "search=page-&limit=1")
+		require.Equal(t, http.StatusOK, status)
+		require.NotEmpty(t, first.Metadata.NextCursor)
+
+		status, _ = list(t, "search=alpha&limit=1&cursor="+url.QueryEscape(first.Metadata.NextCursor))
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+func TestListServersStatusFilter(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	since := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+
+	for _, tc := range []struct {
+		name   string
+		status model.Status
+	}{
+		{"com.example/status-active", model.StatusActive},
+		{"com.example/status-deprecated", model.StatusDeprecated},
+		{"com.example/status-deleted", model.StatusDeleted},
+	} {
+		server := &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        tc.name,
+			Description: "Status filter test server",
+			Version:     "1.0.0",
+		}
+		_, err := registryService.CreateServer(ctx, server)
+		require.NoError(t, err)
+		if tc.status != model.StatusActive {
+			_, err = registryService.UpdateServer(ctx, tc.name, "1.0.0", server, stringPtr(string(tc.status)), "", "")
+			require.NoError(t, err)
+		}
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService)
+
+	tests := []struct {
+		name          string
+		queryParams   string
+		expectedNames []string
+	}{
+		{
+			name:          "deleted versions are hidden by default",
+			queryParams:   "",
+			expectedNames: []string{"com.example/status-active", "com.example/status-deprecated"},
+		},
+		{
+			name:          "include deleted",
+			queryParams:   "?include_deleted=true",
+			expectedNames: []string{"com.example/status-active", "com.example/status-deleted", "com.example/status-deprecated"},
+		},
+		{
+			name:          "filter by status",
+			queryParams:   "?status=deprecated",
+			expectedNames: []string{"com.example/status-deprecated"},
+		},
+		{
+			name:          "filter by several statuses",
+			queryParams:   "?status=active,deleted",
+			expectedNames: []string{"com.example/status-active", "com.example/status-deleted"},
+		},
+		{
+			name:          "updated_since returns deleted versions as tombstones",
+			queryParams:   "?updated_since=" + url.QueryEscape(since),
+			expectedNames: []string{"com.example/status-active", "com.example/status-deleted", "com.example/status-deprecated"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/servers"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var resp apiv0.ServerListResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+
+			names := make([]string, len(resp.Servers))
+			for i, server := range resp.Servers {
+				names[i] = server.Server.Name
+			}
+			assert.Equal(t, tt.expectedNames, names)
+		})
+	}
+
+	t.Run("invalid status", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers?status=unknown", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+}
+
 func TestGetLatestServerVersionEndpoint(t *testing.T) {
 	ctx := context.Background()
 	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
@@ -392,6 +588,406 @@ func TestGetAllVersionsEndpoint(t *testing.T) {
 	}
 }
 
+func TestGetAllVersionsEndpointPagination(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	serverName := "com.example/paginated-versions-server"
+	for _, version := range []string{"1.9.0", "1.10.0", "1.2.0"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        serverName,
+			Description: "Paginated versions server " + version,
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService)
+
+	fetchAll := func(t *testing.T, query string) []string {
+		t.Helper()
+		var versions []string
+		cursor := ""
+		for {
+			requestURL := "/v0/servers/" + url.PathEscape(serverName) + "/versions?limit=2" + query
+			if cursor != "" {
+				requestURL += "&cursor=" + url.QueryEscape(cursor)
+			}
+			req := httptest.NewRequest(http.MethodGet, requestURL, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var resp apiv0.ServerListResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			for _, server := range resp.Servers {
+				versions = append(versions, server.Server.Version)
+			}
+			if resp.Metadata.NextCursor == "" {
+				return versions
+			}
+			cursor = resp.Metadata.NextCursor
+		}
+	}
+
+	t.Run("default order is semantic version descending", func(t *testing.T) {
+		assert.Equal(t, []string{"1.10.0", "1.9.0", "1.2.0"}, fetchAll(t, ""))
+	})
+
+	t.Run("ascending version order", func(t *testing.T) {
+		assert.Equal(t, []string{"1.2.0", "1.9.0", "1.10.0"}, fetchAll(t, "&order=asc"))
+	})
+
+	t.Run("published_at order", func(t *testing.T) {
+		assert.Equal(t, []string{"1.9.0", "1.10.0", "1.2.0"}, fetchAll(t, "&order_by=published_at&order=asc"))
+	})
+
+	t.Run("invalid order_by", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape(serverName)+"/versions?order_by=name", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape(serverName)+"/versions?cursor=9.9.9", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("cursor from another ordering", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape(serverName)+"/versions?limit=1", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp apiv0.ServerListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.NotEmpty(t, resp.Metadata.NextCursor)
+
+		req = httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape(serverName)+
+			"/versions?limit=1&order_by=published_at&cursor="+url.QueryEscape(resp.Metadata.NextCursor), nil)
+		w = httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestServersEndpointsCaching(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	serverName := "com.example/cached-server"
+	server := &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        serverName,
+		Description: "Cached server",
+		Version:     "1.0.0",
+	}
+	_, err := registryService.CreateServer(ctx, server)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService)
+
+	get := func(t *testing.T, path string, headers map[string]string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	versionPath := "/v0/servers/" + url.PathEscape(serverName) + "/versions/1.0.0"
+
+	t.Run("cache headers", func(t *testing.T) {
+		w := get(t, versionPath, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Regexp(t, `^"[0-9a-f]{64}"$`, w.Header().Get("ETag"))
+		assert.NotEmpty(t, w.Header().Get("Last-Modified"))
+		assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+
+		w = get(t, "/v0/servers/"+url.PathEscape(serverName)+"/versions/latest", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "public, max-age=60", w.Header().Get("Cache-Control"))
+
+		w = get(t, "/v0/servers", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotEmpty(t, w.Header().Get("ETag"))
+		assert.Empty(t, w.Header().Get("Last-Modified"))
+		assert.Equal(t, "public, max-age=60", w.Header().Get("Cache-Control"))
+	})
+
+	t.Run("if-none-match", func(t *testing.T) {
+		for _, path := range []string{versionPath, "/v0/servers", "/v0/servers/" + url.PathEscape(serverName) + "/versions"} {
+			etag := get(t, path, nil).Header().Get("ETag")
+			require.NotEmpty(t, etag, path)
+
+			w := get(t, path, map[string]string{"If-None-Match": etag})
+			assert.Equal(t, http.StatusNotModified, w.Code, path)
+			assert.Empty(t, w.Body.String(), path)
+			assert.Equal(t, etag, w.Header().Get("ETag"), path)
+
+			w = get(t, path, map[string]string{"If-None-Match": `"other", W/` + etag})
+			assert.Equal(t, http.StatusNotModified, w.Code, path)
+
+			w = get(t, path, map[string]string{"If-None-Match": `"other"`})
+			assert.Equal(t, http.StatusOK, w.Code, path)
+		}
+	})
+
+	t.Run("if-modified-since", func(t *testing.T) {
+		lastModified := get(t, versionPath, nil).Header().Get("Last-Modified")
+
+		w := get(t, versionPath, map[string]string{"If-Modified-Since": lastModified})
+		assert.Equal(t, http.StatusNotModified, w.Code)
+
+		w = get(t, versionPath, map[string]string{"If-Modified-Since": "Mon, 01 Jan 2024 00:00:00 GMT"})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// If-None-Match takes precedence
+		w = get(t, versionPath, map[string]string{"If-Modified-Since": lastModified, "If-None-Match": `"other"`})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// Aliases can move to an older version, so they are only validated by ETag
+		for _, path := range []string{
+			"/v0/servers/" + url.PathEscape(serverName) + "/versions/latest",
+			"/v0/servers/" + url.PathEscape(serverName) + "/resolve?range=" + url.QueryEscape("^1.0.0"),
+		} {
+			w = get(t, path, nil)
+			require.Equal(t, http.StatusOK, w.Code, path)
+			assert.Empty(t, w.Header().Get("Last-Modified"), path)
+
+			w = get(t, path, map[string]string{"If-Modified-Since": time.Now().UTC().Format(http.TimeFormat)})
+			assert.Equal(t, http.StatusOK, w.Code, path)
+		}
+	})
+
+	t.Run("changes invalidate etags", func(t *testing.T) {
+		etag := get(t, versionPath, nil).Header().Get("ETag")
+
+		edited := *server
+		edited.Description = "Edited cached server"
+		_, err := registryService.UpdateServer(ctx, serverName, "1.0.0", &edited, nil, "", "")
+		require.NoError(t, err)
+
+		w := get(t, versionPath, map[string]string{"If-None-Match": etag})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotEqual(t, etag, w.Header().Get("ETag"))
+	})
+}
+
+func TestDiffServerVersionsEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	serverName := "com.example/diff-server"
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        serverName,
+			Description: "Diff server " + version,
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService)
+
+	t.Run("diff two versions", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape(serverName)+"/diff?from=1.0.0&to=latest", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp apiv0.ServerDiffResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, "1.0.0", resp.From)
+		assert.Equal(t, "1.1.0", resp.To)
+		assert.Equal(t, []apiv0.ServerDiffChange{
+			{Path: "description", Type: "changed", From: "Diff server 1.0.0", To: "Diff server 1.1.0"},
+			{Path: "version", Type: "changed", From: "1.0.0", To: "1.1.0"},
+		}, resp.Changes)
+		assert.Equal(t, "~ description: \"Diff server 1.0.0\" -> \"Diff server 1.1.0\"\n~ version: \"1.0.0\" -> \"1.1.0\"\n", resp.Text)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape(serverName)+"/diff?from=1.0.0&to=9.9.9", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("missing parameters", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape(serverName)+"/diff?from=1.0.0", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+}
+
+func TestGetServerByDigestEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	server := &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/digest-server",
+		Description: "Digest server",
+		Version:     "1.0.0",
+	}
+	created, err := registryService.CreateServer(ctx, server)
+	require.NoError(t, err)
+
+	digest, err := canonicaljson.Digest(server)
+	require.NoError(t, err)
+	assert.Equal(t, digest, created.Meta.Official.ContentDigest)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService)
+
+	tests := []struct {
+		name           string
+		digest         string
+		expectedStatus int
+	}{
+		{name: "known digest", digest: digest, expectedStatus: http.StatusOK},
+		{name: "uppercase digest", digest: strings.ToUpper(digest), expectedStatus: http.StatusOK},
+		{name: "unknown digest", digest: strings.Repeat("0", 64), expectedStatus: http.StatusNotFound},
+		{name: "malformed digest", digest: "not-a-digest", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/digests/"+tt.digest, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+
+			if tt.expectedStatus == http.StatusOK {
+				var resp apiv0.ServerResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Equal(t, "com.example/digest-server", resp.Server.Name)
+				assert.Equal(t, digest, resp.Meta.Official.ContentDigest)
+			}
+		})
+	}
+}
+
+func TestResolveServerVersionEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	serverName := "com.example/range-server"
+
+	// Setup test data with stable, prerelease and deleted versions
+	for _, version := range []string{"1.0.0", "1.2.0", "1.3.0-beta.1", "1.4.0", "2.0.0"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        serverName,
+			Description: "Range test server " + version,
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+	_, err := registryService.UpdateServer(ctx, serverName, "1.4.0", &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        serverName,
+		Description: "Range test server 1.4.0",
+		Version:     "1.4.0",
+	}, stringPtr(string(model.StatusDeleted)), "", "")
+	require.NoError(t, err)
+
+	// Create API
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService)
+
+	tests := []struct {
+		name            string
+		serverName      string
+		versionRange    string
+		expectedStatus  int
+		expectedVersion string
+	}{
+		{
+			name:            "caret range skips deleted and prerelease versions",
+			serverName:      serverName,
+			versionRange:    "^1.0.0",
+			expectedStatus:  http.StatusOK,
+			expectedVersion: "1.2.0",
+		},
+		{
+			name:            "tilde range",
+			serverName:      serverName,
+			versionRange:    "~1.0",
+			expectedStatus:  http.StatusOK,
+			expectedVersion: "1.0.0",
+		},
+		{
+			name:            "explicit prerelease range",
+			serverName:      serverName,
+			versionRange:    ">=1.3.0-beta.0 <1.4.0",
+			expectedStatus:  http.StatusOK,
+			expectedVersion: "1.3.0-beta.1",
+		},
+		{
+			name:            "wildcard",
+			serverName:      serverName,
+			versionRange:    "*",
+			expectedStatus:  http.StatusOK,
+			expectedVersion: "2.0.0",
+		},
+		{
+			name:           "no matching version",
+			serverName:     serverName,
+			versionRange:   "^3.0.0",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid range",
+			serverName:     serverName,
+			versionRange:   "^not-a-version",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "non-existent server",
+			serverName:     "com.example/non-existent",
+			versionRange:   "^1.0.0",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encodedName := url.PathEscape(tt.serverName)
+			req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+encodedName+"/resolve?range="+url.QueryEscape(tt.versionRange), nil)
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var resp apiv0.ServerResponse
+				err := json.NewDecoder(w.Body).Decode(&resp)
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedVersion, resp.Server.Version)
+			}
+		})
+	}
+}
+
 func TestServersEndpointEdgeCases(t *testing.T) {
 	ctx := context.Background()
 	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
@@ -0,0 +1,35 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/snapshot"
+)
+
+// RegisterSnapshotEndpoint registers the signed snapshot manifest endpoint with a custom path prefix
+func RegisterSnapshotEndpoint(api huma.API, pathPrefix string, publisher *snapshot.Publisher) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-snapshot-manifest" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/snapshot",
+		Summary:     "Get signed snapshot manifest",
+		Description: "Get the latest periodically generated manifest of every server version with the SHA-256 of the canonical JSON (RFC 8785) encoding of its server.json, signed with the registry's Ed25519 key, so that mirrors can verify the data they copy.",
+		Tags:        []string{"servers"},
+	}, func(_ context.Context, _ *struct{}) (*Response[snapshot.SignedManifest], error) {
+		if publisher == nil {
+			return nil, huma.Error503ServiceUnavailable("Snapshot manifests are not configured")
+		}
+
+		manifest := publisher.Latest()
+		if manifest == nil {
+			return nil, huma.Error503ServiceUnavailable("Snapshot manifest has not been generated yet")
+		}
+
+		return &Response[snapshot.SignedManifest]{
+			Body: *manifest,
+		}, nil
+	})
+}
@@ -0,0 +1,84 @@
+package v0_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/snapshot"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/canonicaljson"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSnapshotManifestEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+	signingKey := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+
+	server := &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/snapshot-server",
+		Description: "Snapshot test server",
+		Version:     "1.0.0",
+	}
+	_, err := registryService.CreateServer(ctx, server)
+	require.NoError(t, err)
+
+	t.Run("signed manifest", func(t *testing.T) {
+		mux := http.NewServeMux()
+		api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+		publisher := snapshot.NewPublisher(registryService, signingKey, time.Hour)
+		require.NoError(t, publisher.Refresh(ctx))
+		v0.RegisterSnapshotEndpoint(api, "/v0", publisher)
+
+		req := httptest.NewRequest(http.MethodGet, "/v0/snapshot", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var manifest snapshot.SignedManifest
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &manifest))
+		require.NoError(t, manifest.Verify(signingKey.Public().(ed25519.PublicKey)))
+
+		digest, err := canonicaljson.Digest(server)
+		require.NoError(t, err)
+		assert.Equal(t, []snapshot.ManifestServer{
+			{Name: "com.example/snapshot-server", Version: "1.0.0", SHA256: digest},
+		}, manifest.Manifest.Servers)
+	})
+
+	t.Run("not generated yet", func(t *testing.T) {
+		mux := http.NewServeMux()
+		api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+		v0.RegisterSnapshotEndpoint(api, "/v0", snapshot.NewPublisher(registryService, signingKey, time.Hour))
+
+		req := httptest.NewRequest(http.MethodGet, "/v0/snapshot", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		mux := http.NewServeMux()
+		api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+		v0.RegisterSnapshotEndpoint(api, "/v0", nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/v0/snapshot", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
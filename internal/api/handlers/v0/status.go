@@ -0,0 +1,142 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// SetServerVersionStatusInput represents the input for changing the status of a server version
+type SetServerVersionStatusInput struct {
+	Authorization string                    `header:"Authorization" doc:"Registry JWT token with publish permissions" required:"true"`
+	ServerName    string                    `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version       string                    `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+	Body          apiv0.ServerStatusRequest `body:""`
+}
+
+// SetServerStatusInput represents the input for changing the status of every version of a server
+type SetServerStatusInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with edit permissions (publish permissions suffice for active and deprecated)" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Status        string `query:"status" doc:"New status for every version of the server" required:"true" enum:"active,deprecated,deleted"`
+	Reason        string `query:"reason" maxLength:"500" doc:"Optional reason, recorded as the status message of each changed version" required:"false"`
+}
+
+// RegisterStatusEndpoints registers the version and server status endpoints with a custom path prefix
+func RegisterStatusEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	// Set version status endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "set-server-version-status" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/status",
+		Summary:     "Set MCP server version status",
+		Description: "Deprecate or undeprecate a specific version of an MCP server, optionally with a deprecation message and a replacement server or version. Deleting versions is reserved for registry admins.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *SetServerVersionStatusInput) (*Response[apiv0.ServerResponse], error) {
+		serverName, err := authorizePublisher(ctx, jwtManager, input.Authorization, input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+
+		updatedServer, err := registry.SetServerVersionStatus(ctx, serverName, version, &input.Body)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server version not found")
+			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Failed to set server version status", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to set server version status", err)
+		}
+
+		return &Response[apiv0.ServerResponse]{
+			Body: *updatedServer,
+		}, nil
+	})
+
+	// Set server status endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "set-server-status" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/servers/{serverName}",
+		Summary:     "Set MCP server status",
+		Description: "Change the status of every version of an MCP server at once, e.g. to take down a server. Deleted versions are never undeleted. Deleting requires edit permissions.",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *SetServerStatusInput) (*Response[apiv0.ServerListResponse], error) {
+		// Extract bearer token
+		const bearerPrefix = "Bearer "
+		authHeader := input.Authorization
+		if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+			return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
+		}
+		token := authHeader[len(bearerPrefix):]
+
+		// Validate Registry JWT token
+		claims, err := jwtManager.ValidateToken(ctx, token)
+		if err != nil {
+			return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+		}
+
+		// URL-decode the server name
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		// Admins can do anything; publishers can deprecate and undeprecate their own servers
+		status := model.Status(input.Status)
+		canEdit := jwtManager.HasPermission(serverName, auth.PermissionActionEdit, claims.Permissions)
+		canPublish := jwtManager.HasPermission(serverName, auth.PermissionActionPublish, claims.Permissions)
+		if !canEdit && (status == model.StatusDeleted || !canPublish) {
+			return nil, huma.Error403Forbidden("You do not have permission to change the status of this server")
+		}
+
+		versions, err := registry.SetAllVersionsStatus(ctx, serverName, status, input.Reason)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server not found")
+			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Failed to set server status", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to set server status", err)
+		}
+
+		serverValues := make([]apiv0.ServerResponse, len(versions))
+		for i, version := range versions {
+			serverValues[i] = *version
+		}
+
+		return &Response[apiv0.ServerListResponse]{
+			Body: apiv0.ServerListResponse{
+				Servers: serverValues,
+				Metadata: apiv0.Metadata{
+					Count: len(versions),
+				},
+			},
+		}, nil
+	})
+}
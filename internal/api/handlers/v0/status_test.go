@@ -0,0 +1,313 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestSetServerVersionStatusEndpoint(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	serverName := "io.github.testuser/status-server"
+	for _, version := range []string{"1.0.0", "2.0.0"} {
+		_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        serverName,
+			Description: "Status test server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterStatusEndpoints(api, "/v0", registryService, cfg)
+
+	publisherToken, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "testuser",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.testuser/*"},
+		},
+	})
+	require.NoError(t, err)
+
+	otherToken, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "otheruser",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.otheruser/*"},
+		},
+	})
+	require.NoError(t, err)
+
+	setStatus := func(t *testing.T, token, version string, body map[string]any) *httptest.ResponseRecorder {
+		t.Helper()
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPut, "/v0/servers/"+url.PathEscape(serverName)+"/versions/"+version+"/status", bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("requires publish permission for the server", func(t *testing.T) {
+		w := setStatus(t, otherToken, "1.0.0", map[string]any{"status": "deprecated"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("deprecate with message and replacement", func(t *testing.T) {
+		w := setStatus(t, publisherToken, "1.0.0", map[string]any{
+			"status":     "deprecated",
+			"message":    "Please upgrade to 2.0.0",
+			"replacedBy": map[string]any{"serverName": serverName, "version": "2.0.0"},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp apiv0.ServerResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.NotNil(t, resp.Meta.Official)
+		assert.Equal(t, model.StatusDeprecated, resp.Meta.Official.Status)
+		assert.Equal(t, "Please upgrade to 2.0.0", resp.Meta.Official.StatusMessage)
+		assert.Equal(t, &apiv0.ServerReference{ServerName: serverName, Version: "2.0.0"}, resp.Meta.Official.ReplacedBy)
+
+		stored, err := registryService.GetServerByNameAndVersion(context.Background(), serverName, "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, "Please upgrade to 2.0.0", stored.Meta.Official.StatusMessage)
+		assert.Equal(t, "2.0.0", stored.Meta.Official.ReplacedBy.Version)
+	})
+
+	t.Run("undeprecate clears message and replacement", func(t *testing.T) {
+		w := setStatus(t, publisherToken, "1.0.0", map[string]any{"status": "active"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp apiv0.ServerResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, model.StatusActive, resp.Meta.Official.Status)
+		assert.Empty(t, resp.Meta.Official.StatusMessage)
+		assert.Nil(t, resp.Meta.Official.ReplacedBy)
+	})
+
+	t.Run("cannot delete", func(t *testing.T) {
+		w := setStatus(t, publisherToken, "1.0.0", map[string]any{"status": "deleted"})
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("message requires deprecation", func(t *testing.T) {
+		w := setStatus(t, publisherToken, "1.0.0", map[string]any{"status": "active", "message": "still fine"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("replacement must exist", func(t *testing.T) {
+		w := setStatus(t, publisherToken, "1.0.0", map[string]any{
+			"status":     "deprecated",
+			"replacedBy": map[string]any{"serverName": "io.github.testuser/missing"},
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("version cannot replace itself", func(t *testing.T) {
+		w := setStatus(t, publisherToken, "1.0.0", map[string]any{
+			"status":     "deprecated",
+			"replacedBy": map[string]any{"serverName": serverName, "version": "1.0.0"},
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		w := setStatus(t, publisherToken, "9.9.9", map[string]any{"status": "deprecated"})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("deleted versions cannot be changed", func(t *testing.T) {
+		_, err := registryService.UpdateServer(context.Background(), serverName, "2.0.0", &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        serverName,
+			Description: "Status test server",
+			Version:     "2.0.0",
+		}, stringPtr(string(model.StatusDeleted)), "github-at:admin", "")
+		require.NoError(t, err)
+
+		w := setStatus(t, publisherToken, "2.0.0", map[string]any{"status": "active"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestSetServerStatusEndpoint(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	serverName := "io.github.testuser/takedown-server"
+	for _, version := range []string{"1.0.0", "1.1.0", "2.0.0"} {
+		_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        serverName,
+			Description: "Takedown test server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterStatusEndpoints(api, "/v0", registryService, cfg)
+
+	adminToken, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "admin",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionEdit, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+
+	publisherToken, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "testuser",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.testuser/*"},
+		},
+	})
+	require.NoError(t, err)
+
+	setStatus := func(t *testing.T, token, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPut, "/v0/servers/"+url.PathEscape(serverName)+"?"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	statuses := func(t *testing.T) map[string]*apiv0.RegistryExtensions {
+		t.Helper()
+		versions, err := registryService.GetAllVersionsByServerName(context.Background(), serverName)
+		require.NoError(t, err)
+		result := make(map[string]*apiv0.RegistryExtensions, len(versions))
+		for _, version := range versions {
+			result[version.Server.Version] = version.Meta.Official
+		}
+		return result
+	}
+
+	t.Run("publishers cannot delete", func(t *testing.T) {
+		w := setStatus(t, publisherToken, "status=deleted")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("publishers can deprecate every version", func(t *testing.T) {
+		w := setStatus(t, publisherToken, "status=deprecated&reason="+url.QueryEscape("No longer maintained"))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp apiv0.ServerListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, 3, resp.Metadata.Count)
+		for _, server := range resp.Servers {
+			assert.Equal(t, model.StatusDeprecated, server.Meta.Official.Status)
+			assert.Equal(t, "No longer maintained", server.Meta.Official.StatusMessage)
+		}
+	})
+
+	t.Run("undeprecate every version", func(t *testing.T) {
+		w := setStatus(t, publisherToken, "status=active")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		for version, official := range statuses(t) {
+			assert.Equal(t, model.StatusActive, official.Status, version)
+			assert.Empty(t, official.StatusMessage, version)
+		}
+	})
+
+	t.Run("latest moves off a deleted version", func(t *testing.T) {
+		_, err := registryService.UpdateServer(context.Background(), serverName, "2.0.0", &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        serverName,
+			Description: "Takedown test server",
+			Version:     "2.0.0",
+		}, stringPtr(string(model.StatusDeleted)), "github-at:admin", "")
+		require.NoError(t, err)
+
+		w := setStatus(t, adminToken, "status=deprecated")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		current := statuses(t)
+		assert.Equal(t, model.StatusDeleted, current["2.0.0"].Status)
+		assert.False(t, current["2.0.0"].IsLatest)
+		assert.True(t, current["1.1.0"].IsLatest)
+	})
+
+	t.Run("admins can take down every version", func(t *testing.T) {
+		w := setStatus(t, adminToken, "status=deleted&reason="+url.QueryEscape("Malware report"))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		current := statuses(t)
+		for version, official := range current {
+			assert.Equal(t, model.StatusDeleted, official.Status, version)
+			// With every version deleted, the server has no latest version
+			assert.False(t, official.IsLatest, version)
+		}
+		assert.Equal(t, "Malware report", current["1.0.0"].StatusMessage)
+		// Versions that were already deleted keep their own status message
+		assert.Empty(t, current["2.0.0"].StatusMessage)
+
+		_, err := registryService.GetServerByName(context.Background(), serverName)
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("deleted versions stay deleted", func(t *testing.T) {
+		w := setStatus(t, adminToken, "status=active")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		for version, official := range statuses(t) {
+			assert.Equal(t, model.StatusDeleted, official.Status, version)
+		}
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v0/servers/"+url.PathEscape("io.github.testuser/missing")+"?status=deleted", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
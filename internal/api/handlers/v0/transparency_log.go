@@ -0,0 +1,144 @@
+package v0
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/snapshot"
+	"github.com/modelcontextprotocol/registry/pkg/transparency"
+)
+
+// LogInclusionProofInput represents the input for proving a server version is in the transparency log
+type LogInclusionProofInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version    string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+	Revision   int    `query:"revision" doc:"Revision whose log entry to prove. Defaults to the latest revision." example:"1"`
+	TreeSize   int64  `query:"tree_size" doc:"Size of the tree to prove inclusion in. Defaults to the current size of the log." example:"42"`
+}
+
+// LogConsistencyProofInput represents the input for proving the transparency log only grew between two sizes
+type LogConsistencyProofInput struct {
+	First  int64 `query:"first" required:"true" doc:"Size of the earlier tree" example:"10"`
+	Second int64 `query:"second" doc:"Size of the later tree. Defaults to the current size of the log." example:"42"`
+}
+
+// RegisterTransparencyLogEndpoints registers the transparency log endpoints with a custom path prefix.
+// Tree heads are signed with the same key as snapshot manifests.
+func RegisterTransparencyLogEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	signingKey, err := snapshot.SigningKey(cfg)
+	if err != nil {
+		log.Printf("Transparency log tree heads cannot be signed: %v", err)
+	}
+
+	// Tree head endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "get-log-tree-head" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/log/tree-head",
+		Summary:     "Get transparency log tree head",
+		Description: "Get the signed size and Merkle root hash of the transparency log of every publish and edit.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, _ *struct{}) (*Response[transparency.SignedTreeHead], error) {
+		if signingKey == nil {
+			return nil, huma.Error503ServiceUnavailable("Transparency log signing is not configured")
+		}
+
+		head, err := registry.GetLogTreeHead(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get tree head", err)
+		}
+		if err := signTreeHeads(signingKey, head); err != nil {
+			return nil, huma.Error500InternalServerError("Failed to sign tree head", err)
+		}
+
+		return &Response[transparency.SignedTreeHead]{
+			Body: *head,
+		}, nil
+	})
+
+	// Inclusion proof endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "get-log-inclusion-proof" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/inclusion-proof",
+		Summary:     "Get transparency log inclusion proof",
+		Description: "Prove that the server.json of a server version is recorded in the transparency log, with the log entry, its audit path and the signed tree head it leads to.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *LogInclusionProofInput) (*Response[transparency.InclusionProof], error) {
+		if signingKey == nil {
+			return nil, huma.Error503ServiceUnavailable("Transparency log signing is not configured")
+		}
+
+		serverName, version, err := decodeServerVersionPath(input.ServerName, input.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		proof, err := registry.GetLogInclusionProof(ctx, serverName, version, input.Revision, input.TreeSize)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Log entry not found")
+			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Invalid tree size", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to get inclusion proof", err)
+		}
+		if err := signTreeHeads(signingKey, &proof.TreeHead); err != nil {
+			return nil, huma.Error500InternalServerError("Failed to sign tree head", err)
+		}
+
+		return &Response[transparency.InclusionProof]{
+			Body: *proof,
+		}, nil
+	})
+
+	// Consistency proof endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "get-log-consistency-proof" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/log/consistency-proof",
+		Summary:     "Get transparency log consistency proof",
+		Description: "Prove that the transparency log at an earlier size is a prefix of the log at a later size, i.e. that no logged entry was changed or removed in between.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *LogConsistencyProofInput) (*Response[transparency.ConsistencyProof], error) {
+		if signingKey == nil {
+			return nil, huma.Error503ServiceUnavailable("Transparency log signing is not configured")
+		}
+
+		proof, err := registry.GetLogConsistencyProof(ctx, input.First, input.Second)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Invalid tree size", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to get consistency proof", err)
+		}
+		if err := signTreeHeads(signingKey, &proof.First, &proof.Second); err != nil {
+			return nil, huma.Error500InternalServerError("Failed to sign tree head", err)
+		}
+
+		return &Response[transparency.ConsistencyProof]{
+			Body: *proof,
+		}, nil
+	})
+}
+
+// signTreeHeads signs tree heads with the same timestamp
+func signTreeHeads(key ed25519.PrivateKey, heads ...*transparency.SignedTreeHead) error {
+	now := time.Now()
+	for _, head := range heads {
+		if err := transparency.SignTreeHead(head, key, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package v0_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/transparency"
+)
+
+func TestTransparencyLogEndpoints(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(seed)}
+	verifier := transparency.NewVerifier(ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey))
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	serverName := "io.github.testuser/logged-server"
+	original := &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        serverName,
+		Description: "Original description",
+		Version:     "1.0.0",
+	}
+	_, err := registryService.CreateServer(context.Background(), original)
+	require.NoError(t, err)
+
+	edited := *original
+	edited.Description = "Edited description"
+	_, err = registryService.UpdateServer(context.Background(), serverName, "1.0.0", &edited, nil, "github-at:admin", "")
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterTransparencyLogEndpoints(api, "/v0", registryService, cfg)
+
+	get := func(t *testing.T, path string, out any) int {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), out))
+		}
+		return w.Code
+	}
+	inclusionPath := "/v0/servers/" + url.PathEscape(serverName) + "/versions/1.0.0/inclusion-proof"
+
+	t.Run("tree head", func(t *testing.T) {
+		var head transparency.SignedTreeHead
+		require.Equal(t, http.StatusOK, get(t, "/v0/log/tree-head", &head))
+		assert.Equal(t, int64(2), head.TreeSize)
+		assert.NoError(t, verifier.VerifyTreeHead(&head))
+	})
+
+	t.Run("inclusion of latest revision", func(t *testing.T) {
+		var proof transparency.InclusionProof
+		require.Equal(t, http.StatusOK, get(t, inclusionPath, &proof))
+		assert.Equal(t, int64(1), proof.LogIndex)
+		assert.Equal(t, transparency.ActionEdit, proof.Entry.Action)
+		assert.NoError(t, verifier.VerifyInclusion(&proof, &edited))
+		assert.ErrorIs(t, verifier.VerifyInclusion(&proof, original), transparency.ErrInvalidProof)
+	})
+
+	t.Run("inclusion of earlier revision in earlier tree", func(t *testing.T) {
+		var proof transparency.InclusionProof
+		require.Equal(t, http.StatusOK, get(t, inclusionPath+"?revision=1&tree_size=1", &proof))
+		assert.Equal(t, int64(0), proof.LogIndex)
+		assert.Equal(t, int64(1), proof.TreeHead.TreeSize)
+		assert.NoError(t, verifier.VerifyInclusion(&proof, original))
+	})
+
+	t.Run("inclusion errors", func(t *testing.T) {
+		var proof transparency.InclusionProof
+		assert.Equal(t, http.StatusBadRequest, get(t, inclusionPath+"?tree_size=1", &proof))
+		assert.Equal(t, http.StatusBadRequest, get(t, inclusionPath+"?tree_size=5", &proof))
+		assert.Equal(t, http.StatusNotFound, get(t, "/v0/servers/"+url.PathEscape(serverName)+"/versions/9.9.9/inclusion-proof", &proof))
+	})
+
+	t.Run("consistency", func(t *testing.T) {
+		var proof transparency.ConsistencyProof
+		require.Equal(t, http.StatusOK, get(t, "/v0/log/consistency-proof?first=1", &proof))
+		assert.Equal(t, int64(1), proof.First.TreeSize)
+		assert.Equal(t, int64(2), proof.Second.TreeSize)
+		assert.NoError(t, verifier.VerifyConsistency(&proof))
+
+		assert.Equal(t, http.StatusBadRequest, get(t, "/v0/log/consistency-proof?first=3", &proof))
+		assert.Equal(t, http.StatusBadRequest, get(t, "/v0/log/consistency-proof?first=0", &proof))
+	})
+
+	t.Run("signing not configured", func(t *testing.T) {
+		mux := http.NewServeMux()
+		api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+		v0.RegisterTransparencyLogEndpoints(api, "/v0", registryService, &config.Config{})
+
+		req := httptest.NewRequest(http.MethodGet, "/v0/log/tree-head", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
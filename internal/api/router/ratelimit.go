@@ -0,0 +1,120 @@
+package router
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/ratelimit"
+)
+
+// rateLimiters holds a limiter per request budget. A nil limiter leaves its budget unlimited.
+type rateLimiters struct {
+	read    *ratelimit.Limiter
+	publish *ratelimit.Limiter
+	auth    *ratelimit.Limiter
+}
+
+// newRateLimiter creates the limiter of a budget, or nil if the budget is unlimited
+func newRateLimiter(perMinute, burst, maxClients int) *ratelimit.Limiter {
+	if perMinute <= 0 || burst <= 0 {
+		return nil
+	}
+	return ratelimit.NewLimiter(ratelimit.PerMinute(perMinute, burst), maxClients)
+}
+
+// RateLimitMiddleware limits the request rate of each client with separate budgets for token
+// exchanges, writes (publishing and admin operations) and reads. Clients are identified by the
+// subject of their registry JWT if they send a valid one, and by IP address otherwise, with
+// X-Forwarded-For only believed from trusted proxies. Every limited response carries RateLimit-*
+// headers, and requests over budget get 429 Too Many Requests.
+func RateLimitMiddleware(api huma.API, cfg *config.Config) func(huma.Context, func(huma.Context)) {
+	// The registry refuses to start with invalid trusted proxies, so this only falls back to
+	// trusting no proxy for configurations that were not validated
+	trustedProxies, err := cfg.TrustedProxies()
+	if err != nil {
+		log.Printf("Ignoring invalid rate limit trusted proxies: %v", err)
+	}
+	limiters := &rateLimiters{
+		read:    newRateLimiter(cfg.RateLimitReadPerMinute, cfg.RateLimitReadBurst, cfg.RateLimitMaxClients),
+		publish: newRateLimiter(cfg.RateLimitPublishPerMinute, cfg.RateLimitPublishBurst, cfg.RateLimitMaxClients),
+		auth:    newRateLimiter(cfg.RateLimitAuthPerMinute, cfg.RateLimitAuthBurst, cfg.RateLimitMaxClients),
+	}
+	jwtManager := auth.NewJWTManager(cfg)
+
+	return func(ctx huma.Context, next func(huma.Context)) {
+		budget, limiter := limiters.forOperation(ctx)
+		if limiter == nil {
+			next(ctx)
+			return
+		}
+
+		key := rateLimitKey(ctx, jwtManager, trustedProxies)
+		result := limiter.Allow(key)
+
+		ctx.SetHeader("RateLimit-Limit", strconv.Itoa(result.Limit))
+		ctx.SetHeader("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		ctx.SetHeader("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+		if !result.Allowed {
+			retryAfter := ceilSeconds(result.RetryAfter)
+			ctx.SetHeader("Retry-After", strconv.Itoa(retryAfter))
+			_ = huma.WriteErr(api, ctx, http.StatusTooManyRequests,
+				fmt.Sprintf("Rate limit of %d %s requests exceeded, retry in %d seconds", result.Limit, budget, retryAfter))
+			return
+		}
+
+		next(ctx)
+	}
+}
+
+// forOperation returns the budget a request counts against and its limiter
+func (l *rateLimiters) forOperation(ctx huma.Context) (string, *ratelimit.Limiter) {
+	tags := ctx.Operation().Tags
+	switch {
+	case slices.Contains(tags, "health") || slices.Contains(tags, "ping"):
+		// Probes must not be turned away
+		return "", nil
+	case slices.Contains(tags, "auth"):
+		return "auth", l.auth
+	case ctx.Method() != http.MethodGet && ctx.Method() != http.MethodHead:
+		return "publish", l.publish
+	default:
+		return "read", l.read
+	}
+}
+
+// rateLimitKey identifies the client of a request: the subject of a valid registry JWT, or the
+// client IP address, or its /64 network for IPv6. Anonymous tokens all have the same subject, so they are keyed by IP too.
+func rateLimitKey(ctx huma.Context, jwtManager *auth.JWTManager, trustedProxies []netip.Prefix) string {
+	const bearerPrefix = "Bearer "
+	authHeader := ctx.Header("Authorization")
+	if len(authHeader) > len(bearerPrefix) && strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		claims, err := jwtManager.ValidateToken(ctx.Context(), authHeader[len(bearerPrefix):])
+		if err == nil && claims.AuthMethod != auth.MethodNone {
+			return "sub:" + string(claims.AuthMethod) + ":" + claims.AuthMethodSubject
+		}
+	}
+
+	var forwardedFor []string
+	ctx.EachHeader(func(name, value string) {
+		if strings.EqualFold(name, "X-Forwarded-For") {
+			forwardedFor = append(forwardedFor, value)
+		}
+	})
+	return "ip:" + ratelimit.ClientKey(ratelimit.ClientIP(ctx.RemoteAddr(), forwardedFor, trustedProxies))
+}
+
+// ceilSeconds rounds a duration up to whole seconds for HTTP headers
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
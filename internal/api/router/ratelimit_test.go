@@ -0,0 +1,154 @@
+package router_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	cfg := &config.Config{
+		JWTPrivateKey:             hex.EncodeToString(make([]byte, ed25519.SeedSize)),
+		RateLimitTrustedProxies:   []string{"10.0.0.0/8"},
+		RateLimitReadPerMinute:    60,
+		RateLimitReadBurst:        3,
+		RateLimitPublishPerMinute: 60,
+		RateLimitPublishBurst:     1,
+		RateLimitAuthPerMinute:    60,
+		RateLimitAuthBurst:        1,
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(router.RateLimitMiddleware(api, cfg))
+	for _, op := range []huma.Operation{
+		{OperationID: "read", Method: http.MethodGet, Path: "/read", Tags: []string{"servers"}},
+		{OperationID: "publish", Method: http.MethodPost, Path: "/publish", Tags: []string{"publish"}},
+		{OperationID: "token", Method: http.MethodPost, Path: "/token", Tags: []string{"auth"}},
+		{OperationID: "health", Method: http.MethodGet, Path: "/health", Tags: []string{"health"}},
+	} {
+		huma.Register(api, op, func(_ context.Context, _ *struct{}) (*struct{}, error) {
+			return nil, nil
+		})
+	}
+
+	token, err := auth.NewJWTManager(cfg).GenerateTokenResponse(context.Background(), auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "alice",
+		Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"}},
+	})
+	require.NoError(t, err)
+
+	request := func(method, path, remoteAddr string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = remoteAddr
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("reads are limited per client IP", func(t *testing.T) {
+		for i := 2; i >= 0; i-- {
+			w := request(http.MethodGet, "/read", "203.0.113.1:1234", nil)
+			require.Equal(t, http.StatusNoContent, w.Code)
+			assert.Equal(t, "3", w.Header().Get("RateLimit-Limit"))
+			assert.Equal(t, strconv.Itoa(i), w.Header().Get("RateLimit-Remaining"))
+		}
+
+		w := request(http.MethodGet, "/read", "203.0.113.1:1234", nil)
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "1", w.Header().Get("Retry-After"))
+		assert.Equal(t, "3", w.Header().Get("RateLimit-Reset"))
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), "Rate limit of 3 read requests exceeded")
+
+		assert.Equal(t, http.StatusNoContent, request(http.MethodGet, "/read", "203.0.113.2:1234", nil).Code)
+	})
+
+	t.Run("X-Forwarded-For is believed from trusted proxies", func(t *testing.T) {
+		forwarded := map[string]string{"X-Forwarded-For": "198.51.100.1"}
+		for range 3 {
+			require.Equal(t, http.StatusNoContent, request(http.MethodGet, "/read", "10.0.0.1:1234", forwarded).Code)
+		}
+		assert.Equal(t, http.StatusTooManyRequests, request(http.MethodGet, "/read", "10.0.0.2:1234", forwarded).Code)
+		assert.Equal(t, http.StatusNoContent, request(http.MethodGet, "/read", "10.0.0.1:1234", map[string]string{"X-Forwarded-For": "198.51.100.2"}).Code)
+	})
+
+	t.Run("IPv6 clients are limited per /64 network", func(t *testing.T) {
+		for i := range 3 {
+			require.Equal(t, http.StatusNoContent, request(http.MethodGet, "/read", "[2001:db8:1:2::"+strconv.Itoa(i+1)+"]:1234", nil).Code)
+		}
+		assert.Equal(t, http.StatusTooManyRequests, request(http.MethodGet, "/read", "[2001:db8:1:2::ffff]:1234", nil).Code)
+		assert.Equal(t, http.StatusNoContent, request(http.MethodGet, "/read", "[2001:db8:1:3::1]:1234", nil).Code)
+	})
+
+	t.Run("budgets are separate", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, request(http.MethodPost, "/publish", "203.0.113.3:1234", nil).Code)
+		assert.Equal(t, http.StatusTooManyRequests, request(http.MethodPost, "/publish", "203.0.113.3:1234", nil).Code)
+		assert.Equal(t, http.StatusNoContent, request(http.MethodPost, "/token", "203.0.113.3:1234", nil).Code)
+		assert.Equal(t, http.StatusTooManyRequests, request(http.MethodPost, "/token", "203.0.113.3:1234", nil).Code)
+		assert.Equal(t, http.StatusNoContent, request(http.MethodGet, "/read", "203.0.113.3:1234", nil).Code)
+	})
+
+	t.Run("authenticated requests are limited per subject", func(t *testing.T) {
+		bearer := map[string]string{"Authorization": "Bearer " + token.RegistryToken}
+		assert.Equal(t, http.StatusNoContent, request(http.MethodPost, "/publish", "203.0.113.4:1234", bearer).Code)
+		assert.Equal(t, http.StatusTooManyRequests, request(http.MethodPost, "/publish", "203.0.113.5:1234", bearer).Code)
+		assert.Equal(t, http.StatusNoContent, request(http.MethodPost, "/publish", "203.0.113.5:1234", nil).Code)
+	})
+
+	t.Run("health checks are not limited", func(t *testing.T) {
+		for range 5 {
+			w := request(http.MethodGet, "/health", "203.0.113.6:1234", nil)
+			assert.Equal(t, http.StatusNoContent, w.Code)
+			assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+		}
+	})
+}
+
+func TestConfigTrustedProxies(t *testing.T) {
+	cfg := &config.Config{RateLimitTrustedProxies: []string{"10.0.0.0/8", "192.168.1.1"}}
+	proxies, err := cfg.TrustedProxies()
+	require.NoError(t, err)
+	assert.Len(t, proxies, 2)
+
+	cfg.RateLimitTrustedProxies = []string{"10.0.0.0/99"}
+	_, err = cfg.TrustedProxies()
+	assert.Error(t, err)
+
+	// The middleware does not fail on configurations that were not validated, and trusts no proxy
+	cfg.JWTPrivateKey = hex.EncodeToString(make([]byte, ed25519.SeedSize))
+	cfg.RateLimitReadPerMinute = 60
+	cfg.RateLimitReadBurst = 1
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	require.NotPanics(t, func() { api.UseMiddleware(router.RateLimitMiddleware(api, cfg)) })
+	huma.Register(api, huma.Operation{OperationID: "read", Method: http.MethodGet, Path: "/read", Tags: []string{"servers"}},
+		func(_ context.Context, _ *struct{}) (*struct{}, error) { return nil, nil })
+
+	for i, expected := range []int{http.StatusNoContent, http.StatusTooManyRequests} {
+		req := httptest.NewRequest(http.MethodGet, "/read", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set("X-Forwarded-For", "198.51.100."+strconv.Itoa(i+1))
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, expected, w.Code)
+	}
+}
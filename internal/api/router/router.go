@@ -16,6 +16,7 @@ import (
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/snapshot"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
 
@@ -130,7 +131,7 @@ func handle404(w http.ResponseWriter, r *http.Request) {
 }
 
 // NewHumaAPI creates a new Huma API with all routes registered
-func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.ServeMux, metrics *telemetry.Metrics, versionInfo *v0.VersionBody) huma.API {
+func NewHumaAPI(cfg *config.Config, registry service.RegistryService, snapshots *snapshot.Publisher, mux *http.ServeMux, metrics *telemetry.Metrics, versionInfo *v0.VersionBody) huma.API {
 	// Create Huma API configuration
 	humaConfig := huma.DefaultConfig("Official MCP Registry", "1.0.0")
 	humaConfig.Info.Description = "A community driven registry service for Model Context Protocol (MCP) servers.\n\n[GitHub repository](https://github.com/modelcontextprotocol/registry) | [Documentation](https://github.com/modelcontextprotocol/registry/tree/main/docs)"
@@ -177,9 +178,14 @@ func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.
 		WithSkipPaths("/health", "/metrics", "/ping", "/docs"),
 	))
 
+	// Add rate limiting after metrics, so that rejected requests are counted
+	if cfg.RateLimitEnabled {
+		api.UseMiddleware(RateLimitMiddleware(api, cfg))
+	}
+
 	// Register routes for all API versions
-	RegisterV0Routes(api, cfg, registry, metrics, versionInfo)
-	RegisterV0_1Routes(api, cfg, registry, metrics, versionInfo)
+	RegisterV0Routes(api, cfg, registry, snapshots, metrics, versionInfo)
+	RegisterV0_1Routes(api, cfg, registry, snapshots, metrics, versionInfo)
 
 	// Add /metrics for Prometheus metrics using promhttp
 	mux.Handle("/metrics", metrics.PrometheusHandler())
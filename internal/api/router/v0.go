@@ -8,29 +8,48 @@ import (
 	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/snapshot"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
 
 func RegisterV0Routes(
-	api huma.API, cfg *config.Config, registry service.RegistryService, metrics *telemetry.Metrics, versionInfo *v0.VersionBody,
+	api huma.API, cfg *config.Config, registry service.RegistryService, snapshots *snapshot.Publisher, metrics *telemetry.Metrics, versionInfo *v0.VersionBody,
 ) {
 	v0.RegisterHealthEndpoint(api, "/v0", cfg, metrics)
 	v0.RegisterPingEndpoint(api, "/v0")
 	v0.RegisterVersionEndpoint(api, "/v0", versionInfo)
 	v0.RegisterServersEndpoints(api, "/v0", registry)
+	v0.RegisterFacetsEndpoint(api, "/v0", registry)
+	v0.RegisterExportEndpoint(api, "/v0", registry, cfg)
+	v0.RegisterSnapshotEndpoint(api, "/v0", snapshots)
 	v0.RegisterEditEndpoints(api, "/v0", registry, cfg)
-	v0auth.RegisterAuthEndpoints(api, "/v0", cfg)
+	v0.RegisterDistTagEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterStatusEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterRevisionEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterTransparencyLogEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterQuotaEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterBlockedNamespaceEndpoints(api, "/v0", registry, cfg)
+	v0auth.RegisterAuthEndpoints(api, "/v0", cfg, registry)
 	v0.RegisterPublishEndpoint(api, "/v0", registry, cfg)
 }
 
 func RegisterV0_1Routes(
-	api huma.API, cfg *config.Config, registry service.RegistryService, metrics *telemetry.Metrics, versionInfo *v0.VersionBody,
+	api huma.API, cfg *config.Config, registry service.RegistryService, snapshots *snapshot.Publisher, metrics *telemetry.Metrics, versionInfo *v0.VersionBody,
 ) {
 	v0.RegisterHealthEndpoint(api, "/v0.1", cfg, metrics)
 	v0.RegisterPingEndpoint(api, "/v0.1")
 	v0.RegisterVersionEndpoint(api, "/v0.1", versionInfo)
 	v0.RegisterServersEndpoints(api, "/v0.1", registry)
+	v0.RegisterFacetsEndpoint(api, "/v0.1", registry)
+	v0.RegisterExportEndpoint(api, "/v0.1", registry, cfg)
+	v0.RegisterSnapshotEndpoint(api, "/v0.1", snapshots)
 	v0.RegisterEditEndpoints(api, "/v0.1", registry, cfg)
-	v0auth.RegisterAuthEndpoints(api, "/v0.1", cfg)
+	v0.RegisterDistTagEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterStatusEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterRevisionEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterTransparencyLogEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterQuotaEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterBlockedNamespaceEndpoints(api, "/v0.1", registry, cfg)
+	v0auth.RegisterAuthEndpoints(api, "/v0.1", cfg, registry)
 	v0.RegisterPublishEndpoint(api, "/v0.1", registry, cfg)
 }
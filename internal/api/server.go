@@ -15,6 +15,7 @@ import (
 	"github.com/modelcontextprotocol/registry/internal/api/router"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/snapshot"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
 
@@ -92,12 +93,12 @@ type Server struct {
 	server   *http.Server
 }
 
-// NewServer creates a new HTTP server
-func NewServer(cfg *config.Config, registryService service.RegistryService, metrics *telemetry.Metrics, versionInfo *v0.VersionBody) *Server {
+// NewServer creates a new HTTP server, serving snapshot manifests from snapshots if it is not nil
+func NewServer(cfg *config.Config, registryService service.RegistryService, snapshots *snapshot.Publisher, metrics *telemetry.Metrics, versionInfo *v0.VersionBody) *Server {
 	// Create HTTP mux and Huma API
 	mux := http.NewServeMux()
 
-	api := router.NewHumaAPI(cfg, registryService, mux, metrics, versionInfo)
+	api := router.NewHumaAPI(cfg, registryService, snapshots, mux, metrics, versionInfo)
 
 	// Configure CORS with permissive settings for public API
 	corsHandler := cors.New(cors.Options{
@@ -110,7 +111,7 @@ func NewServer(cfg *config.Config, registryService service.RegistryService, metr
 			http.MethodOptions,
 		},
 		AllowedHeaders:   []string{"*"},
-		ExposedHeaders:   []string{"Content-Type", "Content-Length"},
+		ExposedHeaders:   []string{"Content-Type", "Content-Length", "ETag", "Last-Modified", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After"},
 		AllowCredentials: false, // Must be false when AllowedOrigins is "*"
 		MaxAge:           86400, // 24 hours
 	})
@@ -1,9 +1,53 @@
 package auth
 
-// BlockedNamespaces contains a list of namespaces that are not allowed to publish packages.
-// This is used as a denylist mechanism to prevent abuse.
-var BlockedNamespaces = []string{
-	// Add blocked namespaces here, e.g.:
-	// "io.github.spammer",
-	// "com.evil-domain",
+import (
+	"slices"
+	"sync"
+)
+
+// NamespaceDenylist is an in-memory list of namespaces that are not allowed to publish packages.
+// It is safe for concurrent use.
+type NamespaceDenylist struct {
+	mu         sync.RWMutex
+	namespaces []string
+}
+
+// Replace replaces the blocked namespaces
+func (d *NamespaceDenylist) Replace(namespaces []string) {
+	namespaces = slices.Clone(namespaces)
+	slices.Sort(namespaces)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.namespaces = slices.Compact(namespaces)
+}
+
+// Add blocks a namespace
+func (d *NamespaceDenylist) Add(namespace string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if i, found := slices.BinarySearch(d.namespaces, namespace); !found {
+		d.namespaces = slices.Insert(d.namespaces, i, namespace)
+	}
 }
+
+// Remove unblocks a namespace
+func (d *NamespaceDenylist) Remove(namespace string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if i, found := slices.BinarySearch(d.namespaces, namespace); found {
+		d.namespaces = slices.Delete(d.namespaces, i, i+1)
+	}
+}
+
+// Namespaces returns the blocked namespaces in order
+func (d *NamespaceDenylist) Namespaces() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return slices.Clone(d.namespaces)
+}
+
+// BlockedNamespaces is the denylist mechanism used to prevent abuse: tokens that can publish to
+// a blocked namespace are not issued. The registry keeps it in sync with the namespaces blocked
+// through the admin API, which are stored in the database.
+var BlockedNamespaces = &NamespaceDenylist{}
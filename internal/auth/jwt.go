@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/ed25519"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -12,6 +13,9 @@ import (
 	"github.com/modelcontextprotocol/registry/internal/config"
 )
 
+// ErrNamespaceBlocked is returned when a token would grant publish permission on a blocked namespace
+var ErrNamespaceBlocked = errors.New("your namespace is blocked. raise an issue at https://github.com/modelcontextprotocol/registry/ if you think this is a mistake")
+
 // PermissionAction represents the type of action that can be performed
 type PermissionAction string
 
@@ -82,9 +86,9 @@ func (j *JWTManager) GenerateTokenResponse(_ context.Context, claims JWTClaims)
 
 	// Check permissions against denylist, provided they are not an admin
 	if !hasGlobalPermissions {
-		for _, blockedNamespace := range BlockedNamespaces {
+		for _, blockedNamespace := range BlockedNamespaces.Namespaces() {
 			if j.HasPermission(blockedNamespace+"/test", PermissionActionPublish, claims.Permissions) {
-				return nil, fmt.Errorf("your namespace is blocked. raise an issue at https://github.com/modelcontextprotocol/registry/ if you think this is a mistake")
+				return nil, ErrNamespaceBlocked
 			}
 		}
 	}
@@ -117,6 +121,11 @@ func (j *JWTManager) GenerateTokenResponse(_ context.Context, claims JWTClaims)
 	}, nil
 }
 
+// TokenDuration returns how long Registry JWT tokens are valid for by default
+func (j *JWTManager) TokenDuration() time.Duration {
+	return j.tokenDuration
+}
+
 // ValidateToken validates a Registry JWT token and returns the claims
 func (j *JWTManager) ValidateToken(_ context.Context, tokenString string) (*JWTClaims, error) {
 	// Parse token
@@ -155,6 +164,32 @@ func (j *JWTManager) HasPermission(resource string, action PermissionAction, per
 	return false
 }
 
+// HasPermissions reports whether every requested permission is covered by the granted permissions,
+// including requested patterns: "io.github.octocat/*" covers "io.github.octocat/tools-*" but not "*"
+func (j *JWTManager) HasPermissions(requested, granted []Permission) bool {
+	for _, req := range requested {
+		covered := false
+		for _, perm := range granted {
+			if perm.Action == req.Action && isPatternMatch(req.ResourcePattern, perm.ResourcePattern) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// isPatternMatch reports whether every resource matched by requested is matched by pattern
+func isPatternMatch(requested, pattern string) bool {
+	if prefix, ok := strings.CutSuffix(requested, "*"); ok {
+		return strings.HasSuffix(pattern, "*") && strings.HasPrefix(prefix, strings.TrimSuffix(pattern, "*"))
+	}
+	return isResourceMatch(requested, pattern)
+}
+
 func isResourceMatch(resource, pattern string) bool {
 	if pattern == "*" {
 		return true
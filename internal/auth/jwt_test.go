@@ -272,6 +272,100 @@ func TestJWTManager_HasPermission(t *testing.T) {
 	}
 }
 
+func TestJWTManager_HasPermissions(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+
+	jwtManager := auth.NewJWTManager(&config.Config{
+		JWTPrivateKey: hex.EncodeToString(testSeed),
+	})
+
+	granted := []auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.testuser/*"},
+		{Action: auth.PermissionActionEdit, ResourcePattern: "io.github.testuser/server1"},
+	}
+
+	tests := []struct {
+		name      string
+		requested []auth.Permission
+		granted   []auth.Permission
+		expected  bool
+	}{
+		{
+			name:      "same permissions",
+			requested: granted,
+			granted:   granted,
+			expected:  true,
+		},
+		{
+			name: "narrower wildcard",
+			requested: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.testuser/tools-*"},
+			},
+			granted:  granted,
+			expected: true,
+		},
+		{
+			name: "exact server within wildcard",
+			requested: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.testuser/server2"},
+			},
+			granted:  granted,
+			expected: true,
+		},
+		{
+			name: "broader wildcard",
+			requested: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.*"},
+			},
+			granted:  granted,
+			expected: false,
+		},
+		{
+			name: "global wildcard",
+			requested: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+			},
+			granted:  granted,
+			expected: false,
+		},
+		{
+			name: "wildcard within exact server",
+			requested: []auth.Permission{
+				{Action: auth.PermissionActionEdit, ResourcePattern: "io.github.testuser/server1*"},
+			},
+			granted:  granted,
+			expected: false,
+		},
+		{
+			name: "different action",
+			requested: []auth.Permission{
+				{Action: auth.PermissionActionEdit, ResourcePattern: "io.github.testuser/server2"},
+			},
+			granted:  granted,
+			expected: false,
+		},
+		{
+			name: "global permissions cover everything",
+			requested: []auth.Permission{
+				{Action: auth.PermissionActionEdit, ResourcePattern: "*"},
+				{Action: auth.PermissionActionEdit, ResourcePattern: "com.example/*"},
+			},
+			granted: []auth.Permission{
+				{Action: auth.PermissionActionEdit, ResourcePattern: "*"},
+			},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, jwtManager.HasPermissions(tt.requested, tt.granted))
+		})
+	}
+}
+
 func TestNewJWTManager_InvalidKeySize(t *testing.T) {
 	// Test with invalid key size (should panic)
 	cfg := &config.Config{
@@ -297,9 +391,9 @@ func TestJWTManager_BlockedNamespaces(t *testing.T) {
 
 	t.Run("blocked namespace should deny token", func(t *testing.T) {
 		// Temporarily override blocked namespaces for testing
-		originalBlocked := auth.BlockedNamespaces
-		auth.BlockedNamespaces = []string{"io.github.spammer"}
-		defer func() { auth.BlockedNamespaces = originalBlocked }()
+		originalBlocked := auth.BlockedNamespaces.Namespaces()
+		auth.BlockedNamespaces.Replace([]string{"io.github.spammer"})
+		defer auth.BlockedNamespaces.Replace(originalBlocked)
 
 		jwtManager := auth.NewJWTManager(cfg)
 
@@ -315,16 +409,16 @@ func TestJWTManager_BlockedNamespaces(t *testing.T) {
 		}
 
 		tokenResponse, err := jwtManager.GenerateTokenResponse(ctx, claims)
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, auth.ErrNamespaceBlocked)
 		assert.Contains(t, err.Error(), "your namespace is blocked")
 		assert.Nil(t, tokenResponse)
 	})
 
 	t.Run("non-blocked namespace should allow token", func(t *testing.T) {
 		// Temporarily override blocked namespaces for testing
-		originalBlocked := auth.BlockedNamespaces
-		auth.BlockedNamespaces = []string{"io.github.spammer"}
-		defer func() { auth.BlockedNamespaces = originalBlocked }()
+		originalBlocked := auth.BlockedNamespaces.Namespaces()
+		auth.BlockedNamespaces.Replace([]string{"io.github.spammer"})
+		defer auth.BlockedNamespaces.Replace(originalBlocked)
 
 		jwtManager := auth.NewJWTManager(cfg)
 
@@ -346,9 +440,9 @@ func TestJWTManager_BlockedNamespaces(t *testing.T) {
 
 	t.Run("multiple permissions with one blocked should deny token", func(t *testing.T) {
 		// Temporarily override blocked namespaces for testing
-		originalBlocked := auth.BlockedNamespaces
-		auth.BlockedNamespaces = []string{"io.github.badorg"}
-		defer func() { auth.BlockedNamespaces = originalBlocked }()
+		originalBlocked := auth.BlockedNamespaces.Namespaces()
+		auth.BlockedNamespaces.Replace([]string{"io.github.badorg"})
+		defer auth.BlockedNamespaces.Replace(originalBlocked)
 
 		jwtManager := auth.NewJWTManager(cfg)
 
@@ -375,9 +469,9 @@ func TestJWTManager_BlockedNamespaces(t *testing.T) {
 
 	t.Run("global admin permissions should bypass denylist", func(t *testing.T) {
 		// Temporarily override blocked namespaces for testing
-		originalBlocked := auth.BlockedNamespaces
-		auth.BlockedNamespaces = []string{"io.github.spammer"}
-		defer func() { auth.BlockedNamespaces = originalBlocked }()
+		originalBlocked := auth.BlockedNamespaces.Namespaces()
+		auth.BlockedNamespaces.Replace([]string{"io.github.spammer"})
+		defer auth.BlockedNamespaces.Replace(originalBlocked)
 
 		jwtManager := auth.NewJWTManager(cfg)
 
@@ -397,3 +491,19 @@ func TestJWTManager_BlockedNamespaces(t *testing.T) {
 		assert.NotEmpty(t, tokenResponse.RegistryToken)
 	})
 }
+
+func TestNamespaceDenylist(t *testing.T) {
+	denylist := &auth.NamespaceDenylist{}
+	assert.Empty(t, denylist.Namespaces())
+
+	denylist.Replace([]string{"io.github.spammer", "com.evil", "io.github.spammer"})
+	assert.Equal(t, []string{"com.evil", "io.github.spammer"}, denylist.Namespaces())
+
+	denylist.Add("io.github.badorg")
+	denylist.Add("com.evil")
+	assert.Equal(t, []string{"com.evil", "io.github.badorg", "io.github.spammer"}, denylist.Namespaces())
+
+	denylist.Remove("com.evil")
+	denylist.Remove("com.unknown")
+	assert.Equal(t, []string{"io.github.badorg", "io.github.spammer"}, denylist.Namespaces())
+}
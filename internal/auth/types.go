@@ -14,6 +14,8 @@ const (
 	MethodDNS Method = "dns"
 	// HTTP-based public/private key authentication
 	MethodHTTP Method = "http"
+	// Long-lived API token created by a logged-in user
+	MethodAPIToken Method = "api-token"
 	// No authentication - should only be used for local development and testing
 	MethodNone Method = "none"
 )
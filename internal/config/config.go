@@ -1,7 +1,13 @@
 package config
 
 import (
+	"net/netip"
+	"time"
+
 	env "github.com/caarlos0/env/v11"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/ratelimit"
 )
 
 // Config holds the application configuration
@@ -16,6 +22,58 @@ type Config struct {
 	JWTPrivateKey            string `env:"JWT_PRIVATE_KEY" envDefault:""`
 	EnableAnonymousAuth      bool   `env:"ENABLE_ANONYMOUS_AUTH" envDefault:"false"`
 	EnableRegistryValidation bool   `env:"ENABLE_REGISTRY_VALIDATION" envDefault:"true"`
+	CursorSigningKey         string `env:"CURSOR_SIGNING_KEY" envDefault:""`
+
+	// Signed snapshot manifests
+	SnapshotSigningKey string        `env:"SNAPSHOT_SIGNING_KEY" envDefault:""`
+	SnapshotInterval   time.Duration `env:"SNAPSHOT_INTERVAL" envDefault:"1h"`
+	SeedPublicKey      string        `env:"SEED_PUBLIC_KEY" envDefault:""`
+	SeedManifest       string        `env:"SEED_MANIFEST" envDefault:""`
+
+	// Read replicas, as a comma-separated list of connection URLs
+	DatabaseReplicaURLs []string `env:"DATABASE_REPLICA_URLS" envSeparator:","`
+
+	// Database connection pools and timeouts
+	DatabaseMaxConns         int32         `env:"DATABASE_MAX_CONNS" envDefault:"30"`
+	DatabaseMinConns         int32         `env:"DATABASE_MIN_CONNS" envDefault:"5"`
+	DatabaseMaxConnLifetime  time.Duration `env:"DATABASE_MAX_CONN_LIFETIME" envDefault:"2h"`
+	DatabaseMaxConnIdleTime  time.Duration `env:"DATABASE_MAX_CONN_IDLE_TIME" envDefault:"30m"`
+	DatabaseStatementTimeout time.Duration `env:"DATABASE_STATEMENT_TIMEOUT" envDefault:"30s"`
+	DatabaseLockTimeout      time.Duration `env:"DATABASE_LOCK_TIMEOUT" envDefault:"10s"`
+
+	// Bulk exports, which each hold a database connection for as long as the client reads. 0 means unlimited.
+	ExportMaxConcurrent int           `env:"EXPORT_MAX_CONCURRENT" envDefault:"4"`
+	ExportTimeout       time.Duration `env:"EXPORT_TIMEOUT" envDefault:"10m"`
+
+	// In-process read cache
+	CacheEnabled    bool          `env:"CACHE_ENABLED" envDefault:"true"`
+	CacheMaxEntries int           `env:"CACHE_MAX_ENTRIES" envDefault:"10000"`
+	CacheTTL        time.Duration `env:"CACHE_TTL" envDefault:"5m"`
+
+	// Rate limits per client, in requests per minute with bursts of up to the burst size. Clients
+	// are identified by IP address, or by token subject for authenticated requests. Each limit keeps
+	// the budgets of at most RateLimitMaxClients clients at a time.
+	RateLimitEnabled          bool     `env:"RATE_LIMIT_ENABLED" envDefault:"true"`
+	RateLimitTrustedProxies   []string `env:"RATE_LIMIT_TRUSTED_PROXIES" envSeparator:","`
+	RateLimitReadPerMinute    int      `env:"RATE_LIMIT_READ_PER_MINUTE" envDefault:"600"`
+	RateLimitReadBurst        int      `env:"RATE_LIMIT_READ_BURST" envDefault:"120"`
+	RateLimitPublishPerMinute int      `env:"RATE_LIMIT_PUBLISH_PER_MINUTE" envDefault:"30"`
+	RateLimitPublishBurst     int      `env:"RATE_LIMIT_PUBLISH_BURST" envDefault:"10"`
+	RateLimitAuthPerMinute    int      `env:"RATE_LIMIT_AUTH_PER_MINUTE" envDefault:"20"`
+	RateLimitAuthBurst        int      `env:"RATE_LIMIT_AUTH_BURST" envDefault:"10"`
+	RateLimitMaxClients       int      `env:"RATE_LIMIT_MAX_CLIENTS" envDefault:"100000"`
+
+	// Publish quotas, which admins can override per namespace, server or publisher. 0 means unlimited.
+	PublishQuotaServersPerNamespace int `env:"PUBLISH_QUOTA_SERVERS_PER_NAMESPACE" envDefault:"100"`
+	PublishQuotaVersionsPerDay      int `env:"PUBLISH_QUOTA_VERSIONS_PER_DAY" envDefault:"100"`
+	PublishQuotaPublishesPerHour    int `env:"PUBLISH_QUOTA_PUBLISHES_PER_HOUR" envDefault:"60"`
+
+	// How often each replica reloads the namespaces blocked from publishing
+	BlockedNamespacesRefreshInterval time.Duration `env:"BLOCKED_NAMESPACES_REFRESH_INTERVAL" envDefault:"1m"`
+
+	// Longest lifetime users can give the API tokens they create. API tokens keep the permissions they
+	// were created with, so this also bounds how long they outlive their owner's access.
+	APITokenMaxLifetime time.Duration `env:"API_TOKEN_MAX_LIFETIME" envDefault:"720h"`
 
 	// OIDC Configuration
 	OIDCEnabled      bool   `env:"OIDC_ENABLED" envDefault:"false"`
@@ -37,3 +95,22 @@ func NewConfig() *Config {
 	}
 	return &cfg
 }
+
+// TrustedProxies parses the IP addresses and CIDR ranges of the reverse proxies whose
+// X-Forwarded-For headers are believed when rate limiting
+func (c *Config) TrustedProxies() ([]netip.Prefix, error) {
+	return ratelimit.ParseTrustedProxies(c.RateLimitTrustedProxies)
+}
+
+// DatabaseOptions returns the connection pool and timeout options of the database
+func (c *Config) DatabaseOptions() *database.Options {
+	return &database.Options{
+		ReplicaURIs:      c.DatabaseReplicaURLs,
+		MaxConns:         c.DatabaseMaxConns,
+		MinConns:         c.DatabaseMinConns,
+		MaxConnLifetime:  c.DatabaseMaxConnLifetime,
+		MaxConnIdleTime:  c.DatabaseMaxConnIdleTime,
+		StatementTimeout: c.DatabaseStatementTimeout,
+		LockTimeout:      c.DatabaseLockTimeout,
+	}
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/canonicaljson"
+)
+
+// contentDigest returns the value of the content_digest column for a server.json: the
+// hex-encoded SHA-256 of its canonical JSON encoding, as in snapshot manifests and the
+// transparency log
+func contentDigest(serverJSON *apiv0.ServerJSON) (string, error) {
+	digest, err := canonicaljson.Digest(serverJSON)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute content digest: %w", err)
+	}
+	return digest, nil
+}
+
+// backfillContentDigests populates content_digest for rows written before the column existed
+func backfillContentDigests(ctx context.Context, conn *pgx.Conn) error {
+	rows, err := conn.Query(ctx, `SELECT server_name, version, value FROM servers WHERE content_digest IS NULL`)
+	if err != nil {
+		return fmt.Errorf("failed to query servers without content digests: %w", err)
+	}
+
+	batch := &pgx.Batch{}
+	for rows.Next() {
+		var serverName, version string
+		var valueJSON []byte
+		if err := rows.Scan(&serverName, &version, &valueJSON); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan server row: %w", err)
+		}
+
+		var serverJSON apiv0.ServerJSON
+		if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to unmarshal server JSON: %w", err)
+		}
+		digest, err := contentDigest(&serverJSON)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		batch.Queue(`UPDATE servers SET content_digest = $1 WHERE server_name = $2 AND version = $3`,
+			digest, serverName, version)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	if err := conn.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to backfill content digests: %w", err)
+	}
+	return nil
+}
@@ -6,27 +6,143 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/transparency"
 )
 
 // Common database errors
 var (
-	ErrNotFound          = errors.New("record not found")
-	ErrAlreadyExists     = errors.New("record already exists")
-	ErrInvalidInput      = errors.New("invalid input")
-	ErrDatabase          = errors.New("database error")
-	ErrInvalidVersion    = errors.New("invalid version: cannot publish duplicate version")
-	ErrMaxServersReached = errors.New("maximum number of versions for this server reached (10000): please reach out at https://github.com/modelcontextprotocol/registry to explain your use case")
+	ErrNotFound           = errors.New("record not found")
+	ErrAlreadyExists      = errors.New("record already exists")
+	ErrInvalidInput       = errors.New("invalid input")
+	ErrDatabase           = errors.New("database error")
+	ErrInvalidVersion     = errors.New("invalid version: cannot publish duplicate version")
+	ErrMaxServersReached  = errors.New("maximum number of versions for this server reached (10000): please reach out at https://github.com/modelcontextprotocol/registry to explain your use case")
+	ErrPreconditionFailed = errors.New("precondition failed")
+	ErrNamespaceBlocked   = errors.New("namespace is blocked from publishing")
 )
 
+// IsTimeout reports whether an error was caused by a statement timeout or a lock timeout, which
+// are transient and worth retrying later
+func IsTimeout(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	// query_canceled (statement timeouts and cancellations) and lock_not_available (lock timeouts)
+	return pgErr.Code == "57014" || pgErr.Code == "55P03"
+}
+
 // ServerFilter defines filtering options for server queries
 type ServerFilter struct {
-	Name          *string    // for finding versions of same server
-	RemoteURL     *string    // for duplicate URL detection
-	UpdatedSince  *time.Time // for incremental sync filtering
-	SubstringName *string    // for substring search on name
-	Version       *string    // for exact version matching
-	IsLatest      *bool      // for filtering latest versions only
+	Name          *string        // for finding versions of same server
+	RemoteURL     *string        // for duplicate URL detection
+	UpdatedSince  *time.Time     // for incremental sync filtering
+	SubstringName *string        // for substring search on name
+	Version       *string        // for exact version matching
+	IsLatest      *bool          // for filtering latest versions only
+	Status        []model.Status // for filtering by lifecycle status (any of); all statuses if empty
+	Namespace     *string        // for servers in a namespace or its sub-namespaces ("com.example" matches "com.example.team/...")
+	RegistryType  *string        // for servers with a package from this registry type
+	PackageID     *string        // for servers with a package with this identifier
+	TransportType *string        // for servers with a package or remote using this transport
+	RepositoryURL *string        // for servers built from this source repository
+	HasRemotes    *bool          // for servers with (or without) remotes
+}
+
+// VersionOrderBy defines the available orderings for version listings
+type VersionOrderBy string
+
+const (
+	// VersionOrderByVersion orders by version precedence (semver, falling back to publish time)
+	VersionOrderByVersion VersionOrderBy = "version"
+	// VersionOrderByPublishedAt orders by publication time
+	VersionOrderByPublishedAt VersionOrderBy = "published_at"
+)
+
+// VersionListOptions defines ordering options for listing the versions of a server
+type VersionListOptions struct {
+	OrderBy    VersionOrderBy // defaults to VersionOrderByVersion
+	Descending bool
+}
+
+// ServerOrderBy defines the available orderings for server listings
+type ServerOrderBy string
+
+const (
+	// ServerOrderByName orders by server name, then by version precedence within a server
+	ServerOrderByName ServerOrderBy = "name"
+	// ServerOrderByPublishedAt orders by publication time
+	ServerOrderByPublishedAt ServerOrderBy = "published_at"
+	// ServerOrderByUpdatedAt orders by last update time
+	ServerOrderByUpdatedAt ServerOrderBy = "updated_at"
+)
+
+// ServerListOptions defines ordering options for listing servers
+type ServerListOptions struct {
+	OrderBy    ServerOrderBy // defaults to ServerOrderByName
+	Descending bool
+}
+
+// ServerListPosition is the sort key of a row in a server listing; a page continues after it
+type ServerListPosition struct {
+	ServerName string
+	Version    string
+	// Timestamp is the published_at or updated_at value of the row when ordering by time
+	Timestamp time.Time
+}
+
+// StatusDetails is the optional context recorded alongside a status change
+type StatusDetails struct {
+	// Message explains the status, e.g. why a version is deprecated
+	Message string
+	// ReplacedBy points users at the server or version to use instead
+	ReplacedBy *apiv0.ServerReference
+}
+
+// columns returns the nullable status_message, replaced_by_server_name and replaced_by_version values
+func (d *StatusDetails) columns() (*string, *string, *string) {
+	if d == nil {
+		return nil, nil, nil
+	}
+	var message, replacedByServerName, replacedByVersion *string
+	if d.Message != "" {
+		message = &d.Message
+	}
+	if d.ReplacedBy != nil {
+		replacedByServerName = &d.ReplacedBy.ServerName
+		if d.ReplacedBy.Version != "" {
+			replacedByVersion = &d.ReplacedBy.Version
+		}
+	}
+	return message, replacedByServerName, replacedByVersion
+}
+
+// PublishQuota identifies a publish quota
+type PublishQuota string
+
+const (
+	// QuotaServersPerNamespace limits the number of servers in a namespace
+	QuotaServersPerNamespace PublishQuota = "servers_per_namespace"
+	// QuotaVersionsPerDay limits the number of versions of a server published in 24 hours
+	QuotaVersionsPerDay PublishQuota = "versions_per_day"
+	// QuotaPublishesPerHour limits the number of versions a publisher publishes in an hour
+	QuotaPublishesPerHour PublishQuota = "publishes_per_hour"
+)
+
+// PoolStats describes the connections of one database connection pool
+type PoolStats struct {
+	Name              string        // "primary", or "replica-N" for the Nth read replica
+	Healthy           bool          // whether reads are routed to the pool; always true for the primary
+	MaxConns          int32         // maximum size of the pool
+	TotalConns        int32         // connections currently open
+	IdleConns         int32         // open connections not in use
+	AcquiredConns     int32         // connections in use
+	AcquireCount      int64         // connections acquired since the pool was created
+	EmptyAcquireCount int64         // acquisitions that had to wait for or open a connection
+	AcquireDuration   time.Duration // total time spent acquiring connections
 }
 
 // Database defines the interface for database operations
@@ -35,16 +151,27 @@ type Database interface {
 	CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *apiv0.ServerJSON, officialMeta *apiv0.RegistryExtensions) (*apiv0.ServerResponse, error)
 	// UpdateServer updates an existing server record
 	UpdateServer(ctx context.Context, tx pgx.Tx, serverName, version string, serverJSON *apiv0.ServerJSON) (*apiv0.ServerResponse, error)
-	// SetServerStatus updates the status of a specific server version
-	SetServerStatus(ctx context.Context, tx pgx.Tx, serverName, version string, status string) (*apiv0.ServerResponse, error)
-	// ListServers retrieve server entries with optional filtering
-	ListServers(ctx context.Context, tx pgx.Tx, filter *ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error)
+	// SetServerStatus updates the status of a specific server version along with its status details,
+	// removing the dist-tags of versions it deletes
+	SetServerStatus(ctx context.Context, tx pgx.Tx, serverName, version string, status string, details *StatusDetails) (*apiv0.ServerResponse, error)
+	// ListServers retrieve a page of server entries with optional filtering in the requested order, continuing after a position
+	ListServers(ctx context.Context, tx pgx.Tx, filter *ServerFilter, options *ServerListOptions, after *ServerListPosition, limit int) ([]*apiv0.ServerResponse, *ServerListPosition, error)
+	// CountServers count the server versions matching a filter
+	CountServers(ctx context.Context, tx pgx.Tx, filter *ServerFilter) (int, error)
+	// ExportServers stream every server version matching a filter to fn in listing order without buffering
+	ExportServers(ctx context.Context, tx pgx.Tx, filter *ServerFilter, fn func(*apiv0.ServerResponse) error) error
+	// GetServerFacets count the servers matching a filter per status, namespace, registry type and transport type
+	GetServerFacets(ctx context.Context, tx pgx.Tx, filter *ServerFilter) (*apiv0.ServerFacetsResponse, error)
 	// GetServerByName retrieve a single server by its name
 	GetServerByName(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerResponse, error)
 	// GetServerByNameAndVersion retrieve specific version of a server by server name and version
 	GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx, serverName string, version string) (*apiv0.ServerResponse, error)
+	// GetServerByContentDigest retrieve the server version whose current server.json has a canonical content digest
+	GetServerByContentDigest(ctx context.Context, tx pgx.Tx, digest string) (*apiv0.ServerResponse, error)
 	// GetAllVersionsByServerName retrieve all versions of a server by server name
 	GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx, serverName string) ([]*apiv0.ServerResponse, error)
+	// ListServerVersions retrieve a page of the versions of a server in the requested order
+	ListServerVersions(ctx context.Context, tx pgx.Tx, serverName string, options *VersionListOptions, cursor string, limit int) ([]*apiv0.ServerResponse, string, error)
 	// GetCurrentLatestVersion retrieve the current latest version of a server by server name
 	GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerResponse, error)
 	// CountServerVersions count the number of versions for a server
@@ -53,11 +180,75 @@ type Database interface {
 	CheckVersionExists(ctx context.Context, tx pgx.Tx, serverName, version string) (bool, error)
 	// UnmarkAsLatest marks the current latest version of a server as no longer latest
 	UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName string) error
+	// MarkAsLatest marks a specific version of a server as latest
+	MarkAsLatest(ctx context.Context, tx pgx.Tx, serverName, version string) error
+	// GetDistTags retrieve the named dist-tags of a server, keyed by tag (excluding the derived "latest" tag)
+	GetDistTags(ctx context.Context, tx pgx.Tx, serverName string) (map[string]string, error)
+	// SetDistTag points a named dist-tag of a server at a specific version
+	SetDistTag(ctx context.Context, tx pgx.Tx, serverName, tag, version string) error
+	// DeleteDistTag removes a named dist-tag from a server
+	DeleteDistTag(ctx context.Context, tx pgx.Tx, serverName, tag string) error
+	// CreateServerRevision appends a server.json to the edit history of a server version
+	CreateServerRevision(ctx context.Context, tx pgx.Tx, serverName, version string, serverJSON *apiv0.ServerJSON, editedBy string) (*apiv0.ServerRevision, error)
+	// ListServerRevisions retrieve the edit history of a server version, oldest first
+	ListServerRevisions(ctx context.Context, tx pgx.Tx, serverName, version string) ([]*apiv0.ServerRevision, error)
+	// GetServerRevision retrieve a specific revision of a server version
+	GetServerRevision(ctx context.Context, tx pgx.Tx, serverName, version string, revision int) (*apiv0.ServerRevision, error)
+	// AppendLogEntry appends an entry to the transparency log and returns its log index
+	AppendLogEntry(ctx context.Context, tx pgx.Tx, entry *transparency.LogEntry) (int64, error)
+	// GetLogTreeSize count the entries in the transparency log
+	GetLogTreeSize(ctx context.Context, tx pgx.Tx) (int64, error)
+	// GetLogSubtreeHash retrieve the hash of a complete subtree of the transparency log
+	GetLogSubtreeHash(ctx context.Context, tx pgx.Tx, level int, index int64) ([]byte, error)
+	// FindLogEntry retrieve the latest transparency log entry of a server version, or the entry of a specific revision if revision > 0
+	FindLogEntry(ctx context.Context, tx pgx.Tx, serverName, version string, revision int) (int64, *transparency.LogEntry, error)
+	// SetServerPublisher records the identity that published a server version
+	SetServerPublisher(ctx context.Context, tx pgx.Tx, serverName, version, publishedBy string) error
+	// CountNamespaceServers count the servers in a namespace, excluding its sub-namespaces
+	CountNamespaceServers(ctx context.Context, tx pgx.Tx, namespace string) (int, error)
+	// CountVersionsPublishedSince count the versions of a server published after a time, and return when the earliest of them was published
+	CountVersionsPublishedSince(ctx context.Context, tx pgx.Tx, serverName string, since time.Time) (int, time.Time, error)
+	// CountPublishesSince count the server versions a publisher published after a time, and return when the earliest of them was published
+	CountPublishesSince(ctx context.Context, tx pgx.Tx, publishedBy string, since time.Time) (int, time.Time, error)
+	// ListPublishQuotaOverrides retrieve every publish quota override, ordered by quota and subject
+	ListPublishQuotaOverrides(ctx context.Context, tx pgx.Tx) ([]*apiv0.PublishQuotaOverride, error)
+	// GetPublishQuotaOverride retrieve the override of a publish quota for a subject
+	GetPublishQuotaOverride(ctx context.Context, tx pgx.Tx, quota PublishQuota, subject string) (*apiv0.PublishQuotaOverride, error)
+	// SetPublishQuotaOverride creates or replaces the override of a publish quota for a subject
+	SetPublishQuotaOverride(ctx context.Context, tx pgx.Tx, quota PublishQuota, subject string, limit int, reason string) (*apiv0.PublishQuotaOverride, error)
+	// DeletePublishQuotaOverride removes the override of a publish quota for a subject
+	DeletePublishQuotaOverride(ctx context.Context, tx pgx.Tx, quota PublishQuota, subject string) error
+	// ListBlockedNamespaces retrieve every namespace blocked from publishing, ordered by namespace
+	ListBlockedNamespaces(ctx context.Context, tx pgx.Tx) ([]*apiv0.BlockedNamespace, error)
+	// IsNamespaceBlocked check if a namespace or one of its parent namespaces is blocked from publishing
+	IsNamespaceBlocked(ctx context.Context, tx pgx.Tx, namespace string) (bool, error)
+	// BlockNamespace blocks a namespace from publishing, or updates the reason it is blocked for
+	BlockNamespace(ctx context.Context, tx pgx.Tx, namespace, reason, blockedBy string) (*apiv0.BlockedNamespace, error)
+	// UnblockNamespace allows a blocked namespace to publish again
+	UnblockNamespace(ctx context.Context, tx pgx.Tx, namespace string) error
+	// CreateAPIToken stores a new API token under the SHA-256 hash of its secret
+	CreateAPIToken(ctx context.Context, tx pgx.Tx, token *apiv0.APIToken, tokenHash []byte) (*apiv0.APIToken, error)
+	// ListAPITokens retrieve the API tokens of an owner, newest first
+	ListAPITokens(ctx context.Context, tx pgx.Tx, owner string) ([]*apiv0.APIToken, error)
+	// GetAPITokenByHash retrieve the API token with the SHA-256 hash of its secret
+	GetAPITokenByHash(ctx context.Context, tx pgx.Tx, tokenHash []byte) (*apiv0.APIToken, error)
+	// MarkAPITokenUsed records that an API token was just used
+	MarkAPITokenUsed(ctx context.Context, tx pgx.Tx, id string) error
+	// DeleteAPIToken revokes an API token of an owner
+	DeleteAPIToken(ctx context.Context, tx pgx.Tx, id, owner string) error
+	// AcquirePublishQuotaLock acquires an exclusive advisory lock on the subject of a publish quota
+	// for the rest of the transaction, so that concurrent publishes are counted against it in turn
+	AcquirePublishQuotaLock(ctx context.Context, tx pgx.Tx, quota PublishQuota, subject string) error
 	// AcquirePublishLock acquires an exclusive advisory lock for publishing a server
 	// This prevents race conditions when multiple versions are published concurrently
 	AcquirePublishLock(ctx context.Context, tx pgx.Tx, serverName string) error
+	// ListenForServerChanges calls fn with the name of each server changed by any registry replica until ctx is done or the connection fails.
+	// fn is first called with an empty name once listening starts, as changes made before may have been missed.
+	ListenForServerChanges(ctx context.Context, fn func(serverName string)) error
 	// InTransaction executes a function within a database transaction
 	InTransaction(ctx context.Context, fn func(ctx context.Context, tx pgx.Tx) error) error
+	// PoolStats returns the connection statistics of the primary pool and of each read replica pool
+	PoolStats() []PoolStats
 	// Close closes the database connection
 	Close() error
 }
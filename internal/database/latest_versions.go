@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/versioning"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// backfillLatestVersions recomputes the latest version of the servers queued in
+// pending_latest_versions, whose latest flag may have been chosen by an older rule
+func backfillLatestVersions(ctx context.Context, conn *pgx.Conn) error {
+	rows, err := conn.Query(ctx, `SELECT server_name FROM pending_latest_versions`)
+	if err != nil {
+		return fmt.Errorf("failed to query servers pending a latest version: %w", err)
+	}
+	serverNames, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return fmt.Errorf("failed to scan server name: %w", err)
+	}
+
+	for _, serverName := range serverNames {
+		if err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			return recomputeLatestVersion(ctx, tx, serverName)
+		}); err != nil {
+			return fmt.Errorf("failed to recompute latest version of %s: %w", serverName, err)
+		}
+	}
+	return nil
+}
+
+// recomputeLatestVersion marks the version of a server that ranks highest for latest among those
+// that are not deleted, as publishing would, and dequeues the server
+func recomputeLatestVersion(ctx context.Context, tx pgx.Tx, serverName string) error {
+	rows, err := tx.Query(ctx, `SELECT version, status, published_at, value FROM servers WHERE server_name = $1`, serverName)
+	if err != nil {
+		return fmt.Errorf("failed to query server versions: %w", err)
+	}
+
+	var latest string
+	var latestPublishedAt time.Time
+	for rows.Next() {
+		var version, status string
+		var publishedAt time.Time
+		var valueJSON []byte
+		if err := rows.Scan(&version, &status, &publishedAt, &valueJSON); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan server row: %w", err)
+		}
+		if model.Status(status) == model.StatusDeleted {
+			continue
+		}
+
+		var serverJSON apiv0.ServerJSON
+		if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to unmarshal server JSON: %w", err)
+		}
+		if latest == "" || versioning.CompareForLatest(versioning.ForServer(&serverJSON), version, latest, publishedAt, latestPublishedAt) > 0 {
+			latest, latestPublishedAt = version, publishedAt
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	// Only one version per server can be latest, so the current one is unmarked first
+	if _, err := tx.Exec(ctx, `UPDATE servers SET is_latest = false WHERE server_name = $1 AND is_latest = true AND version <> $2`, serverName, latest); err != nil {
+		return fmt.Errorf("failed to unmark latest version: %w", err)
+	}
+	if latest != "" {
+		if _, err := tx.Exec(ctx, `UPDATE servers SET is_latest = true WHERE server_name = $1 AND version = $2 AND is_latest = false`, serverName, latest); err != nil {
+			return fmt.Errorf("failed to mark latest version: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM pending_latest_versions WHERE server_name = $1`, serverName); err != nil {
+		return fmt.Errorf("failed to dequeue server: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+//nolint:testpackage
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfillLatestVersions(t *testing.T) {
+	ctx := context.Background()
+	testURI := createTestDB(t)
+	db := connectTestDB(t, testURI, nil)
+
+	// Versions flagged as latest by the rule before prereleases were kept out of latest
+	publishedAt := time.Now().Add(-time.Hour)
+	create := func(name, version string, status model.Status, isLatest bool) {
+		t.Helper()
+		publishedAt = publishedAt.Add(time.Minute)
+		_, err := db.CreateServer(ctx, nil, &apiv0.ServerJSON{
+			Name:        name,
+			Description: "Latest backfill test server",
+			Version:     version,
+		}, &apiv0.RegistryExtensions{
+			Status:      status,
+			PublishedAt: publishedAt,
+			UpdatedAt:   publishedAt,
+			IsLatest:    isLatest,
+		})
+		require.NoError(t, err)
+	}
+	create("com.example/prerelease", "1.0.0", model.StatusActive, false)
+	create("com.example/prerelease", "2.0.0-beta.1", model.StatusActive, true)
+	create("com.example/taken-down", "1.0.0", model.StatusDeleted, true)
+	create("com.example/unchanged", "1.0.0", model.StatusActive, true)
+
+	conn, err := pgx.Connect(ctx, testURI)
+	require.NoError(t, err)
+	defer conn.Close(ctx)
+	_, err = conn.Exec(ctx, `INSERT INTO pending_latest_versions (server_name) SELECT DISTINCT server_name FROM servers`)
+	require.NoError(t, err)
+
+	require.NoError(t, backfillLatestVersions(ctx, conn))
+
+	latest, err := db.GetServerByName(ctx, nil, "com.example/prerelease")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", latest.Server.Version)
+
+	_, err = db.GetServerByName(ctx, nil, "com.example/taken-down")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	latest, err = db.GetServerByName(ctx, nil, "com.example/unchanged")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", latest.Server.Version)
+
+	var pending int
+	require.NoError(t, conn.QueryRow(ctx, `SELECT COUNT(*) FROM pending_latest_versions`).Scan(&pending))
+	assert.Zero(t, pending)
+}
+
+func TestBackfillVersionSortKeysRecomputesLatestVersions(t *testing.T) {
+	ctx := context.Background()
+	testURI := createTestDB(t)
+	db := connectTestDB(t, testURI, nil)
+
+	// Under semver neither version is valid, so the later publication was chosen as latest,
+	// while PEP 440 orders the post-release above its release
+	publishedAt := time.Now().Add(-time.Hour)
+	for _, version := range []string{"1.0.post1", "1.0"} {
+		publishedAt = publishedAt.Add(time.Minute)
+		_, err := db.CreateServer(ctx, nil, &apiv0.ServerJSON{
+			Name:          "com.example/python-server",
+			Description:   "Latest backfill test server",
+			Version:       version,
+			VersionScheme: "pep440",
+		}, &apiv0.RegistryExtensions{
+			Status:      model.StatusActive,
+			PublishedAt: publishedAt,
+			UpdatedAt:   publishedAt,
+			IsLatest:    version == "1.0",
+		})
+		require.NoError(t, err)
+	}
+
+	conn, err := pgx.Connect(ctx, testURI)
+	require.NoError(t, err)
+	defer conn.Close(ctx)
+	_, err = conn.Exec(ctx, `UPDATE servers SET version_sort_key = NULL`)
+	require.NoError(t, err)
+
+	require.NoError(t, backfillVersionSortKeys(ctx, conn))
+	require.NoError(t, backfillLatestVersions(ctx, conn))
+
+	latest, err := db.GetServerByName(ctx, nil, "com.example/python-server")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.post1", latest.Server.Version)
+}
@@ -0,0 +1,21 @@
+-- Migration: Add npm-style named dist-tags per server
+--
+-- A dist-tag (e.g. "next", "beta") points at one published version of a server.
+-- The "latest" tag is not stored here: it is derived from servers.is_latest so the
+-- registry keeps a single source of truth for the latest version.
+
+BEGIN;
+
+CREATE TABLE server_dist_tags (
+    server_name VARCHAR(255) NOT NULL,
+    tag VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (server_name, tag),
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version)
+);
+
+ALTER TABLE server_dist_tags ADD CONSTRAINT check_dist_tag_not_latest
+CHECK (tag <> 'latest');
+
+COMMIT;
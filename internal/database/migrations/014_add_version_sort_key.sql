@@ -0,0 +1,18 @@
+-- Migration: Add a byte-sortable version key for semantic version ordering
+--
+-- Versions were ordered lexicographically, so 1.10.0 sorted before 1.9.0.
+-- version_sort_key is computed by the registry when a version is published and
+-- encodes semver precedence (falling back to publication time for non-semver
+-- versions) so that ORDER BY and cursor comparisons work in SQL.
+--
+-- Existing rows are backfilled by the registry at startup, as the encoding is
+-- implemented in Go alongside the version comparison logic.
+
+BEGIN;
+
+ALTER TABLE servers ADD COLUMN version_sort_key VARCHAR(255) COLLATE "C";
+
+CREATE INDEX idx_servers_name_version_sort ON servers (server_name, version_sort_key, version);
+CREATE INDEX idx_servers_name_published_at ON servers (server_name, published_at, version);
+
+COMMIT;
@@ -0,0 +1,16 @@
+-- Migration: Recompute version sort keys for per-server version schemes
+--
+-- Version ordering now follows the server's version scheme (semver, PEP 440,
+-- calver or NuGet) instead of always using semver. Keys are reset here and
+-- recomputed by the registry at startup, as the encoding is implemented in Go.
+--
+-- Keys for long versions in some schemes can exceed 255 characters, so the
+-- column is widened to TEXT.
+
+BEGIN;
+
+ALTER TABLE servers ALTER COLUMN version_sort_key TYPE TEXT COLLATE "C";
+
+UPDATE servers SET version_sort_key = NULL;
+
+COMMIT;
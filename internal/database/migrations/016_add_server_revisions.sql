@@ -0,0 +1,30 @@
+-- Migration: Keep the edit history of each server version
+--
+-- Edits overwrite servers.value in place. Every stored server.json is now also
+-- appended to server_revisions, so earlier revisions stay retrievable and can be
+-- restored. Revision 1 is the original publication (edited_by is NULL).
+--
+-- Existing versions are backfilled with their current server.json as revision 1,
+-- as content overwritten by earlier edits was not kept.
+
+BEGIN;
+
+CREATE TABLE server_revisions (
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    revision INTEGER NOT NULL,
+    value JSONB NOT NULL,
+    edited_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (server_name, version, revision),
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version) ON DELETE CASCADE
+);
+
+ALTER TABLE server_revisions ADD CONSTRAINT check_revision_positive
+CHECK (revision > 0);
+
+INSERT INTO server_revisions (server_name, version, revision, value, edited_by, created_at)
+SELECT server_name, version, 1, value, NULL, published_at
+FROM servers;
+
+COMMIT;
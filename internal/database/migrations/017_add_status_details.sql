@@ -0,0 +1,16 @@
+-- Migration: Record why a server version has its status
+--
+-- Publishers can deprecate their own versions with an optional message and a
+-- pointer to the server (and optionally version) that replaces them. Both are
+-- cleared whenever the status changes again.
+
+BEGIN;
+
+ALTER TABLE servers ADD COLUMN status_message TEXT;
+ALTER TABLE servers ADD COLUMN replaced_by_server_name VARCHAR(255);
+ALTER TABLE servers ADD COLUMN replaced_by_version VARCHAR(255);
+
+ALTER TABLE servers ADD CONSTRAINT check_replaced_by_version_has_server
+CHECK (replaced_by_version IS NULL OR replaced_by_server_name IS NOT NULL);
+
+COMMIT;
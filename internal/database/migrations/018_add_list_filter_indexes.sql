@@ -0,0 +1,21 @@
+-- Migration: Support filtering server listings by namespace, package, transport and repository
+--
+-- namespace and has_remotes are generated from the server name and server.json so
+-- they can be indexed like ordinary columns. Package registry type, package identifier
+-- and transport type filters use JSONB containment (@>) on packages and remotes, which
+-- the GIN indexes from migration 009 already cover.
+
+BEGIN;
+
+ALTER TABLE servers ADD COLUMN namespace VARCHAR(255)
+GENERATED ALWAYS AS (split_part(server_name, '/', 1)) STORED;
+
+ALTER TABLE servers ADD COLUMN has_remotes BOOLEAN
+GENERATED ALWAYS AS (COALESCE(jsonb_typeof(value->'remotes') = 'array' AND value->'remotes' <> '[]'::jsonb, false)) STORED;
+
+-- text_pattern_ops supports the prefix match used for sub-namespaces (LIKE 'com.example.%')
+CREATE INDEX idx_servers_namespace ON servers (namespace text_pattern_ops);
+CREATE INDEX idx_servers_has_remotes ON servers (server_name) WHERE has_remotes;
+CREATE INDEX idx_servers_repository_url ON servers ((value->'repository'->>'url'));
+
+COMMIT;
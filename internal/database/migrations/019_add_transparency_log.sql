@@ -0,0 +1,52 @@
+-- Migration: Append-only transparency log of publishes and edits
+--
+-- Every server.json stored by a publish or an edit is appended to a Merkle tree
+-- (RFC 9162), so that clients can check with inclusion proofs that what they were
+-- served is logged, and monitors can check with consistency proofs that logged
+-- entries are never rewritten.
+--
+-- transparency_log_entries holds the canonical JSON leaf data of each entry.
+-- transparency_log_nodes holds the hash of every complete subtree, keyed by its
+-- level (log2 of its size) and its index at that level; level 0 are the leaf
+-- hashes. Proofs for any tree size only need such subtrees, which never change.
+--
+-- Versions published before this migration are not in the log.
+
+BEGIN;
+
+CREATE TABLE transparency_log_entries (
+    log_index BIGINT PRIMARY KEY,
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    revision INTEGER NOT NULL,
+    leaf_data BYTEA NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_log_index_non_negative CHECK (log_index >= 0)
+);
+
+CREATE INDEX idx_transparency_log_entries_server ON transparency_log_entries (server_name, version, revision);
+
+CREATE TABLE transparency_log_nodes (
+    level SMALLINT NOT NULL,
+    node_index BIGINT NOT NULL,
+    hash BYTEA NOT NULL,
+    PRIMARY KEY (level, node_index)
+);
+
+-- Reject changes to logged data; the log can only be appended to
+CREATE OR REPLACE FUNCTION reject_transparency_log_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'the transparency log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER transparency_log_entries_append_only
+    BEFORE UPDATE OR DELETE ON transparency_log_entries
+    FOR EACH ROW EXECUTE FUNCTION reject_transparency_log_changes();
+
+CREATE TRIGGER transparency_log_nodes_append_only
+    BEFORE UPDATE OR DELETE ON transparency_log_nodes
+    FOR EACH ROW EXECUTE FUNCTION reject_transparency_log_changes();
+
+COMMIT;
@@ -0,0 +1,17 @@
+-- Migration: Add a canonical content digest to each server version
+--
+-- content_digest is the hex-encoded SHA-256 of the canonical JSON (RFC 8785)
+-- encoding of the stored server.json. It identifies exactly this content of a
+-- server version, so clients can look versions up by it and edits can be made
+-- conditional on the content they were based on.
+--
+-- Existing rows are backfilled by the registry at startup, as the canonical
+-- encoding is implemented in Go.
+
+BEGIN;
+
+ALTER TABLE servers ADD COLUMN content_digest VARCHAR(64);
+
+CREATE INDEX idx_servers_content_digest ON servers (content_digest);
+
+COMMIT;
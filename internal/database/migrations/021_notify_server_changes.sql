@@ -0,0 +1,30 @@
+-- Migration: Notify listeners when servers or dist-tags change
+--
+-- Registry replicas cache reads in memory. Every change to a server version or a
+-- dist-tag sends a NOTIFY on the server_changes channel with the server name as
+-- payload, delivered when the transaction commits, so every replica can drop its
+-- cached entries for that server whichever replica made the change.
+
+BEGIN;
+
+CREATE OR REPLACE FUNCTION notify_server_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM pg_notify('server_changes', OLD.server_name);
+    ELSE
+        PERFORM pg_notify('server_changes', NEW.server_name);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER servers_notify_change
+    AFTER INSERT OR UPDATE OR DELETE ON servers
+    FOR EACH ROW EXECUTE FUNCTION notify_server_change();
+
+CREATE TRIGGER server_dist_tags_notify_change
+    AFTER INSERT OR UPDATE OR DELETE ON server_dist_tags
+    FOR EACH ROW EXECUTE FUNCTION notify_server_change();
+
+COMMIT;
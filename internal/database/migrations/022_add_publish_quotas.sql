@@ -0,0 +1,25 @@
+-- Migration: Add publish quotas
+--
+-- Publishes are limited per namespace, per server and per publisher. Each server
+-- version records the identity that published it, so that the publishes of a
+-- publisher can be counted, and admins can override the configured quotas for
+-- specific namespaces, servers and publishers.
+
+BEGIN;
+
+-- Identity of the publisher (auth method and subject), NULL for imported versions
+ALTER TABLE servers ADD COLUMN published_by TEXT;
+
+CREATE INDEX idx_servers_published_by ON servers (published_by, published_at)
+    WHERE published_by IS NOT NULL;
+
+CREATE TABLE publish_quota_overrides (
+    quota VARCHAR(50) NOT NULL CHECK (quota IN ('servers_per_namespace', 'versions_per_day', 'publishes_per_hour')),
+    subject VARCHAR(255) NOT NULL,
+    quota_limit INTEGER NOT NULL CHECK (quota_limit >= 0),
+    reason TEXT,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (quota, subject)
+);
+
+COMMIT;
@@ -0,0 +1,16 @@
+-- Migration: Add blocked namespaces
+--
+-- Namespaces blocked from publishing used to be compiled into the registry.
+-- Storing them lets admins block and unblock namespaces without a redeploy.
+
+BEGIN;
+
+CREATE TABLE blocked_namespaces (
+    namespace VARCHAR(255) PRIMARY KEY,
+    reason TEXT,
+    -- Identity of the admin that blocked the namespace (auth method and subject)
+    blocked_by TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
@@ -0,0 +1,25 @@
+-- Migration: Add API tokens
+--
+-- Long-lived API tokens let CI systems publish without an interactive login. A
+-- logged-in user creates a token with a subset of their permissions, and the
+-- token is exchanged for short-lived registry JWTs. Only a SHA-256 hash of each
+-- token is stored.
+
+BEGIN;
+
+CREATE TABLE api_tokens (
+    id VARCHAR(32) PRIMARY KEY,
+    token_hash BYTEA NOT NULL UNIQUE,
+    -- Identity of the user that created the token (auth method and subject)
+    owner TEXT NOT NULL,
+    description TEXT NOT NULL,
+    -- Permissions granted by the token, as [{"action": ..., "resource": ...}]
+    permissions JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    last_used_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_api_tokens_owner ON api_tokens (owner);
+
+COMMIT;
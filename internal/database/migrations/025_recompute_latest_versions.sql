@@ -0,0 +1,16 @@
+-- Migration: Recompute the latest version of existing servers
+--
+-- Prereleases no longer win the latest version, but servers published before
+-- kept the latest flag chosen by the old rule. The rule is implemented in Go,
+-- so servers are queued here and recomputed by the registry at startup.
+
+BEGIN;
+
+CREATE TABLE pending_latest_versions (
+    server_name VARCHAR(255) PRIMARY KEY
+);
+
+INSERT INTO pending_latest_versions (server_name)
+SELECT DISTINCT server_name FROM servers;
+
+COMMIT;
@@ -0,0 +1,14 @@
+-- Migration: Remove dist-tags pointing at deleted versions
+--
+-- Deleting a version now removes its dist-tags, so that they no longer resolve
+-- to taken-down versions. This removes the tags of versions deleted before.
+
+BEGIN;
+
+DELETE FROM server_dist_tags
+USING servers
+WHERE servers.server_name = server_dist_tags.server_name
+  AND servers.version = server_dist_tags.version
+  AND servers.status = 'deleted';
+
+COMMIT;
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -15,11 +16,22 @@ import (
 
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/transparency"
 )
 
 // PostgreSQL is an implementation of the Database interface using PostgreSQL
 type PostgreSQL struct {
+	// pool connects to the primary, which serves all writes and transactions
 	pool *pgxpool.Pool
+
+	// replicas serve reads made outside a transaction while they are healthy
+	replicas         []*replica
+	nextReplica      atomic.Uint64
+	stopHealthChecks context.CancelFunc
+	healthChecksDone chan struct{}
+
+	// lockTimeout bounds how long AcquirePublishLock waits; zero waits indefinitely
+	lockTimeout time.Duration
 }
 
 // Executor is an interface for executing queries (satisfied by both pgx.Tx and pgxpool.Pool)
@@ -37,20 +49,69 @@ func (db *PostgreSQL) getExecutor(tx pgx.Tx) Executor {
 	return db.pool
 }
 
-// NewPostgreSQL creates a new instance of the PostgreSQL database
-func NewPostgreSQL(ctx context.Context, connectionURI string) (*PostgreSQL, error) {
-	// Parse connection config for pool settings
-	config, err := pgxpool.ParseConfig(connectionURI)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse PostgreSQL config: %w", err)
-	}
+// Options configures the connection pools and query timeouts of a PostgreSQL database
+type Options struct {
+	// ReplicaURIs are the connection URIs of read replicas, which serve reads made outside a
+	// transaction while they are healthy
+	ReplicaURIs []string
+
+	// Pool sizes and connection lifetimes; zero values keep the defaults
+	MaxConns        int32
+	MinConns        int32
+	MaxConnLifetime time.Duration
+	MaxConnIdleTime time.Duration
+
+	// StatementTimeout aborts any query running longer; zero disables it
+	StatementTimeout time.Duration
+	// LockTimeout bounds how long AcquirePublishLock waits for the lock; zero waits indefinitely
+	LockTimeout time.Duration
+}
 
-	// Configure pool for stability-focused defaults
+// configurePool applies the pool options to a pool config, with stability-focused defaults
+func configurePool(config *pgxpool.Config, options *Options) {
 	config.MaxConns = 30                      // Handle good concurrent load
 	config.MinConns = 5                       // Keep connections warm for fast response
 	config.MaxConnIdleTime = 30 * time.Minute // Keep connections available for bursts
 	config.MaxConnLifetime = 2 * time.Hour    // Refresh connections regularly for stability
 
+	if options.MaxConns > 0 {
+		config.MaxConns = options.MaxConns
+	}
+	if options.MinConns > 0 {
+		config.MinConns = options.MinConns
+	}
+	if options.MaxConnIdleTime > 0 {
+		config.MaxConnIdleTime = options.MaxConnIdleTime
+	}
+	if options.MaxConnLifetime > 0 {
+		config.MaxConnLifetime = options.MaxConnLifetime
+	}
+	if options.StatementTimeout > 0 {
+		// Applies to every statement of every connection, so a pathological query cannot hold
+		// a connection indefinitely
+		config.ConnConfig.RuntimeParams["statement_timeout"] = postgresInterval(options.StatementTimeout)
+	}
+}
+
+// postgresInterval formats a duration as a PostgreSQL timeout setting in milliseconds
+func postgresInterval(d time.Duration) string {
+	return fmt.Sprintf("%dms", d.Milliseconds())
+}
+
+// NewPostgreSQL creates a new instance of the PostgreSQL database. options may be nil to use the
+// defaults without read replicas.
+func NewPostgreSQL(ctx context.Context, connectionURI string, options *Options) (*PostgreSQL, error) {
+	if options == nil {
+		options = &Options{}
+	}
+
+	// Parse connection config for pool settings
+	config, err := pgxpool.ParseConfig(connectionURI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse PostgreSQL config: %w", err)
+	}
+	configurePool(config, options)
+
 	// Create connection pool with configured settings
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
@@ -69,87 +130,194 @@ func NewPostgreSQL(ctx context.Context, connectionURI string) (*PostgreSQL, erro
 	}
 	defer conn.Release()
 
+	// Migrations and backfills may rewrite whole tables, so they are not bound by the statement
+	// timeout meant for requests
+	if _, err := conn.Exec(ctx, "SET statement_timeout = 0"); err != nil {
+		return nil, fmt.Errorf("failed to disable statement timeout for migrations: %w", err)
+	}
+	defer func() {
+		//nolint:contextcheck // The connection goes back to the pool even if ctx is done
+		_, _ = conn.Exec(context.Background(), "RESET statement_timeout")
+	}()
+
 	migrator := NewMigrator(conn.Conn())
 	if err := migrator.Migrate(ctx); err != nil {
 		return nil, fmt.Errorf("failed to run database migrations: %w", err)
 	}
 
-	return &PostgreSQL{
-		pool: pool,
-	}, nil
+	if err := backfillVersionSortKeys(ctx, conn.Conn()); err != nil {
+		return nil, fmt.Errorf("failed to backfill version sort keys: %w", err)
+	}
+
+	if err := backfillContentDigests(ctx, conn.Conn()); err != nil {
+		return nil, fmt.Errorf("failed to backfill content digests: %w", err)
+	}
+
+	if err := backfillLatestVersions(ctx, conn.Conn()); err != nil {
+		return nil, fmt.Errorf("failed to backfill latest versions: %w", err)
+	}
+
+	replicas, err := connectReplicas(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	db := &PostgreSQL{
+		pool:        pool,
+		replicas:    replicas,
+		lockTimeout: options.LockTimeout,
+	}
+	if len(replicas) > 0 {
+		healthCtx, stop := context.WithCancel(context.Background())
+		db.stopHealthChecks = stop
+		db.healthChecksDone = make(chan struct{})
+		go watchReplicas(healthCtx, replicas, db.healthChecksDone) //nolint:contextcheck // Health checks outlive the connection context
+	}
+	return db, nil
+}
+
+// serverFilterConditions builds the WHERE conditions for a server filter, using dedicated
+// columns and indexed JSONB expressions. Placeholders are numbered from $1.
+func serverFilterConditions(filter *ServerFilter) ([]string, []any) {
+	var whereConditions []string
+	args := []any{}
+	argIndex := 1
+
+	if filter == nil {
+		return whereConditions, args
+	}
+
+	if filter.Name != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("server_name = $%d", argIndex))
+		args = append(args, *filter.Name)
+		argIndex++
+	}
+	if filter.RemoteURL != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("EXISTS (SELECT 1 FROM jsonb_array_elements(value->'remotes') AS remote WHERE remote->>'url' = $%d)", argIndex))
+		args = append(args, *filter.RemoteURL)
+		argIndex++
+	}
+	if filter.UpdatedSince != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("updated_at > $%d", argIndex))
+		args = append(args, *filter.UpdatedSince)
+		argIndex++
+	}
+	if filter.SubstringName != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("server_name ILIKE $%d", argIndex))
+		args = append(args, "%"+*filter.SubstringName+"%")
+		argIndex++
+	}
+	if filter.Version != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("version = $%d", argIndex))
+		args = append(args, *filter.Version)
+		argIndex++
+	}
+	if filter.IsLatest != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("is_latest = $%d", argIndex))
+		args = append(args, *filter.IsLatest)
+		argIndex++
+	}
+	if len(filter.Status) > 0 {
+		statuses := make([]string, len(filter.Status))
+		for i, status := range filter.Status {
+			statuses[i] = string(status)
+		}
+		whereConditions = append(whereConditions, fmt.Sprintf("status = ANY($%d)", argIndex))
+		args = append(args, statuses)
+		argIndex++
+	}
+	if filter.Namespace != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("(namespace = $%d OR namespace LIKE $%d)", argIndex, argIndex+1))
+		args = append(args, *filter.Namespace, escapeLikePattern(*filter.Namespace)+".%")
+		argIndex += 2
+	}
+	// Package and transport filters use JSONB containment so the GIN indexes on packages and remotes apply
+	if filter.RegistryType != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("value->'packages' @> $%d::jsonb", argIndex))
+		args = append(args, jsonArrayOf(map[string]any{"registryType": *filter.RegistryType}))
+		argIndex++
+	}
+	if filter.PackageID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("value->'packages' @> $%d::jsonb", argIndex))
+		args = append(args, jsonArrayOf(map[string]any{"identifier": *filter.PackageID}))
+		argIndex++
+	}
+	if filter.TransportType != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("(value->'remotes' @> $%d::jsonb OR value->'packages' @> $%d::jsonb)", argIndex, argIndex+1))
+		args = append(args,
+			jsonArrayOf(map[string]any{"type": *filter.TransportType}),
+			jsonArrayOf(map[string]any{"transport": map[string]any{"type": *filter.TransportType}}),
+		)
+		argIndex += 2
+	}
+	if filter.RepositoryURL != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("value->'repository'->>'url' = $%d", argIndex))
+		args = append(args, *filter.RepositoryURL)
+		argIndex++
+	}
+	if filter.HasRemotes != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("has_remotes = $%d", argIndex))
+		args = append(args, *filter.HasRemotes)
+	}
+
+	return whereConditions, args
 }
 
 func (db *PostgreSQL) ListServers(
 	ctx context.Context,
 	tx pgx.Tx,
 	filter *ServerFilter,
-	cursor string,
+	options *ServerListOptions,
+	after *ServerListPosition,
 	limit int,
-) ([]*apiv0.ServerResponse, string, error) {
+) ([]*apiv0.ServerResponse, *ServerListPosition, error) {
 	if limit <= 0 {
 		limit = 10
 	}
 
 	if ctx.Err() != nil {
-		return nil, "", ctx.Err()
+		return nil, nil, ctx.Err()
 	}
 
-	// Build WHERE clause for filtering using dedicated columns
-	var whereConditions []string
-	args := []any{}
-	argIndex := 1
+	if options == nil {
+		options = &ServerListOptions{}
+	}
 
-	// Add filters using dedicated columns for better performance
-	if filter != nil {
-		if filter.Name != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("server_name = $%d", argIndex))
-			args = append(args, *filter.Name)
-			argIndex++
-		}
-		if filter.RemoteURL != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("EXISTS (SELECT 1 FROM jsonb_array_elements(value->'remotes') AS remote WHERE remote->>'url' = $%d)", argIndex))
-			args = append(args, *filter.RemoteURL)
-			argIndex++
-		}
-		if filter.UpdatedSince != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("updated_at > $%d", argIndex))
-			args = append(args, *filter.UpdatedSince)
-			argIndex++
-		}
-		if filter.SubstringName != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("server_name ILIKE $%d", argIndex))
-			args = append(args, "%"+*filter.SubstringName+"%")
-			argIndex++
-		}
-		if filter.Version != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("version = $%d", argIndex))
-			args = append(args, *filter.Version)
-			argIndex++
-		}
-		if filter.IsLatest != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("is_latest = $%d", argIndex))
-			args = append(args, *filter.IsLatest)
-			argIndex++
-		}
+	// Every ordering ends in the unique (server_name, version) pair so positions are unambiguous
+	orderColumns := []string{"server_name", "version_sort_key", "version"}
+	timeColumn := ""
+	switch options.OrderBy {
+	case ServerOrderByPublishedAt:
+		timeColumn = "published_at"
+	case ServerOrderByUpdatedAt:
+		timeColumn = "updated_at"
+	}
+	if timeColumn != "" {
+		orderColumns = []string{timeColumn, "server_name", "version"}
+	}
+	direction, comparison := "ASC", ">"
+	if options.Descending {
+		direction, comparison = "DESC", "<"
 	}
 
-	// Add cursor pagination using compound serverName:version cursor
-	if cursor != "" {
-		// Parse cursor format: "serverName:version"
-		parts := strings.SplitN(cursor, ":", 2)
-		if len(parts) == 2 {
-			cursorServerName := parts[0]
-			cursorVersion := parts[1]
-
-			// Use compound condition: (server_name > cursor_name) OR (server_name = cursor_name AND version > cursor_version)
-			whereConditions = append(whereConditions, fmt.Sprintf("(server_name > $%d OR (server_name = $%d AND version > $%d))", argIndex, argIndex+1, argIndex+2))
-			args = append(args, cursorServerName, cursorServerName, cursorVersion)
+	// Build WHERE clause for filtering using dedicated columns
+	whereConditions, args := serverFilterConditions(filter)
+	argIndex := len(args) + 1
+
+	// Continue after the position of the last row of the previous page
+	if after != nil {
+		if timeColumn != "" {
+			whereConditions = append(whereConditions, fmt.Sprintf(
+				"(%s) %s ($%d, $%d, $%d)", strings.Join(orderColumns, ", "), comparison, argIndex, argIndex+1, argIndex+2))
+			args = append(args, after.Timestamp, after.ServerName, after.Version)
 			argIndex += 3
 		} else {
-			// Fallback for malformed cursor - treat as server name only for backwards compatibility
-			whereConditions = append(whereConditions, fmt.Sprintf("server_name > $%d", argIndex))
-			args = append(args, cursor)
-			argIndex++
+			// Versions within a server are ordered by their sort key, so continue after the position's key
+			whereConditions = append(whereConditions, fmt.Sprintf(
+				"(server_name %[1]s $%[2]d OR (server_name = $%[2]d AND (version_sort_key, version) %[1]s ((SELECT version_sort_key FROM servers WHERE server_name = $%[2]d AND version = $%[3]d), $%[3]d)))",
+				comparison, argIndex, argIndex+1))
+			args = append(args, after.ServerName, after.Version)
+			argIndex += 2
 		}
 	}
 
@@ -159,68 +327,266 @@ func (db *PostgreSQL) ListServers(
 		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
 	}
 
+	orderTerms := make([]string, len(orderColumns))
+	for i, column := range orderColumns {
+		orderTerms[i] = column + " " + direction
+	}
+
 	// Query servers table with hybrid column/JSON data
 	query := fmt.Sprintf(`
-        SELECT server_name, version, status, published_at, updated_at, is_latest, value
+        SELECT `+serverColumns+`
         FROM servers
         %s
-        ORDER BY server_name, version
+        ORDER BY %s
         LIMIT $%d
-    `, whereClause, argIndex)
+    `, whereClause, strings.Join(orderTerms, ", "), argIndex)
 	args = append(args, limit)
 
-	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
+	rows, err := db.getReadExecutor(ctx, tx).Query(ctx, query, args...)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to query servers: %w", err)
+		return nil, nil, fmt.Errorf("failed to query servers: %w", err)
 	}
 	defer rows.Close()
 
 	var results []*apiv0.ServerResponse
 	for rows.Next() {
-		var serverName, version, status string
-		var publishedAt, updatedAt time.Time
-		var isLatest bool
-		var valueJSON []byte
-
-		err := rows.Scan(&serverName, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+		serverResponse, err := scanServerRow(rows)
 		if err != nil {
-			return nil, "", fmt.Errorf("failed to scan server row: %w", err)
+			return nil, nil, fmt.Errorf("failed to scan server row: %w", err)
 		}
 
-		// Parse the ServerJSON from JSONB
-		var serverJSON apiv0.ServerJSON
-		if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
-			return nil, "", fmt.Errorf("failed to unmarshal server JSON: %w", err)
+		results = append(results, serverResponse)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	// The next page continues after the last row when the page is full
+	if len(results) == 0 || len(results) < limit {
+		return results, nil, nil
+	}
+	last := results[len(results)-1]
+	next := &ServerListPosition{
+		ServerName: last.Server.Name,
+		Version:    last.Server.Version,
+	}
+	switch options.OrderBy {
+	case ServerOrderByPublishedAt:
+		next.Timestamp = last.Meta.Official.PublishedAt
+	case ServerOrderByUpdatedAt:
+		next.Timestamp = last.Meta.Official.UpdatedAt
+	}
+
+	return results, next, nil
+}
+
+// CountServers counts the server versions matching a filter, i.e. the rows ListServers pages through
+func (db *PostgreSQL) CountServers(ctx context.Context, tx pgx.Tx, filter *ServerFilter) (int, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	whereConditions, args := serverFilterConditions(filter)
+	whereClause := ""
+	if len(whereConditions) > 0 {
+		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
+	}
+
+	var count int
+	err := db.getReadExecutor(ctx, tx).QueryRow(ctx, "SELECT COUNT(*) FROM servers "+whereClause, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count servers: %w", err)
+	}
+
+	return count, nil
+}
+
+// exportFetchSize is the number of rows ExportServers fetches from its cursor at a time
+const exportFetchSize = 500
+
+// ExportServers streams every server version matching a filter to fn in listing order, through a
+// server-side cursor so the result set is never held in memory. Without a transaction it reads
+// from a read-only repeatable read snapshot, so a long export sees one consistent registry state.
+func (db *PostgreSQL) ExportServers(ctx context.Context, tx pgx.Tx, filter *ServerFilter, fn func(*apiv0.ServerResponse) error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if tx == nil {
+		snapshot, err := db.readPool().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+		if err != nil {
+			return fmt.Errorf("failed to begin export transaction: %w", err)
 		}
+		//nolint:contextcheck // Intentionally using separate context for rollback to ensure cleanup even if request is cancelled
+		defer func() {
+			rollbackCtx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+			if rbErr := snapshot.Rollback(rollbackCtx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+				log.Printf("failed to rollback export transaction: %v", rbErr)
+			}
+		}()
+		tx = snapshot
+	}
+
+	whereConditions, args := serverFilterConditions(filter)
+	whereClause := ""
+	if len(whereConditions) > 0 {
+		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		DECLARE server_export NO SCROLL CURSOR FOR
+		SELECT `+serverColumns+`
+		FROM servers
+		%s
+		ORDER BY server_name, version_sort_key, version
+	`, whereClause)
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to declare export cursor: %w", err)
+	}
 
-		// Build ServerResponse with separated metadata
-		serverResponse := &apiv0.ServerResponse{
-			Server: serverJSON,
-			Meta: apiv0.ResponseMeta{
-				Official: &apiv0.RegistryExtensions{
-					Status:      model.Status(status),
-					PublishedAt: publishedAt,
-					UpdatedAt:   updatedAt,
-					IsLatest:    isLatest,
-				},
-			},
+	for {
+		fetched, err := fetchServerExportBatch(ctx, tx, fn)
+		if err != nil {
+			return err
+		}
+		if fetched < exportFetchSize {
+			break
 		}
+	}
 
-		results = append(results, serverResponse)
+	if _, err := tx.Exec(ctx, "CLOSE server_export"); err != nil {
+		return fmt.Errorf("failed to close export cursor: %w", err)
+	}
+
+	return nil
+}
+
+// fetchServerExportBatch passes the next batch of rows of the export cursor to fn and returns how many were fetched
+func fetchServerExportBatch(ctx context.Context, tx pgx.Tx, fn func(*apiv0.ServerResponse) error) (int, error) {
+	rows, err := tx.Query(ctx, fmt.Sprintf("FETCH %d FROM server_export", exportFetchSize))
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch from export cursor: %w", err)
+	}
+	defer rows.Close()
+
+	fetched := 0
+	for rows.Next() {
+		fetched++
+		serverResponse, err := scanServerRow(rows)
+		if err != nil {
+			return 0, fmt.Errorf("failed to scan server row: %w", err)
+		}
+		if err := fn(serverResponse); err != nil {
+			return 0, err
+		}
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, "", fmt.Errorf("error iterating rows: %w", err)
+		return 0, fmt.Errorf("error iterating rows: %w", err)
 	}
 
-	// Determine next cursor using compound serverName:version format
-	nextCursor := ""
-	if len(results) > 0 && len(results) >= limit {
-		lastResult := results[len(results)-1]
-		nextCursor = lastResult.Server.Name + ":" + lastResult.Server.Version
+	return fetched, nil
+}
+
+// escapeLikePattern escapes the LIKE wildcards in s so it matches literally
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// jsonArrayOf encodes a single-element JSON array, for containment (@>) matches against JSONB arrays
+func jsonArrayOf(element map[string]any) string {
+	// Marshalling a map of strings cannot fail
+	data, _ := json.Marshal([]map[string]any{element})
+	return string(data)
+}
+
+// namespaceFacetLimit caps the namespace facet, which has one bucket per publisher
+const namespaceFacetLimit = 100
+
+// GetServerFacets counts the servers matching a filter per status, namespace, package registry
+// type and transport type. A server is counted once per bucket that any matching version falls in.
+func (db *PostgreSQL) GetServerFacets(ctx context.Context, tx pgx.Tx, filter *ServerFilter) (*apiv0.ServerFacetsResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
 	}
 
-	return results, nextCursor, nil
+	whereConditions, args := serverFilterConditions(filter)
+	whereClause := ""
+	if len(whereConditions) > 0 {
+		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
+	}
+
+	// Compute every facet in one pass over the matching versions
+	query := fmt.Sprintf(`
+		WITH matched AS (
+			SELECT server_name, status, namespace,
+				CASE WHEN jsonb_typeof(value->'packages') = 'array' THEN value->'packages' ELSE '[]'::jsonb END AS packages,
+				CASE WHEN jsonb_typeof(value->'remotes') = 'array' THEN value->'remotes' ELSE '[]'::jsonb END AS remotes
+			FROM servers
+			%s
+		)
+		SELECT 'total', '', COUNT(DISTINCT server_name) FROM matched
+		UNION ALL
+		SELECT 'status', status, COUNT(DISTINCT server_name) FROM matched GROUP BY status
+		UNION ALL
+		(SELECT 'namespace', namespace, COUNT(DISTINCT server_name) FROM matched GROUP BY namespace
+			ORDER BY 3 DESC, 2 LIMIT %d)
+		UNION ALL
+		SELECT 'registryType', package->>'registryType', COUNT(DISTINCT server_name)
+		FROM matched, jsonb_array_elements(packages) AS package
+		WHERE package->>'registryType' IS NOT NULL
+		GROUP BY 2
+		UNION ALL
+		SELECT 'transportType', transport_type, COUNT(DISTINCT server_name)
+		FROM (
+			SELECT server_name, package->'transport'->>'type' AS transport_type FROM matched, jsonb_array_elements(packages) AS package
+			UNION ALL
+			SELECT server_name, remote->>'type' FROM matched, jsonb_array_elements(remotes) AS remote
+		) AS transports
+		WHERE transport_type IS NOT NULL
+		GROUP BY 2
+	`, whereClause, namespaceFacetLimit)
+
+	rows, err := db.getReadExecutor(ctx, tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server facets: %w", err)
+	}
+	defer rows.Close()
+
+	facets := &apiv0.ServerFacetsResponse{
+		Status:        map[string]int{},
+		Namespace:     map[string]int{},
+		RegistryType:  map[string]int{},
+		TransportType: map[string]int{},
+	}
+	for rows.Next() {
+		var facet, value string
+		var count int
+		if err := rows.Scan(&facet, &value, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan server facet row: %w", err)
+		}
+
+		switch facet {
+		case "total":
+			facets.Total = count
+		case "status":
+			facets.Status[value] = count
+		case "namespace":
+			facets.Namespace[value] = count
+		case "registryType":
+			facets.RegistryType[value] = count
+		case "transportType":
+			facets.TransportType[value] = count
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return facets, nil
 }
 
 // GetServerByName retrieves the latest version of a server by server name
@@ -230,19 +596,14 @@ func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName
 	}
 
 	query := `
-		SELECT server_name, version, status, published_at, updated_at, is_latest, value
+		SELECT ` + serverColumns + `
 		FROM servers
 		WHERE server_name = $1 AND is_latest = true
 		ORDER BY published_at DESC
 		LIMIT 1
 	`
 
-	var name, version, status string
-	var publishedAt, updatedAt time.Time
-	var isLatest bool
-	var valueJSON []byte
-
-	err := db.getExecutor(tx).QueryRow(ctx, query, serverName).Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+	serverResponse, err := scanServerRow(db.getReadExecutor(ctx, tx).QueryRow(ctx, query, serverName))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -250,25 +611,6 @@ func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName
 		return nil, fmt.Errorf("failed to get server by name: %w", err)
 	}
 
-	// Parse the ServerJSON from JSONB
-	var serverJSON apiv0.ServerJSON
-	if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
-	}
-
-	// Build ServerResponse with separated metadata
-	serverResponse := &apiv0.ServerResponse{
-		Server: serverJSON,
-		Meta: apiv0.ResponseMeta{
-			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(status),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
-			},
-		},
-	}
-
 	return serverResponse, nil
 }
 
@@ -279,18 +621,13 @@ func (db *PostgreSQL) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx,
 	}
 
 	query := `
-		SELECT server_name, version, status, published_at, updated_at, is_latest, value
+		SELECT ` + serverColumns + `
 		FROM servers
 		WHERE server_name = $1 AND version = $2
 		LIMIT 1
 	`
 
-	var name, vers, status string
-	var publishedAt, updatedAt time.Time
-	var isLatest bool
-	var valueJSON []byte
-
-	err := db.getExecutor(tx).QueryRow(ctx, query, serverName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+	serverResponse, err := scanServerRow(db.getReadExecutor(ctx, tx).QueryRow(ctx, query, serverName, version))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -298,23 +635,28 @@ func (db *PostgreSQL) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx,
 		return nil, fmt.Errorf("failed to get server by name and version: %w", err)
 	}
 
-	// Parse the ServerJSON from JSONB
-	var serverJSON apiv0.ServerJSON
-	if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	return serverResponse, nil
+}
+
+// GetServerByContentDigest retrieves the server version whose current server.json has the given content digest
+func (db *PostgreSQL) GetServerByContentDigest(ctx context.Context, tx pgx.Tx, digest string) (*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
 	}
 
-	// Build ServerResponse with separated metadata
-	serverResponse := &apiv0.ServerResponse{
-		Server: serverJSON,
-		Meta: apiv0.ResponseMeta{
-			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(status),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
-			},
-		},
+	query := `
+		SELECT ` + serverColumns + `
+		FROM servers
+		WHERE content_digest = $1
+		LIMIT 1
+	`
+
+	serverResponse, err := scanServerRow(db.getReadExecutor(ctx, tx).QueryRow(ctx, query, digest))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get server by content digest: %w", err)
 	}
 
 	return serverResponse, nil
@@ -327,13 +669,13 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 	}
 
 	query := `
-		SELECT server_name, version, status, published_at, updated_at, is_latest, value
+		SELECT ` + serverColumns + `
 		FROM servers
 		WHERE server_name = $1
-		ORDER BY published_at DESC
+		ORDER BY version_sort_key DESC, version DESC
 	`
 
-	rows, err := db.getExecutor(tx).Query(ctx, query, serverName)
+	rows, err := db.getReadExecutor(ctx, tx).Query(ctx, query, serverName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query server versions: %w", err)
 	}
@@ -341,35 +683,11 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 
 	var results []*apiv0.ServerResponse
 	for rows.Next() {
-		var name, version, status string
-		var publishedAt, updatedAt time.Time
-		var isLatest bool
-		var valueJSON []byte
-
-		err := rows.Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+		serverResponse, err := scanServerRow(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan server row: %w", err)
 		}
 
-		// Parse the ServerJSON from JSONB
-		var serverJSON apiv0.ServerJSON
-		if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
-		}
-
-		// Build ServerResponse with separated metadata
-		serverResponse := &apiv0.ServerResponse{
-			Server: serverJSON,
-			Meta: apiv0.ResponseMeta{
-				Official: &apiv0.RegistryExtensions{
-					Status:      model.Status(status),
-					PublishedAt: publishedAt,
-					UpdatedAt:   updatedAt,
-					IsLatest:    isLatest,
-				},
-			},
-		}
-
 		results = append(results, serverResponse)
 	}
 
@@ -384,32 +702,139 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 	return results, nil
 }
 
-// CreateServer inserts a new server version with official metadata
-func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *apiv0.ServerJSON, officialMeta *apiv0.RegistryExtensions) (*apiv0.ServerResponse, error) {
-	if ctx.Err() != nil {
-		return nil, ctx.Err()
+// ListServerVersions retrieves a page of the versions of a server in the requested order
+// The cursor is the version string of the last item on the previous page
+func (db *PostgreSQL) ListServerVersions(
+	ctx context.Context,
+	tx pgx.Tx,
+	serverName string,
+	options *VersionListOptions,
+	cursor string,
+	limit int,
+) ([]*apiv0.ServerResponse, string, error) {
+	if limit <= 0 {
+		limit = 10
 	}
 
-	// Validate inputs
-	if serverJSON == nil || officialMeta == nil {
-		return nil, fmt.Errorf("serverJSON and officialMeta are required")
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
 	}
 
-	if serverJSON.Name == "" || serverJSON.Version == "" {
-		return nil, fmt.Errorf("server name and version are required")
+	if options == nil {
+		options = &VersionListOptions{}
 	}
 
-	// Marshal the ServerJSON to JSONB
-	valueJSON, err := json.Marshal(serverJSON)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal server JSON: %w", err)
+	// Each ordering uses a unique (column, version) pair so the cursor position is unambiguous
+	orderColumn := "version_sort_key"
+	if options.OrderBy == VersionOrderByPublishedAt {
+		orderColumn = "published_at"
+	}
+	direction, comparison := "ASC", ">"
+	if options.Descending {
+		direction, comparison = "DESC", "<"
 	}
 
-	// Insert the new server version using composite primary key
-	insertQuery := `
-		INSERT INTO servers (server_name, version, status, published_at, updated_at, is_latest, value)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-	`
+	// The cursor check and the page query must run on the same executor: a lagging replica
+	// that has not seen the cursor version would otherwise silently return an empty page
+	executor := db.getReadExecutor(ctx, tx)
+
+	whereConditions := []string{"server_name = $1"}
+	args := []any{serverName}
+	argIndex := 2
+
+	if cursor != "" {
+		var cursorExists bool
+		err := executor.QueryRow(ctx,
+			`SELECT EXISTS(SELECT 1 FROM servers WHERE server_name = $1 AND version = $2)`,
+			serverName, cursor,
+		).Scan(&cursorExists)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to look up cursor version: %w", err)
+		}
+		if !cursorExists {
+			return nil, "", fmt.Errorf("%w: unknown cursor", ErrInvalidInput)
+		}
+
+		whereConditions = append(whereConditions, fmt.Sprintf(
+			"(%s, version) %s ((SELECT %s FROM servers WHERE server_name = $1 AND version = $%d), $%d)",
+			orderColumn, comparison, orderColumn, argIndex, argIndex))
+		args = append(args, cursor)
+		argIndex++
+	}
+
+	query := fmt.Sprintf(`
+		SELECT `+serverColumns+`
+		FROM servers
+		WHERE %s
+		ORDER BY %s %s, version %s
+		LIMIT $%d
+	`, strings.Join(whereConditions, " AND "), orderColumn, direction, direction, argIndex)
+	args = append(args, limit)
+
+	rows, err := executor.Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query server versions: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*apiv0.ServerResponse
+	for rows.Next() {
+		serverResponse, err := scanServerRow(rows)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to scan server row: %w", err)
+		}
+
+		results = append(results, serverResponse)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	// A server without any versions does not exist
+	if len(results) == 0 && cursor == "" {
+		return nil, "", ErrNotFound
+	}
+
+	nextCursor := ""
+	if len(results) > 0 && len(results) >= limit {
+		nextCursor = results[len(results)-1].Server.Version
+	}
+
+	return results, nextCursor, nil
+}
+
+// CreateServer inserts a new server version with official metadata
+func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *apiv0.ServerJSON, officialMeta *apiv0.RegistryExtensions) (*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	// Validate inputs
+	if serverJSON == nil || officialMeta == nil {
+		return nil, fmt.Errorf("serverJSON and officialMeta are required")
+	}
+
+	if serverJSON.Name == "" || serverJSON.Version == "" {
+		return nil, fmt.Errorf("server name and version are required")
+	}
+
+	// Marshal the ServerJSON to JSONB
+	valueJSON, err := json.Marshal(serverJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal server JSON: %w", err)
+	}
+
+	digest, err := contentDigest(serverJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	// Insert the new server version using composite primary key
+	insertQuery := `
+		INSERT INTO servers (server_name, version, status, published_at, updated_at, is_latest, value, version_sort_key, content_digest)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
 
 	_, err = db.getExecutor(tx).Exec(ctx, insertQuery,
 		serverJSON.Name,
@@ -419,6 +844,8 @@ func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *a
 		officialMeta.UpdatedAt,
 		officialMeta.IsLatest,
 		valueJSON,
+		versionSortKey(serverJSON, officialMeta.PublishedAt),
+		digest,
 	)
 
 	if err != nil {
@@ -426,10 +853,12 @@ func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *a
 	}
 
 	// Return the complete ServerResponse
+	official := *officialMeta
+	official.ContentDigest = digest
 	serverResponse := &apiv0.ServerResponse{
 		Server: *serverJSON,
 		Meta: apiv0.ResponseMeta{
-			Official: officialMeta,
+			Official: &official,
 		},
 	}
 
@@ -458,19 +887,35 @@ func (db *PostgreSQL) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, v
 		return nil, fmt.Errorf("failed to marshal updated server: %w", err)
 	}
 
+	digest, err := contentDigest(serverJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	// The sort key depends on the version scheme, which an edit can change
+	var existingPublishedAt time.Time
+	err = db.getExecutor(tx).QueryRow(ctx,
+		`SELECT published_at FROM servers WHERE server_name = $1 AND version = $2`,
+		serverName, version,
+	).Scan(&existingPublishedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get server: %w", err)
+	}
+
 	// Update only the JSON data (keep existing metadata columns)
 	query := `
 		UPDATE servers
-		SET value = $1, updated_at = NOW()
+		SET value = $1, updated_at = NOW(), version_sort_key = $4, content_digest = $5
 		WHERE server_name = $2 AND version = $3
-		RETURNING server_name, version, status, published_at, updated_at, is_latest
+		RETURNING ` + serverColumns + `
 	`
 
-	var name, vers, status string
-	var publishedAt, updatedAt time.Time
-	var isLatest bool
-
-	err = db.getExecutor(tx).QueryRow(ctx, query, valueJSON, serverName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest)
+	serverResponse, err := scanServerRow(db.getExecutor(tx).QueryRow(ctx, query, valueJSON, serverName, version,
+		versionSortKey(serverJSON, existingPublishedAt), digest,
+	))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -478,42 +923,29 @@ func (db *PostgreSQL) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, v
 		return nil, fmt.Errorf("failed to update server: %w", err)
 	}
 
-	// Return the updated ServerResponse
-	serverResponse := &apiv0.ServerResponse{
-		Server: *serverJSON,
-		Meta: apiv0.ResponseMeta{
-			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(status),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
-			},
-		},
-	}
-
 	return serverResponse, nil
 }
 
 // SetServerStatus updates the status of a specific server version
-func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName, version string, status string) (*apiv0.ServerResponse, error) {
+// The status message and replacement are replaced by those in details, or cleared if details is nil.
+// Deleting a version removes the dist-tags pointing at it.
+func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName, version string, status string, details *StatusDetails) (*apiv0.ServerResponse, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
 
-	// Update the status column
+	statusMessage, replacedByServerName, replacedByVersion := details.columns()
+
+	// Update the status columns
 	query := `
 		UPDATE servers
-		SET status = $1, updated_at = NOW()
+		SET status = $1, status_message = $4, replaced_by_server_name = $5, replaced_by_version = $6, updated_at = NOW()
 		WHERE server_name = $2 AND version = $3
-		RETURNING server_name, version, status, value, published_at, updated_at, is_latest
+		RETURNING ` + serverColumns + `
 	`
 
-	var name, vers, currentStatus string
-	var publishedAt, updatedAt time.Time
-	var isLatest bool
-	var valueJSON []byte
-
-	err := db.getExecutor(tx).QueryRow(ctx, query, status, serverName, version).Scan(&name, &vers, &currentStatus, &valueJSON, &publishedAt, &updatedAt, &isLatest)
+	serverResponse, err := scanServerRow(db.getExecutor(tx).QueryRow(ctx, query, status, serverName, version,
+		statusMessage, replacedByServerName, replacedByVersion))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -521,26 +953,51 @@ func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName
 		return nil, fmt.Errorf("failed to update server status: %w", err)
 	}
 
-	// Unmarshal the JSON data
-	var serverJSON apiv0.ServerJSON
-	if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	// Dist-tags must not keep resolving to a taken-down version
+	if status == string(model.StatusDeleted) {
+		if _, err := db.getExecutor(tx).Exec(ctx,
+			`DELETE FROM server_dist_tags WHERE server_name = $1 AND version = $2`, serverName, version,
+		); err != nil {
+			return nil, fmt.Errorf("failed to delete dist-tags of deleted version: %w", err)
+		}
 	}
 
-	// Return the updated ServerResponse
-	serverResponse := &apiv0.ServerResponse{
-		Server: serverJSON,
-		Meta: apiv0.ResponseMeta{
-			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(currentStatus),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
-			},
-		},
+	return serverResponse, nil
+}
+
+// serverChangesChannel is the NOTIFY channel of the server change triggers
+const serverChangesChannel = "server_changes"
+
+// ListenForServerChanges calls fn with the name of each server changed by any registry replica,
+// as notified by the server change triggers, until ctx is done or the connection fails
+func (db *PostgreSQL) ListenForServerChanges(ctx context.Context, fn func(serverName string)) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
 	}
 
-	return serverResponse, nil
+	poolConn, err := db.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	// A listening connection must not go back to the pool
+	conn := poolConn.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+serverChangesChannel); err != nil {
+		return fmt.Errorf("failed to listen for server changes: %w", err)
+	}
+	fn("")
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("failed to wait for server changes: %w", err)
+		}
+		fn(notification.Payload)
+	}
 }
 
 // InTransaction executes a function within a database transaction
@@ -583,6 +1040,14 @@ func (db *PostgreSQL) AcquirePublishLock(ctx context.Context, tx pgx.Tx, serverN
 
 	lockID := hashServerName(serverName)
 
+	// The timeout lasts until the end of the transaction, and also bounds waits for the row locks
+	// of the publish
+	if db.lockTimeout > 0 {
+		if _, err := db.getExecutor(tx).Exec(ctx, "SELECT set_config('lock_timeout', $1, true)", postgresInterval(db.lockTimeout)); err != nil {
+			return fmt.Errorf("failed to set lock timeout: %w", err)
+		}
+	}
+
 	if _, err := db.getExecutor(tx).Exec(ctx, "SELECT pg_advisory_xact_lock($1)", lockID); err != nil {
 		return fmt.Errorf("failed to acquire publish lock: %w", err)
 	}
@@ -590,6 +1055,25 @@ func (db *PostgreSQL) AcquirePublishLock(ctx context.Context, tx pgx.Tx, serverN
 	return nil
 }
 
+// AcquirePublishQuotaLock acquires an exclusive advisory lock on the subject of a publish quota.
+// Publishes of different servers can count against the same namespace or publisher quota, which
+// the per-server publish lock does not serialize. Using pg_advisory_xact_lock which auto-releases
+// on transaction end.
+func (db *PostgreSQL) AcquirePublishQuotaLock(ctx context.Context, tx pgx.Tx, quota PublishQuota, subject string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	// Server names cannot contain ":", so quota locks never share a key with publish locks
+	lockID := hashServerName("quota:" + string(quota) + ":" + subject)
+
+	if _, err := db.getExecutor(tx).Exec(ctx, "SELECT pg_advisory_xact_lock($1)", lockID); err != nil {
+		return fmt.Errorf("failed to acquire publish quota lock: %w", err)
+	}
+
+	return nil
+}
+
 // hashServerName creates a consistent hash of the server name for advisory locking
 // We use FNV-1a hash and mask to 63 bits to fit in PostgreSQL's bigint range
 func hashServerName(name string) int64 {
@@ -612,22 +1096,15 @@ func (db *PostgreSQL) GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, se
 		return nil, ctx.Err()
 	}
 
-	executor := db.getExecutor(tx)
+	executor := db.getReadExecutor(ctx, tx)
 
 	query := `
-		SELECT server_name, version, status, value, published_at, updated_at, is_latest
+		SELECT ` + serverColumns + `
 		FROM servers
 		WHERE server_name = $1 AND is_latest = true
 	`
 
-	row := executor.QueryRow(ctx, query, serverName)
-
-	var name, version, status string
-	var publishedAt, updatedAt time.Time
-	var isLatest bool
-	var jsonValue []byte
-
-	err := row.Scan(&name, &version, &status, &jsonValue, &publishedAt, &updatedAt, &isLatest)
+	serverResponse, err := scanServerRow(executor.QueryRow(ctx, query, serverName))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -635,24 +1112,6 @@ func (db *PostgreSQL) GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, se
 		return nil, fmt.Errorf("failed to scan server row: %w", err)
 	}
 
-	// Parse the JSON value to get the server details
-	var serverJSON apiv0.ServerJSON
-	if err := json.Unmarshal(jsonValue, &serverJSON); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
-	}
-
-	// Build ServerResponse with separated metadata
-	serverResponse := &apiv0.ServerResponse{
-		Server: serverJSON,
-		Meta: apiv0.ResponseMeta{
-			Official: &apiv0.RegistryExtensions{
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
-			},
-		},
-	}
-
 	return serverResponse, nil
 }
 
@@ -662,7 +1121,7 @@ func (db *PostgreSQL) CountServerVersions(ctx context.Context, tx pgx.Tx, server
 		return 0, ctx.Err()
 	}
 
-	executor := db.getExecutor(tx)
+	executor := db.getReadExecutor(ctx, tx)
 
 	query := `SELECT COUNT(*) FROM servers WHERE server_name = $1`
 
@@ -681,7 +1140,7 @@ func (db *PostgreSQL) CheckVersionExists(ctx context.Context, tx pgx.Tx, serverN
 		return false, ctx.Err()
 	}
 
-	executor := db.getExecutor(tx)
+	executor := db.getReadExecutor(ctx, tx)
 
 	query := `SELECT EXISTS(SELECT 1 FROM servers WHERE server_name = $1 AND version = $2)`
 
@@ -712,8 +1171,834 @@ func (db *PostgreSQL) UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName
 	return nil
 }
 
-// Close closes the database connection
-func (db *PostgreSQL) Close() error {
+// MarkAsLatest marks a specific version of a server as latest
+// Callers must unmark the current latest version first, as only one version per server can be latest
+func (db *PostgreSQL) MarkAsLatest(ctx context.Context, tx pgx.Tx, serverName, version string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	executor := db.getExecutor(tx)
+
+	query := `UPDATE servers SET is_latest = true WHERE server_name = $1 AND version = $2`
+
+	result, err := executor.Exec(ctx, query, serverName, version)
+	if err != nil {
+		return fmt.Errorf("failed to mark latest version: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// GetDistTags retrieves the named dist-tags of a server, keyed by tag
+// The "latest" tag is derived from is_latest and is not included
+func (db *PostgreSQL) GetDistTags(ctx context.Context, tx pgx.Tx, serverName string) (map[string]string, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	executor := db.getReadExecutor(ctx, tx)
+
+	query := `SELECT tag, version FROM server_dist_tags WHERE server_name = $1 ORDER BY tag`
+
+	rows, err := executor.Query(ctx, query, serverName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dist-tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make(map[string]string)
+	for rows.Next() {
+		var tag, version string
+		if err := rows.Scan(&tag, &version); err != nil {
+			return nil, fmt.Errorf("failed to scan dist-tag row: %w", err)
+		}
+		tags[tag] = version
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return tags, nil
+}
+
+// SetDistTag points a named dist-tag of a server at a specific version, creating or moving the tag
+func (db *PostgreSQL) SetDistTag(ctx context.Context, tx pgx.Tx, serverName, tag, version string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	executor := db.getExecutor(tx)
+
+	query := `
+		INSERT INTO server_dist_tags (server_name, tag, version, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (server_name, tag) DO UPDATE SET version = EXCLUDED.version, updated_at = NOW()
+	`
+
+	if _, err := executor.Exec(ctx, query, serverName, tag, version); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to set dist-tag: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteDistTag removes a named dist-tag from a server
+func (db *PostgreSQL) DeleteDistTag(ctx context.Context, tx pgx.Tx, serverName, tag string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	executor := db.getExecutor(tx)
+
+	query := `DELETE FROM server_dist_tags WHERE server_name = $1 AND tag = $2`
+
+	result, err := executor.Exec(ctx, query, serverName, tag)
+	if err != nil {
+		return fmt.Errorf("failed to delete dist-tag: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// SetServerPublisher records the identity that published a server version
+func (db *PostgreSQL) SetServerPublisher(ctx context.Context, tx pgx.Tx, serverName, version, publishedBy string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `UPDATE servers SET published_by = NULLIF($3, '') WHERE server_name = $1 AND version = $2`
+
+	result, err := db.getExecutor(tx).Exec(ctx, query, serverName, version, publishedBy)
+	if err != nil {
+		return fmt.Errorf("failed to set server publisher: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// CountNamespaceServers counts the distinct servers in a namespace, excluding its sub-namespaces
+func (db *PostgreSQL) CountNamespaceServers(ctx context.Context, tx pgx.Tx, namespace string) (int, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	query := `SELECT COUNT(DISTINCT server_name) FROM servers WHERE namespace = $1`
+
+	var count int
+	if err := db.getReadExecutor(ctx, tx).QueryRow(ctx, query, namespace).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count namespace servers: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountVersionsPublishedSince counts the versions of a server published after a time, and
+// returns when the earliest of them was published (the zero time if there are none)
+func (db *PostgreSQL) CountVersionsPublishedSince(ctx context.Context, tx pgx.Tx, serverName string, since time.Time) (int, time.Time, error) {
+	if ctx.Err() != nil {
+		return 0, time.Time{}, ctx.Err()
+	}
+
+	query := `SELECT COUNT(*), MIN(published_at) FROM servers WHERE server_name = $1 AND published_at > $2`
+
+	return db.countPublishes(ctx, tx, query, serverName, since)
+}
+
+// CountPublishesSince counts the server versions a publisher published after a time, and
+// returns when the earliest of them was published (the zero time if there are none)
+func (db *PostgreSQL) CountPublishesSince(ctx context.Context, tx pgx.Tx, publishedBy string, since time.Time) (int, time.Time, error) {
+	if ctx.Err() != nil {
+		return 0, time.Time{}, ctx.Err()
+	}
+
+	query := `SELECT COUNT(*), MIN(published_at) FROM servers WHERE published_by = $1 AND published_at > $2`
+
+	return db.countPublishes(ctx, tx, query, publishedBy, since)
+}
+
+// countPublishes runs a query returning a count of server versions and their earliest publication time
+func (db *PostgreSQL) countPublishes(ctx context.Context, tx pgx.Tx, query, subject string, since time.Time) (int, time.Time, error) {
+	var count int
+	var earliest *time.Time
+	if err := db.getReadExecutor(ctx, tx).QueryRow(ctx, query, subject, since).Scan(&count, &earliest); err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to count publishes: %w", err)
+	}
+	if earliest == nil {
+		return count, time.Time{}, nil
+	}
+
+	return count, *earliest, nil
+}
+
+// ListPublishQuotaOverrides retrieves every publish quota override, ordered by quota and subject
+func (db *PostgreSQL) ListPublishQuotaOverrides(ctx context.Context, tx pgx.Tx) ([]*apiv0.PublishQuotaOverride, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT quota, subject, quota_limit, COALESCE(reason, ''), updated_at
+		FROM publish_quota_overrides
+		ORDER BY quota, subject
+	`
+
+	rows, err := db.getReadExecutor(ctx, tx).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query publish quota overrides: %w", err)
+	}
+	defer rows.Close()
+
+	overrides := []*apiv0.PublishQuotaOverride{}
+	for rows.Next() {
+		var override apiv0.PublishQuotaOverride
+		if err := rows.Scan(&override.Quota, &override.Subject, &override.Limit, &override.Reason, &override.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan publish quota override row: %w", err)
+		}
+		overrides = append(overrides, &override)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return overrides, nil
+}
+
+// GetPublishQuotaOverride retrieves the override of a publish quota for a subject
+func (db *PostgreSQL) GetPublishQuotaOverride(ctx context.Context, tx pgx.Tx, quota PublishQuota, subject string) (*apiv0.PublishQuotaOverride, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT quota, subject, quota_limit, COALESCE(reason, ''), updated_at
+		FROM publish_quota_overrides
+		WHERE quota = $1 AND subject = $2
+	`
+
+	var override apiv0.PublishQuotaOverride
+	err := db.getReadExecutor(ctx, tx).QueryRow(ctx, query, string(quota), subject).
+		Scan(&override.Quota, &override.Subject, &override.Limit, &override.Reason, &override.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get publish quota override: %w", err)
+	}
+
+	return &override, nil
+}
+
+// SetPublishQuotaOverride creates or replaces the override of a publish quota for a subject
+func (db *PostgreSQL) SetPublishQuotaOverride(ctx context.Context, tx pgx.Tx, quota PublishQuota, subject string, limit int, reason string) (*apiv0.PublishQuotaOverride, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if subject == "" || limit < 0 {
+		return nil, fmt.Errorf("%w: a quota override needs a subject and a limit of at least 0", ErrInvalidInput)
+	}
+
+	query := `
+		INSERT INTO publish_quota_overrides (quota, subject, quota_limit, reason, updated_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NOW())
+		ON CONFLICT (quota, subject) DO UPDATE
+		SET quota_limit = EXCLUDED.quota_limit, reason = EXCLUDED.reason, updated_at = NOW()
+		RETURNING updated_at
+	`
+
+	override := &apiv0.PublishQuotaOverride{
+		Quota:   string(quota),
+		Subject: subject,
+		Limit:   limit,
+		Reason:  reason,
+	}
+	err := db.getExecutor(tx).QueryRow(ctx, query, string(quota), subject, limit, reason).Scan(&override.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23514" {
+			return nil, fmt.Errorf("%w: unknown publish quota %q", ErrInvalidInput, quota)
+		}
+		return nil, fmt.Errorf("failed to set publish quota override: %w", err)
+	}
+
+	return override, nil
+}
+
+// DeletePublishQuotaOverride removes the override of a publish quota for a subject
+func (db *PostgreSQL) DeletePublishQuotaOverride(ctx context.Context, tx pgx.Tx, quota PublishQuota, subject string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `DELETE FROM publish_quota_overrides WHERE quota = $1 AND subject = $2`
+
+	result, err := db.getExecutor(tx).Exec(ctx, query, string(quota), subject)
+	if err != nil {
+		return fmt.Errorf("failed to delete publish quota override: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ListBlockedNamespaces retrieves every namespace blocked from publishing, ordered by namespace
+func (db *PostgreSQL) ListBlockedNamespaces(ctx context.Context, tx pgx.Tx) ([]*apiv0.BlockedNamespace, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT namespace, COALESCE(reason, ''), COALESCE(blocked_by, ''), created_at
+		FROM blocked_namespaces
+		ORDER BY namespace
+	`
+
+	rows, err := db.getReadExecutor(ctx, tx).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query blocked namespaces: %w", err)
+	}
+	defer rows.Close()
+
+	blocked := []*apiv0.BlockedNamespace{}
+	for rows.Next() {
+		var namespace apiv0.BlockedNamespace
+		if err := rows.Scan(&namespace.Namespace, &namespace.Reason, &namespace.BlockedBy, &namespace.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan blocked namespace row: %w", err)
+		}
+		blocked = append(blocked, &namespace)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return blocked, nil
+}
+
+// IsNamespaceBlocked checks if a namespace or one of its parent namespaces is blocked from
+// publishing: blocking "com.example" also blocks "com.example.team"
+func (db *PostgreSQL) IsNamespaceBlocked(ctx context.Context, tx pgx.Tx, namespace string) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	// A namespace and each of its parents, e.g. "com.example.team" and "com.example"
+	candidates := []string{namespace}
+	for i := strings.LastIndex(namespace, "."); i > 0; i = strings.LastIndex(namespace[:i], ".") {
+		candidates = append(candidates, namespace[:i])
+	}
+
+	query := `SELECT EXISTS(SELECT 1 FROM blocked_namespaces WHERE namespace = ANY($1))`
+
+	var blocked bool
+	if err := db.getReadExecutor(ctx, tx).QueryRow(ctx, query, candidates).Scan(&blocked); err != nil {
+		return false, fmt.Errorf("failed to check blocked namespaces: %w", err)
+	}
+
+	return blocked, nil
+}
+
+// BlockNamespace blocks a namespace from publishing, or updates the reason it is blocked for
+func (db *PostgreSQL) BlockNamespace(ctx context.Context, tx pgx.Tx, namespace, reason, blockedBy string) (*apiv0.BlockedNamespace, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO blocked_namespaces (namespace, reason, blocked_by, created_at)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), NOW())
+		ON CONFLICT (namespace) DO UPDATE SET reason = EXCLUDED.reason, blocked_by = EXCLUDED.blocked_by
+		RETURNING created_at
+	`
+
+	blocked := &apiv0.BlockedNamespace{
+		Namespace: namespace,
+		Reason:    reason,
+		BlockedBy: blockedBy,
+	}
+	if err := db.getExecutor(tx).QueryRow(ctx, query, namespace, reason, blockedBy).Scan(&blocked.CreatedAt); err != nil {
+		return nil, fmt.Errorf("failed to block namespace: %w", err)
+	}
+
+	return blocked, nil
+}
+
+// UnblockNamespace allows a blocked namespace to publish again
+func (db *PostgreSQL) UnblockNamespace(ctx context.Context, tx pgx.Tx, namespace string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `DELETE FROM blocked_namespaces WHERE namespace = $1`
+
+	result, err := db.getExecutor(tx).Exec(ctx, query, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to unblock namespace: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// CreateAPIToken stores a new API token under the SHA-256 hash of its secret
+func (db *PostgreSQL) CreateAPIToken(ctx context.Context, tx pgx.Tx, token *apiv0.APIToken, tokenHash []byte) (*apiv0.APIToken, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	permissionsJSON, err := json.Marshal(token.Permissions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal API token permissions: %w", err)
+	}
+
+	query := `
+		INSERT INTO api_tokens (id, token_hash, owner, description, permissions, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), $6)
+		RETURNING created_at
+	`
+
+	created := *token
+	created.LastUsedAt = nil
+	if err := db.getExecutor(tx).QueryRow(ctx, query, token.ID, tokenHash, token.Owner, token.Description, permissionsJSON, token.ExpiresAt).Scan(&created.CreatedAt); err != nil {
+		return nil, fmt.Errorf("failed to create API token: %w", err)
+	}
+
+	return &created, nil
+}
+
+// ListAPITokens retrieve the API tokens of an owner, newest first. Reads go to the primary so
+// that tokens are listed as soon as they are created or revoked.
+func (db *PostgreSQL) ListAPITokens(ctx context.Context, tx pgx.Tx, owner string) ([]*apiv0.APIToken, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT id, owner, description, permissions, created_at, expires_at, last_used_at
+		FROM api_tokens
+		WHERE owner = $1
+		ORDER BY created_at DESC, id
+	`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query API tokens: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := []*apiv0.APIToken{}
+	for rows.Next() {
+		token, err := scanAPITokenRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// GetAPITokenByHash retrieve the API token with the SHA-256 hash of its secret. Reads go to the
+// primary so that a revoked token cannot be exchanged on a lagging replica.
+func (db *PostgreSQL) GetAPITokenByHash(ctx context.Context, tx pgx.Tx, tokenHash []byte) (*apiv0.APIToken, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT id, owner, description, permissions, created_at, expires_at, last_used_at
+		FROM api_tokens
+		WHERE token_hash = $1
+	`
+
+	token, err := scanAPITokenRow(db.getExecutor(tx).QueryRow(ctx, query, tokenHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// MarkAPITokenUsed records that an API token was just used
+func (db *PostgreSQL) MarkAPITokenUsed(ctx context.Context, tx pgx.Tx, id string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `UPDATE api_tokens SET last_used_at = NOW() WHERE id = $1`
+
+	if _, err := db.getExecutor(tx).Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to mark API token as used: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAPIToken revokes an API token of an owner
+func (db *PostgreSQL) DeleteAPIToken(ctx context.Context, tx pgx.Tx, id, owner string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `DELETE FROM api_tokens WHERE id = $1 AND owner = $2`
+
+	result, err := db.getExecutor(tx).Exec(ctx, query, id, owner)
+	if err != nil {
+		return fmt.Errorf("failed to delete API token: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// scanAPITokenRow scans an API token row, leaving pgx.ErrNoRows unwrapped
+func scanAPITokenRow(row pgx.Row) (*apiv0.APIToken, error) {
+	var token apiv0.APIToken
+	var permissionsJSON []byte
+	if err := row.Scan(&token.ID, &token.Owner, &token.Description, &permissionsJSON, &token.CreatedAt, &token.ExpiresAt, &token.LastUsedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan API token row: %w", err)
+	}
+	if err := json.Unmarshal(permissionsJSON, &token.Permissions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal API token permissions: %w", err)
+	}
+	return &token, nil
+}
+
+// CreateServerRevision appends a server.json to the edit history of a server version,
+// numbering it one above the current highest revision
+func (db *PostgreSQL) CreateServerRevision(ctx context.Context, tx pgx.Tx, serverName, version string, serverJSON *apiv0.ServerJSON, editedBy string) (*apiv0.ServerRevision, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	valueJSON, err := json.Marshal(serverJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal server JSON: %w", err)
+	}
+
+	query := `
+		INSERT INTO server_revisions (server_name, version, revision, value, edited_by, created_at)
+		SELECT $1, $2, COALESCE(MAX(revision), 0) + 1, $3, NULLIF($4, ''), NOW()
+		FROM server_revisions
+		WHERE server_name = $1 AND version = $2
+		RETURNING revision, created_at
+	`
+
+	revision := &apiv0.ServerRevision{
+		Server:   *serverJSON,
+		EditedBy: editedBy,
+	}
+	err = db.getExecutor(tx).QueryRow(ctx, query, serverName, version, valueJSON, editedBy).Scan(&revision.Revision, &revision.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to create server revision: %w", err)
+	}
+
+	return revision, nil
+}
+
+// ListServerRevisions retrieve the edit history of a server version, oldest first
+func (db *PostgreSQL) ListServerRevisions(ctx context.Context, tx pgx.Tx, serverName, version string) ([]*apiv0.ServerRevision, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT revision, value, COALESCE(edited_by, ''), created_at
+		FROM server_revisions
+		WHERE server_name = $1 AND version = $2
+		ORDER BY revision
+	`
+
+	rows, err := db.getReadExecutor(ctx, tx).Query(ctx, query, serverName, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*apiv0.ServerRevision
+	for rows.Next() {
+		revision, err := scanServerRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	if len(revisions) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return revisions, nil
+}
+
+// GetServerRevision retrieve a specific revision of a server version
+func (db *PostgreSQL) GetServerRevision(ctx context.Context, tx pgx.Tx, serverName, version string, revision int) (*apiv0.ServerRevision, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT revision, value, COALESCE(edited_by, ''), created_at
+		FROM server_revisions
+		WHERE server_name = $1 AND version = $2 AND revision = $3
+	`
+
+	result, err := scanServerRevision(db.getReadExecutor(ctx, tx).QueryRow(ctx, query, serverName, version, revision))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// AppendLogEntry appends an entry to the transparency log, along with the hash of every subtree
+// the new leaf completes. Appends are serialized by a table lock held until the transaction ends,
+// so log indexes have no gaps and follow commit order.
+func (db *PostgreSQL) AppendLogEntry(ctx context.Context, tx pgx.Tx, entry *transparency.LogEntry) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	if tx == nil {
+		var index int64
+		err := db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+			var err error
+			index, err = db.AppendLogEntry(ctx, tx, entry)
+			return err
+		})
+		return index, err
+	}
+
+	leafData, err := entry.LeafData()
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode log entry: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, "LOCK TABLE transparency_log_entries IN EXCLUSIVE MODE"); err != nil {
+		return 0, fmt.Errorf("failed to lock transparency log: %w", err)
+	}
+
+	index, err := db.GetLogTreeSize(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO transparency_log_entries (log_index, server_name, version, revision, leaf_data)
+		VALUES ($1, $2, $3, $4, $5)
+	`, index, entry.ServerName, entry.Version, entry.Revision, leafData)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert log entry: %w", err)
+	}
+
+	// Store the leaf, then, as long as the node is a right child, the parent it completes
+	hash := transparency.LeafHash(leafData)
+	level, nodeIndex := 0, index
+	for {
+		_, err := tx.Exec(ctx, "INSERT INTO transparency_log_nodes (level, node_index, hash) VALUES ($1, $2, $3)", level, nodeIndex, hash)
+		if err != nil {
+			return 0, fmt.Errorf("failed to insert log node: %w", err)
+		}
+		if nodeIndex%2 == 0 {
+			break
+		}
+
+		left, err := db.GetLogSubtreeHash(ctx, tx, level, nodeIndex-1)
+		if err != nil {
+			return 0, err
+		}
+		hash = transparency.NodeHash(left, hash)
+		level++
+		nodeIndex /= 2
+	}
+
+	return index, nil
+}
+
+// GetLogTreeSize count the entries in the transparency log
+func (db *PostgreSQL) GetLogTreeSize(ctx context.Context, tx pgx.Tx) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	// Log reads stay on the primary even outside a transaction: a proof combines several reads,
+	// which replicas lagging by different amounts could answer inconsistently. Log indexes have
+	// no gaps.
+	var size int64
+	if err := db.getExecutor(tx).QueryRow(ctx, "SELECT COALESCE(MAX(log_index) + 1, 0) FROM transparency_log_entries").Scan(&size); err != nil {
+		return 0, fmt.Errorf("failed to get transparency log size: %w", err)
+	}
+
+	return size, nil
+}
+
+// GetLogSubtreeHash retrieve the hash of the complete subtree of 2^level leaves starting at leaf index<<level
+func (db *PostgreSQL) GetLogSubtreeHash(ctx context.Context, tx pgx.Tx, level int, index int64) ([]byte, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var hash []byte
+	err := db.getExecutor(tx).QueryRow(ctx, "SELECT hash FROM transparency_log_nodes WHERE level = $1 AND node_index = $2", level, index).Scan(&hash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get log node: %w", err)
+	}
+
+	return hash, nil
+}
+
+// FindLogEntry retrieve the latest transparency log entry of a server version, or the entry of a specific revision if revision > 0
+func (db *PostgreSQL) FindLogEntry(ctx context.Context, tx pgx.Tx, serverName, version string, revision int) (int64, *transparency.LogEntry, error) {
+	if ctx.Err() != nil {
+		return 0, nil, ctx.Err()
+	}
+
+	query := `
+		SELECT log_index, leaf_data
+		FROM transparency_log_entries
+		WHERE server_name = $1 AND version = $2 AND ($3 = 0 OR revision = $3)
+		ORDER BY log_index DESC
+		LIMIT 1
+	`
+
+	var index int64
+	var leafData []byte
+	err := db.getExecutor(tx).QueryRow(ctx, query, serverName, version, revision).Scan(&index, &leafData)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil, ErrNotFound
+		}
+		return 0, nil, fmt.Errorf("failed to get log entry: %w", err)
+	}
+
+	var entry transparency.LogEntry
+	if err := json.Unmarshal(leafData, &entry); err != nil {
+		return 0, nil, fmt.Errorf("failed to unmarshal log entry: %w", err)
+	}
+
+	return index, &entry, nil
+}
+
+// serverColumns is the servers column list read by scanServerRow
+const serverColumns = "server_name, version, status, published_at, updated_at, is_latest, value, " +
+	"status_message, replaced_by_server_name, replaced_by_version, content_digest"
+
+// scanServerRow scans a row of serverColumns into a ServerResponse with separated metadata
+func scanServerRow(row pgx.Row) (*apiv0.ServerResponse, error) {
+	var name, version, status string
+	var publishedAt, updatedAt time.Time
+	var isLatest bool
+	var valueJSON []byte
+	var statusMessage, replacedByServerName, replacedByVersion, digest *string
+
+	err := row.Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON,
+		&statusMessage, &replacedByServerName, &replacedByVersion, &digest)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the ServerJSON from JSONB
+	var serverJSON apiv0.ServerJSON
+	if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	}
+
+	official := &apiv0.RegistryExtensions{
+		Status:      model.Status(status),
+		PublishedAt: publishedAt,
+		UpdatedAt:   updatedAt,
+		IsLatest:    isLatest,
+	}
+	if statusMessage != nil {
+		official.StatusMessage = *statusMessage
+	}
+	if digest != nil {
+		official.ContentDigest = *digest
+	}
+	if replacedByServerName != nil {
+		official.ReplacedBy = &apiv0.ServerReference{ServerName: *replacedByServerName}
+		if replacedByVersion != nil {
+			official.ReplacedBy.Version = *replacedByVersion
+		}
+	}
+
+	return &apiv0.ServerResponse{
+		Server: serverJSON,
+		Meta: apiv0.ResponseMeta{
+			Official: official,
+		},
+	}, nil
+}
+
+// scanServerRevision scans a (revision, value, edited_by, created_at) row
+func scanServerRevision(row pgx.Row) (*apiv0.ServerRevision, error) {
+	var revision apiv0.ServerRevision
+	var valueJSON []byte
+
+	if err := row.Scan(&revision.Revision, &valueJSON, &revision.EditedBy, &revision.CreatedAt); err != nil {
+		return nil, fmt.Errorf("failed to scan server revision row: %w", err)
+	}
+
+	if err := json.Unmarshal(valueJSON, &revision.Server); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	}
+
+	return &revision, nil
+}
+
+// Close closes the database connections
+func (db *PostgreSQL) Close() error {
+	if db.stopHealthChecks != nil {
+		db.stopHealthChecks()
+		<-db.healthChecksDone
+	}
+	closeReplicas(db.replicas)
 	db.pool.Close()
 	return nil
 }
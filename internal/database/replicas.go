@@ -0,0 +1,174 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// replicaHealthCheckInterval is how often replicas are pinged to decide whether reads can use them
+	replicaHealthCheckInterval = 10 * time.Second
+	// replicaHealthCheckTimeout is how long a replica has to answer a ping before it is considered unhealthy
+	replicaHealthCheckTimeout = 2 * time.Second
+)
+
+// replica is the connection pool of a read replica
+type replica struct {
+	name    string
+	pool    *pgxpool.Pool
+	healthy atomic.Bool
+}
+
+// connectReplicas creates a connection pool for each replica and checks their health once.
+// Replicas that cannot be reached yet are not an error: reads go to the primary until they
+// become healthy.
+func connectReplicas(ctx context.Context, options *Options) ([]*replica, error) {
+	replicas := make([]*replica, 0, len(options.ReplicaURIs))
+	for i, uri := range options.ReplicaURIs {
+		config, err := pgxpool.ParseConfig(uri)
+		if err != nil {
+			closeReplicas(replicas)
+			// The URI may hold a password, so it is not included in the error
+			return nil, fmt.Errorf("failed to parse PostgreSQL replica %d config: %w", i+1, err)
+		}
+		configurePool(config, options)
+
+		pool, err := pgxpool.NewWithConfig(ctx, config)
+		if err != nil {
+			closeReplicas(replicas)
+			return nil, fmt.Errorf("failed to create PostgreSQL replica %d pool: %w", i+1, err)
+		}
+		replicas = append(replicas, &replica{name: fmt.Sprintf("replica-%d", i+1), pool: pool})
+	}
+
+	checkReplicas(ctx, replicas)
+	for _, r := range replicas {
+		if !r.healthy.Load() {
+			log.Printf("PostgreSQL %s is not reachable yet, routing reads to other servers", r.name)
+		}
+	}
+	return replicas, nil
+}
+
+// checkReplicas pings every replica concurrently and records whether it answered, logging
+// changes in health
+func checkReplicas(ctx context.Context, replicas []*replica) {
+	var wg sync.WaitGroup
+	for _, r := range replicas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pingCtx, cancel := context.WithTimeout(ctx, replicaHealthCheckTimeout)
+			defer cancel()
+
+			err := r.pool.Ping(pingCtx)
+			if ctx.Err() != nil {
+				// Shutting down
+				return
+			}
+			healthy := err == nil
+			if r.healthy.Swap(healthy) == healthy {
+				return
+			}
+			if healthy {
+				log.Printf("PostgreSQL %s is healthy, routing reads to it", r.name)
+			} else {
+				log.Printf("PostgreSQL %s is unhealthy, routing reads to other servers: %v", r.name, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// watchReplicas checks the health of the replicas periodically until ctx is done
+func watchReplicas(ctx context.Context, replicas []*replica, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(replicaHealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			checkReplicas(ctx, replicas)
+		}
+	}
+}
+
+func closeReplicas(replicas []*replica) {
+	for _, r := range replicas {
+		r.pool.Close()
+	}
+}
+
+// readPool returns the pool to run reads outside a transaction on: the next healthy replica in
+// round-robin order, or the primary if there is none
+func (db *PostgreSQL) readPool() *pgxpool.Pool {
+	n := len(db.replicas)
+	if n == 0 {
+		return db.pool
+	}
+
+	start := int(db.nextReplica.Add(1) % uint64(n)) //nolint:gosec // n is a small positive int
+	for i := range n {
+		if r := db.replicas[(start+i)%n]; r.healthy.Load() {
+			return r.pool
+		}
+	}
+	return db.pool
+}
+
+// primaryReadsKey is the context key set by WithPrimaryReads
+type primaryReadsKey struct{}
+
+// WithPrimaryReads returns a context whose reads outside a transaction go to the primary instead
+// of a replica. Replicas can lag behind the primary, so reads that must see the latest commits,
+// such as cache fills right after a write invalidated the cache, should use it.
+func WithPrimaryReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryReadsKey{}, true)
+}
+
+// getReadExecutor returns the executor for a read-only query: the transaction if there is one,
+// so that it sees the transaction's own writes, the primary if ctx asks for primary reads, or
+// else a replica.
+func (db *PostgreSQL) getReadExecutor(ctx context.Context, tx pgx.Tx) Executor {
+	if tx != nil {
+		return tx
+	}
+	if primary, _ := ctx.Value(primaryReadsKey{}).(bool); primary {
+		return db.pool
+	}
+	return db.readPool()
+}
+
+// PoolStats returns the connection statistics of the primary pool and of each replica pool
+func (db *PostgreSQL) PoolStats() []PoolStats {
+	stats := []PoolStats{poolStats("primary", true, db.pool)}
+	for _, r := range db.replicas {
+		stats = append(stats, poolStats(r.name, r.healthy.Load(), r.pool))
+	}
+	return stats
+}
+
+func poolStats(name string, healthy bool, pool *pgxpool.Pool) PoolStats {
+	stat := pool.Stat()
+	return PoolStats{
+		Name:              name,
+		Healthy:           healthy,
+		MaxConns:          stat.MaxConns(),
+		TotalConns:        stat.TotalConns(),
+		IdleConns:         stat.IdleConns(),
+		AcquiredConns:     stat.AcquiredConns(),
+		AcquireCount:      stat.AcquireCount(),
+		EmptyAcquireCount: stat.EmptyAcquireCount(),
+		AcquireDuration:   stat.AcquireDuration(),
+	}
+}
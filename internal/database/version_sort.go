@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/versioning"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// versionSortKey returns the value of the version_sort_key column for a server version.
+// Its byte order (COLLATE "C") matches the ordering of the server's version scheme.
+func versionSortKey(serverJSON *apiv0.ServerJSON, publishedAt time.Time) string {
+	return versioning.SortKey(versioning.ForServer(serverJSON), serverJSON.Version, publishedAt)
+}
+
+// backfillVersionSortKeys populates version_sort_key for rows written before the column existed
+// or whose key was reset because the sort key encoding changed, and queues their servers for
+// backfillLatestVersions
+func backfillVersionSortKeys(ctx context.Context, conn *pgx.Conn) error {
+	rows, err := conn.Query(ctx, `SELECT server_name, version, published_at, value FROM servers WHERE version_sort_key IS NULL`)
+	if err != nil {
+		return fmt.Errorf("failed to query servers without sort keys: %w", err)
+	}
+
+	batch := &pgx.Batch{}
+	for rows.Next() {
+		var serverName, version string
+		var publishedAt time.Time
+		var valueJSON []byte
+		if err := rows.Scan(&serverName, &version, &publishedAt, &valueJSON); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan server row: %w", err)
+		}
+
+		var serverJSON apiv0.ServerJSON
+		if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to unmarshal server JSON: %w", err)
+		}
+		// The version column is authoritative
+		serverJSON.Version = version
+
+		batch.Queue(`UPDATE servers SET version_sort_key = $1 WHERE server_name = $2 AND version = $3`,
+			versionSortKey(&serverJSON, publishedAt), serverName, version)
+		// The new ordering may disagree with the one that chose the latest version
+		batch.Queue(`INSERT INTO pending_latest_versions (server_name) VALUES ($1) ON CONFLICT DO NOTHING`, serverName)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	if err := conn.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to backfill version sort keys: %w", err)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package importer
 
 import (
 	"context"
+	"crypto/ed25519"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,15 +10,22 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/snapshot"
 	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/canonicaljson"
 )
 
 // Service handles importing seed data into the registry
 type Service struct {
 	registry service.RegistryService
+
+	// In verify mode, only seed data matching a manifest signed by publicKey is imported
+	publicKey    ed25519.PublicKey
+	manifestPath string
 }
 
 // NewService creates a new importer service
@@ -25,6 +33,13 @@ func NewService(registry service.RegistryService) *Service {
 	return &Service{registry: registry}
 }
 
+// NewVerifyingService creates an importer service that refuses seed data unless it matches a snapshot
+// manifest signed by publicKey. An empty manifestPath defaults to the /snapshot endpoint of the
+// registry API being imported from.
+func NewVerifyingService(registry service.RegistryService, publicKey ed25519.PublicKey, manifestPath string) *Service {
+	return &Service{registry: registry, publicKey: publicKey, manifestPath: manifestPath}
+}
+
 // ImportFromPath imports seed data from various sources:
 // 1. Local file paths (*.json files) - expects ServerJSON array format
 // 2. Direct HTTP URLs to seed.json files - expects ServerJSON array format
@@ -35,6 +50,13 @@ func (s *Service) ImportFromPath(ctx context.Context, path string) error {
 		return fmt.Errorf("failed to read seed data: %w", err)
 	}
 
+	if s.publicKey != nil {
+		servers, err = s.verifyServers(ctx, path, servers)
+		if err != nil {
+			return fmt.Errorf("failed to verify seed data: %w", err)
+		}
+	}
+
 	// Import each server using registry service CreateServer
 	var successfullyCreated []string
 	var failedCreations []string
@@ -61,6 +83,88 @@ func (s *Service) ImportFromPath(ctx context.Context, path string) error {
 	return nil
 }
 
+// verifyServers checks the seed data against the signed manifest. Any server whose digest does not
+// match fails the whole import, since that means the data was modified; servers the manifest does
+// not list, e.g. because they were published after it was generated, are skipped.
+func (s *Service) verifyServers(ctx context.Context, path string, servers []*apiv0.ServerJSON) ([]*apiv0.ServerJSON, error) {
+	manifest, err := s.readManifest(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	digests := make(map[string]string, len(manifest.Manifest.Servers))
+	for _, entry := range manifest.Manifest.Servers {
+		digests[entry.Name+"@"+entry.Version] = entry.SHA256
+	}
+
+	var verified []*apiv0.ServerJSON
+	var unlisted []string
+	var mismatched []string
+	for _, server := range servers {
+		key := server.Name + "@" + server.Version
+		expected, ok := digests[key]
+		if !ok {
+			unlisted = append(unlisted, key)
+			continue
+		}
+
+		digest, err := canonicaljson.Digest(server)
+		if err != nil {
+			return nil, fmt.Errorf("failed to digest %s: %w", key, err)
+		}
+		if digest != expected {
+			mismatched = append(mismatched, key)
+			continue
+		}
+		verified = append(verified, server)
+	}
+
+	if len(mismatched) > 0 {
+		log.Printf("Servers not matching the signed manifest: %v", mismatched)
+		return nil, fmt.Errorf("%d servers do not match the signed manifest", len(mismatched))
+	}
+	if len(unlisted) > 0 {
+		log.Printf("Warning: Skipping %d servers not listed in the signed manifest: %v", len(unlisted), unlisted)
+	}
+	log.Printf("Verification summary: %d servers match the manifest signed at %s", len(verified), manifest.Manifest.GeneratedAt.Format(time.RFC3339))
+
+	return verified, nil
+}
+
+// readManifest reads the signed manifest and checks its signature
+func (s *Service) readManifest(ctx context.Context, seedPath string) (*snapshot.SignedManifest, error) {
+	path := s.manifestPath
+	if path == "" {
+		// Registry APIs serve the manifest next to the server list
+		base, _, found := strings.Cut(seedPath, "/servers")
+		if !found || !strings.HasPrefix(seedPath, "http") {
+			return nil, fmt.Errorf("a manifest location is required to verify seed data from %s", seedPath)
+		}
+		path = base + "/snapshot"
+	}
+
+	var data []byte
+	var err error
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		data, err = fetchFromHTTP(ctx, path)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest from %s: %w", path, err)
+	}
+
+	var manifest snapshot.SignedManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if err := manifest.Verify(s.publicKey); err != nil {
+		return nil, err
+	}
+
+	return &manifest, nil
+}
+
 // readSeedFile reads seed data from various sources
 func readSeedFile(ctx context.Context, path string) ([]*apiv0.ServerJSON, error) {
 	var data []byte
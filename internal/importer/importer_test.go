@@ -2,18 +2,22 @@ package importer_test
 
 import (
 	"context"
+	"crypto/ed25519"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/importer"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/snapshot"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/canonicaljson"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -53,7 +57,7 @@ func TestImportService_LocalFile(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify the server was imported using registry service
-	servers, _, err := registryService.ListServers(context.Background(), nil, "", 10)
+	servers, _, err := registryService.ListServers(context.Background(), nil, nil, "", 10)
 	require.NoError(t, err)
 	assert.Len(t, servers, 1)
 	assert.Equal(t, "io.github.test/test-server-1", servers[0].Server.Name)
@@ -99,7 +103,7 @@ func TestImportService_HTTPFile(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify the server was imported
-	servers, _, err := registryService.ListServers(context.Background(), nil, "", 10)
+	servers, _, err := registryService.ListServers(context.Background(), nil, nil, "", 10)
 	require.NoError(t, err)
 	assert.Len(t, servers, 1)
 	assert.Equal(t, "io.github.test/http-test-server", servers[0].Server.Name)
@@ -138,7 +142,7 @@ func TestImportService_RegistryPagination(t *testing.T) {
 
 	// Create test HTTP server that serves the registry API
 	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		servers, _, _ := registryService.ListServers(ctx, nil, "", 10)
+		servers, _, _ := registryService.ListServers(ctx, nil, nil, "", 10)
 
 		// Convert to response format
 		serverValues := make([]apiv0.ServerResponse, len(servers))
@@ -168,7 +172,7 @@ func TestImportService_RegistryPagination(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify servers were imported
-	importedServers, _, err := targetRegistryService.ListServers(context.Background(), nil, "", 10)
+	importedServers, _, err := targetRegistryService.ListServers(context.Background(), nil, nil, "", 10)
 	require.NoError(t, err)
 	assert.Len(t, importedServers, 2)
 
@@ -244,3 +248,86 @@ func TestImportService_ErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestImportService_VerifyMode(t *testing.T) {
+	signingKey := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	publicKey := signingKey.Public().(ed25519.PublicKey)
+
+	seedData := []*apiv0.ServerJSON{
+		{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/signed-server",
+			Description: "Signed server",
+			Version:     "1.0.0",
+		},
+		{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/unlisted-server",
+			Description: "Server published after the manifest",
+			Version:     "1.0.0",
+		},
+	}
+	digest, err := canonicaljson.Digest(seedData[0])
+	require.NoError(t, err)
+	entries := []snapshot.ManifestServer{{Name: "com.example/signed-server", Version: "1.0.0", SHA256: digest}}
+
+	tempDir := t.TempDir()
+	seedFile := filepath.Join(tempDir, "seed.json")
+	jsonData, err := json.Marshal(seedData)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(seedFile, jsonData, 0600))
+
+	writeManifest := func(t *testing.T, entries []snapshot.ManifestServer, key ed25519.PrivateKey) string {
+		t.Helper()
+		signed, err := snapshot.Sign(&snapshot.Manifest{Format: snapshot.ManifestFormat, Servers: entries}, key)
+		require.NoError(t, err)
+		data, err := json.Marshal(signed)
+		require.NoError(t, err)
+		file := filepath.Join(t.TempDir(), "manifest.json")
+		require.NoError(t, os.WriteFile(file, data, 0600))
+		return file
+	}
+
+	importWith := func(t *testing.T, manifestPath string) (service.RegistryService, error) {
+		t.Helper()
+		registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false})
+		importerService := importer.NewVerifyingService(registryService, publicKey, manifestPath)
+		return registryService, importerService.ImportFromPath(context.Background(), seedFile)
+	}
+
+	t.Run("imports servers matching the manifest and skips unlisted ones", func(t *testing.T) {
+		registryService, err := importWith(t, writeManifest(t, entries, signingKey))
+		require.NoError(t, err)
+
+		servers, _, err := registryService.ListServers(context.Background(), nil, nil, "", 10)
+		require.NoError(t, err)
+		require.Len(t, servers, 1)
+		assert.Equal(t, "com.example/signed-server", servers[0].Server.Name)
+	})
+
+	t.Run("refuses mismatched data", func(t *testing.T) {
+		tampered := []snapshot.ManifestServer{{Name: "com.example/signed-server", Version: "1.0.0", SHA256: strings.Repeat("0", 64)}}
+		registryService, err := importWith(t, writeManifest(t, tampered, signingKey))
+		assert.ErrorContains(t, err, "do not match the signed manifest")
+
+		servers, _, err := registryService.ListServers(context.Background(), nil, nil, "", 10)
+		require.NoError(t, err)
+		assert.Empty(t, servers)
+	})
+
+	t.Run("refuses an unsigned manifest", func(t *testing.T) {
+		_, err := importWith(t, writeManifest(t, entries, nil))
+		assert.ErrorIs(t, err, snapshot.ErrUnsigned)
+	})
+
+	t.Run("refuses a manifest signed with another key", func(t *testing.T) {
+		otherKey := ed25519.NewKeyFromSeed(append(make([]byte, ed25519.SeedSize-1), 1))
+		_, err := importWith(t, writeManifest(t, entries, otherKey))
+		assert.ErrorIs(t, err, snapshot.ErrInvalidSignature)
+	})
+
+	t.Run("requires a manifest location for local files", func(t *testing.T) {
+		_, err := importWith(t, "")
+		assert.ErrorContains(t, err, "manifest location is required")
+	})
+}
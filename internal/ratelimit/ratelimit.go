@@ -0,0 +1,140 @@
+// Package ratelimit limits the request rate of clients with token buckets
+package ratelimit
+
+import (
+	"container/list"
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the buckets that refilled completely are forgotten. A full bucket
+// behaves like a new one, so idle clients need not use memory.
+const sweepInterval = time.Minute
+
+// Limit is the request budget of each client: bursts of up to Burst requests, refilled at Rate
+// requests per second
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// PerMinute returns the limit of n requests per minute, in bursts of up to burst requests
+func PerMinute(n, burst int) Limit {
+	return Limit{Rate: float64(n) / 60, Burst: burst}
+}
+
+// Result is the outcome of a request against a client's budget
+type Result struct {
+	// Allowed is whether the request is within the budget
+	Allowed bool
+	// Limit is the size of the budget
+	Limit int
+	// Remaining is the number of requests left in the budget
+	Remaining int
+	// Reset is how long until the budget is full again
+	Reset time.Duration
+	// RetryAfter is how long until the next request is allowed, if this one was not
+	RetryAfter time.Duration
+}
+
+// Limiter keeps a token bucket per client key. It is safe for concurrent use.
+type Limiter struct {
+	limit   Limit
+	maxKeys int
+	now     func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*list.Element
+	recent    *list.List // Buckets, most recently used first
+	lastSweep time.Time
+}
+
+type bucket struct {
+	key     string
+	tokens  float64
+	updated time.Time
+}
+
+// NewLimiter creates a limiter giving each client key the same limit. It keeps the buckets of at
+// most maxKeys clients, forgetting the least recently seen ones first, or of any number of clients
+// if maxKeys is 0.
+func NewLimiter(limit Limit, maxKeys int) *Limiter {
+	return &Limiter{
+		limit:   limit,
+		maxKeys: maxKeys,
+		now:     time.Now,
+		buckets: make(map[string]*list.Element),
+		recent:  list.New(),
+	}
+}
+
+// Allow takes a request from the budget of a client key, if there is one left
+func (l *Limiter) Allow(key string) Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	var b *bucket
+	if elem, ok := l.buckets[key]; ok {
+		b = elem.Value.(*bucket)
+		l.recent.MoveToFront(elem)
+	} else {
+		if l.maxKeys > 0 && len(l.buckets) >= l.maxKeys {
+			l.forget(l.recent.Back())
+		}
+		b = &bucket{key: key, tokens: float64(l.limit.Burst), updated: now}
+		l.buckets[key] = l.recent.PushFront(b)
+	}
+	b.tokens = l.refill(b, now)
+	b.updated = now
+
+	result := Result{Limit: l.limit.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = l.timeToRefill(1 - b.tokens)
+	}
+	result.Remaining = int(math.Floor(b.tokens))
+	result.Reset = l.timeToRefill(float64(l.limit.Burst) - b.tokens)
+	return result
+}
+
+// refill returns the tokens of a bucket at a time
+func (l *Limiter) refill(b *bucket, now time.Time) float64 {
+	elapsed := now.Sub(b.updated).Seconds()
+	return math.Min(float64(l.limit.Burst), b.tokens+elapsed*l.limit.Rate)
+}
+
+// timeToRefill returns how long it takes to refill a number of tokens
+func (l *Limiter) timeToRefill(tokens float64) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+	if l.limit.Rate <= 0 {
+		return math.MaxInt64
+	}
+	return time.Duration(math.Ceil(tokens / l.limit.Rate * float64(time.Second)))
+}
+
+// sweep forgets the buckets that refilled completely
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for _, elem := range l.buckets {
+		if l.refill(elem.Value.(*bucket), now) >= float64(l.limit.Burst) {
+			l.forget(elem)
+		}
+	}
+}
+
+// forget removes a bucket, so that its client starts over with a full one
+func (l *Limiter) forget(elem *list.Element) {
+	delete(l.buckets, elem.Value.(*bucket).key)
+	l.recent.Remove(elem)
+}
@@ -0,0 +1,158 @@
+//nolint:testpackage
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newLimiter := func() *Limiter {
+		l := NewLimiter(PerMinute(60, 3), 0)
+		l.now = func() time.Time { return now }
+		return l
+	}
+
+	t.Run("bursts up to the limit", func(t *testing.T) {
+		l := newLimiter()
+		for i := 2; i >= 0; i-- {
+			result := l.Allow("client")
+			assert.True(t, result.Allowed)
+			assert.Equal(t, 3, result.Limit)
+			assert.Equal(t, i, result.Remaining)
+		}
+
+		result := l.Allow("client")
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+		assert.Equal(t, time.Second, result.RetryAfter)
+		assert.Equal(t, 3*time.Second, result.Reset)
+	})
+
+	t.Run("clients have separate budgets", func(t *testing.T) {
+		l := newLimiter()
+		for range 3 {
+			l.Allow("client")
+		}
+		assert.False(t, l.Allow("client").Allowed)
+		assert.True(t, l.Allow("other").Allowed)
+	})
+
+	t.Run("budgets refill over time", func(t *testing.T) {
+		l := newLimiter()
+		for range 3 {
+			l.Allow("client")
+		}
+		now = now.Add(1500 * time.Millisecond)
+		result := l.Allow("client")
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+		assert.False(t, l.Allow("client").Allowed)
+
+		now = now.Add(time.Hour)
+		result = l.Allow("client")
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 2, result.Remaining)
+	})
+
+	t.Run("idle clients are forgotten", func(t *testing.T) {
+		l := newLimiter()
+		l.Allow("idle")
+		now = now.Add(2 * sweepInterval)
+		l.Allow("active")
+		assert.Len(t, l.buckets, 1)
+		assert.Contains(t, l.buckets, "active")
+	})
+
+	t.Run("least recently seen clients are forgotten beyond the maximum", func(t *testing.T) {
+		l := NewLimiter(PerMinute(60, 3), 2)
+		l.now = func() time.Time { return now }
+		for range 3 {
+			l.Allow("first")
+		}
+		l.Allow("second")
+		l.Allow("first")
+		l.Allow("third")
+		assert.Len(t, l.buckets, 2)
+		assert.NotContains(t, l.buckets, "second")
+		assert.False(t, l.Allow("first").Allowed)
+	})
+}
+
+func TestClientIP(t *testing.T) {
+	trusted, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1", " "})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		expected     string
+	}{
+		{
+			name:       "direct client",
+			remoteAddr: "203.0.113.7:51234",
+			expected:   "203.0.113.7",
+		},
+		{
+			name:         "untrusted peer cannot spoof X-Forwarded-For",
+			remoteAddr:   "203.0.113.7:51234",
+			forwardedFor: []string{"198.51.100.1"},
+			expected:     "203.0.113.7",
+		},
+		{
+			name:         "trusted proxy",
+			remoteAddr:   "10.1.2.3:51234",
+			forwardedFor: []string{"198.51.100.1"},
+			expected:     "198.51.100.1",
+		},
+		{
+			name:         "chain of trusted proxies",
+			remoteAddr:   "10.1.2.3:51234",
+			forwardedFor: []string{"1.2.3.4, 198.51.100.1, 192.168.1.1", "10.9.9.9"},
+			expected:     "198.51.100.1",
+		},
+		{
+			name:         "malformed hop",
+			remoteAddr:   "10.1.2.3:51234",
+			forwardedFor: []string{"198.51.100.1, unknown"},
+			expected:     "10.1.2.3",
+		},
+		{
+			name:         "only trusted hops",
+			remoteAddr:   "10.1.2.3:51234",
+			forwardedFor: []string{"10.4.5.6"},
+			expected:     "10.4.5.6",
+		},
+		{
+			name:       "IPv6",
+			remoteAddr: "[2001:db8::1]:51234",
+			expected:   "2001:db8::1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ClientIP(tt.remoteAddr, tt.forwardedFor, trusted))
+		})
+	}
+
+	for ip, key := range map[string]string{
+		"203.0.113.7":          "203.0.113.7",
+		"::ffff:203.0.113.7":   "203.0.113.7",
+		"2001:db8:1:2:3:4:5:6": "2001:db8:1:2::/64",
+		"2001:db8:1:2:ffff::1": "2001:db8:1:2::/64",
+		"fe80::1%eth0":         "fe80::/64",
+		"not-an-ip":            "not-an-ip",
+	} {
+		assert.Equal(t, key, ClientKey(ip), ip)
+	}
+
+	_, err = ParseTrustedProxies([]string{"not-an-ip"})
+	assert.Error(t, err)
+	_, err = ParseTrustedProxies([]string{"10.0.0.0/99"})
+	assert.Error(t, err)
+}
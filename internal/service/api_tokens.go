@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// APITokenPrefix starts every API token, so that leaked tokens are easy to recognize and scan for
+const APITokenPrefix = "mcpr_"
+
+// CreateAPIToken creates an API token for an owner, returning its secret only this once.
+// Callers are responsible for checking that the owner holds the requested permissions.
+func (s *registryServiceImpl) CreateAPIToken(ctx context.Context, owner string, req *apiv0.APITokenCreateRequest) (*apiv0.APITokenCreateResponse, error) {
+	if err := s.validateAPITokenRequest(req, time.Now()); err != nil {
+		return nil, err
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("failed to generate API token ID: %w", err)
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate API token: %w", err)
+	}
+	token := APITokenPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	created, err := s.db.CreateAPIToken(ctx, nil, &apiv0.APIToken{
+		ID:          hex.EncodeToString(id),
+		Description: req.Description,
+		Owner:       owner,
+		Permissions: req.Permissions,
+		ExpiresAt:   req.ExpiresAt.UTC(),
+	}, hashAPIToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv0.APITokenCreateResponse{
+		APIToken: *created,
+		Token:    token,
+	}, nil
+}
+
+// validateAPITokenRequest checks the expiry and permission patterns of a new API token
+func (s *registryServiceImpl) validateAPITokenRequest(req *apiv0.APITokenCreateRequest, now time.Time) error {
+	if strings.TrimSpace(req.Description) == "" {
+		return fmt.Errorf("%w: description is required", database.ErrInvalidInput)
+	}
+	if len(req.Permissions) == 0 {
+		return fmt.Errorf("%w: at least one permission is required", database.ErrInvalidInput)
+	}
+	if !req.ExpiresAt.After(now) {
+		return fmt.Errorf("%w: expiry must be in the future", database.ErrInvalidInput)
+	}
+	if s.cfg.APITokenMaxLifetime > 0 && req.ExpiresAt.After(now.Add(s.cfg.APITokenMaxLifetime)) {
+		return fmt.Errorf("%w: expiry cannot be more than %s away", database.ErrInvalidInput, s.cfg.APITokenMaxLifetime)
+	}
+
+	for _, perm := range req.Permissions {
+		if perm.Action != "publish" && perm.Action != "edit" {
+			return fmt.Errorf("%w: unknown permission action %q", database.ErrInvalidInput, perm.Action)
+		}
+		if perm.Resource == "" || strings.Contains(strings.TrimSuffix(perm.Resource, "*"), "*") {
+			return fmt.Errorf("%w: resource pattern %q must be non-empty and can only contain '*' at the end", database.ErrInvalidInput, perm.Resource)
+		}
+	}
+	return nil
+}
+
+// ListAPITokens retrieve the API tokens of an owner, newest first
+func (s *registryServiceImpl) ListAPITokens(ctx context.Context, owner string) ([]*apiv0.APIToken, error) {
+	return s.db.ListAPITokens(ctx, nil, owner)
+}
+
+// RevokeAPIToken revokes an API token of an owner
+func (s *registryServiceImpl) RevokeAPIToken(ctx context.Context, id, owner string) error {
+	return s.db.DeleteAPIToken(ctx, nil, id, owner)
+}
+
+// ExchangeAPIToken retrieve the unexpired API token with a secret, recording that it was used
+func (s *registryServiceImpl) ExchangeAPIToken(ctx context.Context, secret string) (*apiv0.APIToken, error) {
+	if !strings.HasPrefix(secret, APITokenPrefix) {
+		return nil, fmt.Errorf("%w: not an API token", database.ErrInvalidInput)
+	}
+
+	token, err := s.db.GetAPITokenByHash(ctx, nil, hashAPIToken(secret))
+	if err != nil {
+		return nil, err
+	}
+	if !time.Now().Before(token.ExpiresAt) {
+		return nil, fmt.Errorf("%w: API token expired at %s", database.ErrInvalidInput, token.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+
+	if err := s.db.MarkAPITokenUsed(ctx, nil, token.ID); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// hashAPIToken hashes an API token for storage. Tokens are random and long, so a fast
+// unsalted hash is enough to keep them from being recovered from the database.
+func hashAPIToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
@@ -0,0 +1,97 @@
+//nolint:testpackage
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPITokens(t *testing.T) {
+	ctx := context.Background()
+	svc := NewRegistryService(database.NewTestDB(t), &config.Config{APITokenMaxLifetime: 30 * 24 * time.Hour})
+
+	request := func(expiresAt time.Time, perms ...apiv0.APITokenPermission) *apiv0.APITokenCreateRequest {
+		return &apiv0.APITokenCreateRequest{
+			Description: "CI publishing",
+			Permissions: perms,
+			ExpiresAt:   expiresAt,
+		}
+	}
+	publishPerm := apiv0.APITokenPermission{Action: "publish", Resource: "io.github.octocat/*"}
+
+	created, err := svc.CreateAPIToken(ctx, "github-at:octocat", request(time.Now().Add(24*time.Hour), publishPerm))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Token, APITokenPrefix))
+	assert.NotEmpty(t, created.ID)
+	assert.Equal(t, "github-at:octocat", created.Owner)
+	assert.Equal(t, []apiv0.APITokenPermission{publishPerm}, created.Permissions)
+	assert.False(t, created.CreatedAt.IsZero())
+	assert.Nil(t, created.LastUsedAt)
+
+	t.Run("invalid requests are rejected", func(t *testing.T) {
+		invalid := []*apiv0.APITokenCreateRequest{
+			request(time.Now().Add(-time.Minute), publishPerm),
+			request(time.Now().Add(60*24*time.Hour), publishPerm),
+			request(time.Now().Add(time.Hour)),
+			request(time.Now().Add(time.Hour), apiv0.APITokenPermission{Action: "delete", Resource: "io.github.octocat/*"}),
+			request(time.Now().Add(time.Hour), apiv0.APITokenPermission{Action: "publish", Resource: "io.github.*/server"}),
+			request(time.Now().Add(time.Hour), apiv0.APITokenPermission{Action: "publish", Resource: ""}),
+		}
+		for _, req := range invalid {
+			_, err := svc.CreateAPIToken(ctx, "github-at:octocat", req)
+			assert.ErrorIs(t, err, database.ErrInvalidInput)
+		}
+	})
+
+	t.Run("exchange returns the token and records its use", func(t *testing.T) {
+		token, err := svc.ExchangeAPIToken(ctx, created.Token)
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, token.ID)
+		assert.Equal(t, "github-at:octocat", token.Owner)
+
+		tokens, err := svc.ListAPITokens(ctx, "github-at:octocat")
+		require.NoError(t, err)
+		require.Len(t, tokens, 1)
+		require.NotNil(t, tokens[0].LastUsedAt)
+	})
+
+	t.Run("unknown tokens cannot be exchanged", func(t *testing.T) {
+		_, err := svc.ExchangeAPIToken(ctx, created.Token+"x")
+		require.ErrorIs(t, err, database.ErrNotFound)
+		_, err = svc.ExchangeAPIToken(ctx, "not-a-token")
+		require.ErrorIs(t, err, database.ErrInvalidInput)
+	})
+
+	t.Run("expired tokens cannot be exchanged", func(t *testing.T) {
+		expiring, err := svc.CreateAPIToken(ctx, "github-at:octocat", request(time.Now().Add(time.Second), publishPerm))
+		require.NoError(t, err)
+		time.Sleep(1100 * time.Millisecond)
+
+		_, err = svc.ExchangeAPIToken(ctx, expiring.Token)
+		require.ErrorIs(t, err, database.ErrInvalidInput)
+	})
+
+	t.Run("tokens are only listed and revoked by their owner", func(t *testing.T) {
+		tokens, err := svc.ListAPITokens(ctx, "github-at:someone")
+		require.NoError(t, err)
+		assert.Empty(t, tokens)
+
+		err = svc.RevokeAPIToken(ctx, created.ID, "github-at:someone")
+		require.ErrorIs(t, err, database.ErrNotFound)
+
+		require.NoError(t, svc.RevokeAPIToken(ctx, created.ID, "github-at:octocat"))
+		_, err = svc.ExchangeAPIToken(ctx, created.Token)
+		require.ErrorIs(t, err, database.ErrNotFound)
+
+		err = svc.RevokeAPIToken(ctx, created.ID, "github-at:octocat")
+		require.ErrorIs(t, err, database.ErrNotFound)
+	})
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// checkNamespaceNotBlocked checks that the namespace of a server is not blocked from publishing
+func (s *registryServiceImpl) checkNamespaceNotBlocked(ctx context.Context, tx pgx.Tx, serverName string) error {
+	namespace, _, _ := strings.Cut(serverName, "/")
+	blocked, err := s.db.IsNamespaceBlocked(ctx, tx, namespace)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return fmt.Errorf("%w: %s. Raise an issue at https://github.com/modelcontextprotocol/registry/ if you think this is a mistake", database.ErrNamespaceBlocked, namespace)
+	}
+	return nil
+}
+
+// ListBlockedNamespaces retrieves every namespace blocked from publishing
+func (s *registryServiceImpl) ListBlockedNamespaces(ctx context.Context) ([]*apiv0.BlockedNamespace, error) {
+	return s.db.ListBlockedNamespaces(ctx, nil)
+}
+
+// BlockNamespace blocks a namespace and its sub-namespaces from publishing
+func (s *registryServiceImpl) BlockNamespace(ctx context.Context, namespace, reason, blockedBy string) (*apiv0.BlockedNamespace, error) {
+	if namespace == "" || strings.Contains(namespace, "/") {
+		return nil, fmt.Errorf("%w: namespace must be non-empty and cannot contain '/'", database.ErrInvalidInput)
+	}
+	return s.db.BlockNamespace(ctx, nil, namespace, reason, blockedBy)
+}
+
+// UnblockNamespace allows a blocked namespace to publish again
+func (s *registryServiceImpl) UnblockNamespace(ctx context.Context, namespace string) error {
+	return s.db.UnblockNamespace(ctx, nil, namespace)
+}
+
+// RefreshBlockedNamespaces loads the namespaces blocked from publishing into a denylist
+func RefreshBlockedNamespaces(ctx context.Context, registry RegistryService, denylist *auth.NamespaceDenylist) error {
+	blocked, err := registry.ListBlockedNamespaces(ctx)
+	if err != nil {
+		return err
+	}
+
+	namespaces := make([]string, 0, len(blocked))
+	for _, namespace := range blocked {
+		namespaces = append(namespaces, namespace.Namespace)
+	}
+	denylist.Replace(namespaces)
+	return nil
+}
+
+// WatchBlockedNamespaces refreshes a denylist every interval until ctx is done, so that
+// namespaces blocked through any registry replica are picked up
+func WatchBlockedNamespaces(ctx context.Context, registry RegistryService, denylist *auth.NamespaceDenylist, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := RefreshBlockedNamespaces(ctx, registry, denylist); err != nil && ctx.Err() == nil {
+				log.Printf("Failed to refresh blocked namespaces: %v", err)
+			}
+		}
+	}
+}
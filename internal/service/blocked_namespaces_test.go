@@ -0,0 +1,83 @@
+//nolint:testpackage
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockedNamespaces(t *testing.T) {
+	ctx := context.Background()
+	svc := NewRegistryService(database.NewTestDB(t), &config.Config{})
+
+	serverJSON := func(name string) *apiv0.ServerJSON {
+		return &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Blocked namespace test server",
+			Version:     "1.0.0",
+		}
+	}
+
+	blocked, err := svc.BlockNamespace(ctx, "com.evil", "Spam", "github-at:admin")
+	require.NoError(t, err)
+	assert.Equal(t, "com.evil", blocked.Namespace)
+	assert.Equal(t, "Spam", blocked.Reason)
+	assert.Equal(t, "github-at:admin", blocked.BlockedBy)
+	assert.False(t, blocked.CreatedAt.IsZero())
+
+	t.Run("publishes to blocked namespaces and their sub-namespaces are refused", func(t *testing.T) {
+		_, err := svc.PublishServer(ctx, serverJSON("com.evil/server"), "github-at:spammer")
+		require.ErrorIs(t, err, database.ErrNamespaceBlocked)
+		assert.Contains(t, err.Error(), "com.evil")
+
+		_, err = svc.PublishServer(ctx, serverJSON("com.evil.team/server"), "github-at:spammer")
+		require.ErrorIs(t, err, database.ErrNamespaceBlocked)
+	})
+
+	t.Run("other namespaces are not affected", func(t *testing.T) {
+		_, err := svc.PublishServer(ctx, serverJSON("com.evilcorp/server"), "github-at:someone")
+		require.NoError(t, err)
+		_, err = svc.PublishServer(ctx, serverJSON("io.github.someone/server"), "github-at:someone")
+		require.NoError(t, err)
+	})
+
+	t.Run("imports are not affected", func(t *testing.T) {
+		_, err := svc.CreateServer(ctx, serverJSON("com.evil/imported"))
+		require.NoError(t, err)
+	})
+
+	t.Run("refresh loads the denylist", func(t *testing.T) {
+		_, err := svc.BlockNamespace(ctx, "io.github.spammer", "", "github-at:admin")
+		require.NoError(t, err)
+
+		denylist := &auth.NamespaceDenylist{}
+		denylist.Add("io.github.unblocked")
+		require.NoError(t, RefreshBlockedNamespaces(ctx, svc, denylist))
+		assert.Equal(t, []string{"com.evil", "io.github.spammer"}, denylist.Namespaces())
+	})
+
+	t.Run("unblocking allows publishing again", func(t *testing.T) {
+		require.NoError(t, svc.UnblockNamespace(ctx, "com.evil"))
+		_, err := svc.PublishServer(ctx, serverJSON("com.evil/server"), "github-at:reformed")
+		require.NoError(t, err)
+
+		err = svc.UnblockNamespace(ctx, "com.evil")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("namespaces cannot contain slashes", func(t *testing.T) {
+		_, err := svc.BlockNamespace(ctx, "com.evil/server", "", "github-at:admin")
+		require.ErrorIs(t, err, database.ErrInvalidInput)
+		_, err = svc.BlockNamespace(ctx, "", "", "github-at:admin")
+		require.ErrorIs(t, err, database.ErrInvalidInput)
+	})
+}
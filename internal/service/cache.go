@@ -0,0 +1,360 @@
+package service
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// listenRetryInterval is how long to wait before listening for server changes again after
+// the listening connection failed
+const listenRetryInterval = 5 * time.Second
+
+// CachingRegistryService caches the results of the most frequent reads of a RegistryService
+// in memory. Entries are dropped when a server changes: directly on writes through this
+// service, and through WatchInvalidations on writes by other registry replicas. Listings can
+// include any server, so they are dropped on every change. Entries also expire after a TTL,
+// which bounds staleness if a change notification is missed. Entries are loaded from the
+// primary database rather than read replicas, so that they include the change that dropped them.
+//
+// Cached results are shared between callers and must not be modified.
+type CachingRegistryService struct {
+	// RegistryService serves everything that is not cached
+	RegistryService
+
+	cache   *lruCache
+	metrics *telemetry.Metrics
+}
+
+// NewCachingRegistryService wraps a RegistryService with a read cache of at most maxEntries
+// results, each kept for at most ttl. metrics may be nil.
+func NewCachingRegistryService(inner RegistryService, maxEntries int, ttl time.Duration, metrics *telemetry.Metrics) *CachingRegistryService {
+	return &CachingRegistryService{
+		RegistryService: inner,
+		cache:           newLRUCache(maxEntries, ttl),
+		metrics:         metrics,
+	}
+}
+
+// Invalidate drops the cached results that may include a server, or every cached result if
+// serverName is empty
+func (s *CachingRegistryService) Invalidate(serverName string) {
+	s.cache.invalidate(serverName)
+	if s.metrics != nil {
+		s.metrics.CacheInvalidations.Add(context.Background(), 1)
+	}
+}
+
+// WatchInvalidations invalidates the cache whenever any registry replica changes a server,
+// until ctx is done. If the listening connection fails, the whole cache is invalidated once
+// listening again, as changes may have been missed in between.
+func (s *CachingRegistryService) WatchInvalidations(ctx context.Context, db database.Database) {
+	for {
+		err := db.ListenForServerChanges(ctx, s.Invalidate)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Listening for server changes failed, retrying in %s: %v", listenRetryInterval, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryInterval):
+		}
+	}
+}
+
+// ListServers retrieves a page of servers, from the cache if possible
+func (s *CachingRegistryService) ListServers(ctx context.Context, filter *database.ServerFilter, options *database.ServerListOptions, cursor string, limit int) ([]*apiv0.ServerResponse, string, error) {
+	type page struct {
+		servers    []*apiv0.ServerResponse
+		nextCursor string
+	}
+
+	key, err := cacheKey("ListServers", filter, options, cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	result, err := cached(ctx, s, "ListServers", "", key, func(ctx context.Context) (page, error) {
+		servers, nextCursor, err := s.RegistryService.ListServers(ctx, filter, options, cursor, limit)
+		return page{servers, nextCursor}, err
+	})
+	return result.servers, result.nextCursor, err
+}
+
+// CountServers counts the server versions matching a filter, from the cache if possible
+func (s *CachingRegistryService) CountServers(ctx context.Context, filter *database.ServerFilter) (int, error) {
+	key, err := cacheKey("CountServers", filter)
+	if err != nil {
+		return 0, err
+	}
+	return cached(ctx, s, "CountServers", "", key, func(ctx context.Context) (int, error) {
+		return s.RegistryService.CountServers(ctx, filter)
+	})
+}
+
+// GetServerByName retrieves the latest version of a server, from the cache if possible
+func (s *CachingRegistryService) GetServerByName(ctx context.Context, serverName string) (*apiv0.ServerResponse, error) {
+	key, err := cacheKey("GetServerByName", serverName)
+	if err != nil {
+		return nil, err
+	}
+	return cached(ctx, s, "GetServerByName", serverName, key, func(ctx context.Context) (*apiv0.ServerResponse, error) {
+		return s.RegistryService.GetServerByName(ctx, serverName)
+	})
+}
+
+// GetServerByNameAndVersion retrieves a specific version of a server, from the cache if possible
+func (s *CachingRegistryService) GetServerByNameAndVersion(ctx context.Context, serverName string, version string) (*apiv0.ServerResponse, error) {
+	key, err := cacheKey("GetServerByNameAndVersion", serverName, version)
+	if err != nil {
+		return nil, err
+	}
+	return cached(ctx, s, "GetServerByNameAndVersion", serverName, key, func(ctx context.Context) (*apiv0.ServerResponse, error) {
+		return s.RegistryService.GetServerByNameAndVersion(ctx, serverName, version)
+	})
+}
+
+// ListServerVersions retrieves a page of the versions of a server, from the cache if possible
+func (s *CachingRegistryService) ListServerVersions(ctx context.Context, serverName string, options *database.VersionListOptions, cursor string, limit int) ([]*apiv0.ServerResponse, string, error) {
+	type page struct {
+		servers    []*apiv0.ServerResponse
+		nextCursor string
+	}
+
+	key, err := cacheKey("ListServerVersions", serverName, options, cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	result, err := cached(ctx, s, "ListServerVersions", serverName, key, func(ctx context.Context) (page, error) {
+		servers, nextCursor, err := s.RegistryService.ListServerVersions(ctx, serverName, options, cursor, limit)
+		return page{servers, nextCursor}, err
+	})
+	return result.servers, result.nextCursor, err
+}
+
+// GetDistTags retrieves the dist-tags of a server, from the cache if possible
+func (s *CachingRegistryService) GetDistTags(ctx context.Context, serverName string) (map[string]string, error) {
+	key, err := cacheKey("GetDistTags", serverName)
+	if err != nil {
+		return nil, err
+	}
+	return cached(ctx, s, "GetDistTags", serverName, key, func(ctx context.Context) (map[string]string, error) {
+		return s.RegistryService.GetDistTags(ctx, serverName)
+	})
+}
+
+// GetServerByDistTag retrieves the version of a server a dist-tag points at, from the cache if possible
+func (s *CachingRegistryService) GetServerByDistTag(ctx context.Context, serverName, tag string) (*apiv0.ServerResponse, error) {
+	key, err := cacheKey("GetServerByDistTag", serverName, tag)
+	if err != nil {
+		return nil, err
+	}
+	return cached(ctx, s, "GetServerByDistTag", serverName, key, func(ctx context.Context) (*apiv0.ServerResponse, error) {
+		return s.RegistryService.GetServerByDistTag(ctx, serverName, tag)
+	})
+}
+
+// CreateServer creates a new server version and invalidates the cached results that may include it
+func (s *CachingRegistryService) CreateServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+	defer s.Invalidate(req.Name)
+	return s.RegistryService.CreateServer(ctx, req)
+}
+
+// PublishServer creates a new server version on behalf of a publisher and invalidates the cached results that may include it
+func (s *CachingRegistryService) PublishServer(ctx context.Context, req *apiv0.ServerJSON, publishedBy string) (*apiv0.ServerResponse, error) {
+	defer s.Invalidate(req.Name)
+	return s.RegistryService.PublishServer(ctx, req, publishedBy)
+}
+
+// UpdateServer updates a server version and invalidates the cached results that may include it
+func (s *CachingRegistryService) UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string, editedBy, expectedDigest string) (*apiv0.ServerResponse, error) {
+	defer s.Invalidate(serverName)
+	return s.RegistryService.UpdateServer(ctx, serverName, version, req, newStatus, editedBy, expectedDigest)
+}
+
+// SetServerVersionStatus changes the status of a server version and invalidates the cached results that may include it
+func (s *CachingRegistryService) SetServerVersionStatus(ctx context.Context, serverName, version string, req *apiv0.ServerStatusRequest) (*apiv0.ServerResponse, error) {
+	defer s.Invalidate(serverName)
+	return s.RegistryService.SetServerVersionStatus(ctx, serverName, version, req)
+}
+
+// SetAllVersionsStatus changes the status of every version of a server and invalidates the cached results that may include them
+func (s *CachingRegistryService) SetAllVersionsStatus(ctx context.Context, serverName string, status model.Status, reason string) ([]*apiv0.ServerResponse, error) {
+	defer s.Invalidate(serverName)
+	return s.RegistryService.SetAllVersionsStatus(ctx, serverName, status, reason)
+}
+
+// RestoreServerRevision restores an earlier revision of a server version and invalidates the cached results that may include it
+func (s *CachingRegistryService) RestoreServerRevision(ctx context.Context, serverName, version string, revision int, editedBy string) (*apiv0.ServerResponse, error) {
+	defer s.Invalidate(serverName)
+	return s.RegistryService.RestoreServerRevision(ctx, serverName, version, revision, editedBy)
+}
+
+// SetDistTag points a dist-tag of a server at one of its versions and invalidates the cached results of the server
+func (s *CachingRegistryService) SetDistTag(ctx context.Context, serverName, tag, version string) (map[string]string, error) {
+	defer s.Invalidate(serverName)
+	return s.RegistryService.SetDistTag(ctx, serverName, tag, version)
+}
+
+// DeleteDistTag removes a dist-tag from a server and invalidates the cached results of the server
+func (s *CachingRegistryService) DeleteDistTag(ctx context.Context, serverName, tag string) (map[string]string, error) {
+	defer s.Invalidate(serverName)
+	return s.RegistryService.DeleteDistTag(ctx, serverName, tag)
+}
+
+// cached returns the cached result of an operation, or loads and caches it. Results that may
+// include any server are cached with an empty serverName. Errors are not cached.
+func cached[T any](ctx context.Context, s *CachingRegistryService, operation, serverName, key string, load func(context.Context) (T, error)) (T, error) {
+	attrs := metric.WithAttributes(attribute.String("operation", operation))
+
+	if value, ok := s.cache.get(key); ok {
+		if s.metrics != nil {
+			s.metrics.CacheHits.Add(ctx, 1, attrs)
+		}
+		return value.(T), nil
+	}
+	if s.metrics != nil {
+		s.metrics.CacheMisses.Add(ctx, 1, attrs)
+	}
+
+	// A change committed while loading may not be in the result, so the result is only
+	// cached if the cache was not invalidated in the meantime. Results are loaded from the
+	// primary: a replica may not have replayed the write that just invalidated the cache yet,
+	// and its stale result would then be cached for the whole TTL.
+	generation := s.cache.currentGeneration()
+	value, err := load(database.WithPrimaryReads(ctx))
+	if err != nil {
+		return value, err
+	}
+	s.cache.put(key, serverName, value, generation)
+	return value, nil
+}
+
+// cacheKey identifies an operation and its arguments
+func cacheKey(operation string, args ...any) (string, error) {
+	encoded, err := json.Marshal(args)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cache key: %w", err)
+	}
+	return operation + string(encoded), nil
+}
+
+// lruCache is a size-bounded cache that evicts the least recently used entry when full, and
+// expires entries after a TTL
+type lruCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ttl        time.Duration
+	now        func() time.Time
+
+	// generation increments on every invalidation
+	generation uint64
+	entries    map[string]*list.Element
+	// order holds the entries from the most to the least recently used
+	order *list.List
+}
+
+type lruEntry struct {
+	key        string
+	serverName string
+	value      any
+	expiresAt  time.Time
+}
+
+func newLRUCache(maxEntries int, ttl time.Duration) *lruCache {
+	return &lruCache{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		now:        time.Now,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+func (c *lruCache) get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*lruEntry)
+	if c.now().After(entry.expiresAt) {
+		c.remove(element)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return entry.value, true
+}
+
+// put caches a value loaded at generation, unless the cache was invalidated since
+func (c *lruCache) put(key, serverName string, value any, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation || c.maxEntries <= 0 {
+		return
+	}
+
+	entry := &lruEntry{key: key, serverName: serverName, value: value, expiresAt: c.now().Add(c.ttl)}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *lruCache) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// invalidate removes the entries of a server and the entries that may include any server, or
+// every entry if serverName is empty
+func (c *lruCache) invalidate(serverName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	if serverName == "" {
+		c.entries = make(map[string]*list.Element)
+		c.order.Init()
+		return
+	}
+	for element := c.order.Front(); element != nil; {
+		next := element.Next()
+		if entry := element.Value.(*lruEntry); entry.serverName == serverName || entry.serverName == "" {
+			c.remove(element)
+		}
+		element = next
+	}
+}
+
+func (c *lruCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *lruCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*lruEntry).key)
+}
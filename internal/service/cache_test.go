@@ -0,0 +1,157 @@
+//nolint:testpackage
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingRegistryService serves fixed reads and counts how often it is called
+type countingRegistryService struct {
+	RegistryService
+
+	calls map[string]int
+}
+
+func (s *countingRegistryService) GetServerByName(_ context.Context, serverName string) (*apiv0.ServerResponse, error) {
+	s.calls["GetServerByName"]++
+	if serverName == "com.example/missing" {
+		return nil, database.ErrNotFound
+	}
+	return &apiv0.ServerResponse{Server: apiv0.ServerJSON{Name: serverName, Version: "1.0.0"}}, nil
+}
+
+func (s *countingRegistryService) CountServers(_ context.Context, _ *database.ServerFilter) (int, error) {
+	s.calls["CountServers"]++
+	return 2, nil
+}
+
+func (s *countingRegistryService) SetDistTag(_ context.Context, _, tag, version string) (map[string]string, error) {
+	return map[string]string{tag: version}, nil
+}
+
+func newTestCachingService(maxEntries int) (*CachingRegistryService, *countingRegistryService) {
+	inner := &countingRegistryService{calls: make(map[string]int)}
+	return NewCachingRegistryService(inner, maxEntries, time.Minute, nil), inner
+}
+
+func TestCachingRegistryService(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("repeated reads are served from the cache", func(t *testing.T) {
+		s, inner := newTestCachingService(10)
+		for range 3 {
+			server, err := s.GetServerByName(ctx, "com.example/a")
+			require.NoError(t, err)
+			assert.Equal(t, "com.example/a", server.Server.Name)
+		}
+		assert.Equal(t, 1, inner.calls["GetServerByName"])
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		s, inner := newTestCachingService(10)
+		for range 2 {
+			_, err := s.GetServerByName(ctx, "com.example/missing")
+			assert.ErrorIs(t, err, database.ErrNotFound)
+		}
+		assert.Equal(t, 2, inner.calls["GetServerByName"])
+	})
+
+	t.Run("writes invalidate the server and listings only", func(t *testing.T) {
+		s, inner := newTestCachingService(10)
+		_, _ = s.GetServerByName(ctx, "com.example/a")
+		_, _ = s.GetServerByName(ctx, "com.example/b")
+		_, _ = s.CountServers(ctx, &database.ServerFilter{})
+
+		_, err := s.SetDistTag(ctx, "com.example/a", "beta", "1.0.0")
+		require.NoError(t, err)
+
+		_, _ = s.GetServerByName(ctx, "com.example/a")
+		_, _ = s.GetServerByName(ctx, "com.example/b")
+		_, _ = s.CountServers(ctx, &database.ServerFilter{})
+		assert.Equal(t, 3, inner.calls["GetServerByName"])
+		assert.Equal(t, 2, inner.calls["CountServers"])
+	})
+
+	t.Run("invalidating without a server name purges everything", func(t *testing.T) {
+		s, _ := newTestCachingService(10)
+		_, _ = s.GetServerByName(ctx, "com.example/a")
+		_, _ = s.GetServerByName(ctx, "com.example/b")
+		require.Equal(t, 2, s.cache.len())
+
+		s.Invalidate("")
+		assert.Equal(t, 0, s.cache.len())
+	})
+
+	t.Run("least recently used entries are evicted", func(t *testing.T) {
+		s, inner := newTestCachingService(2)
+		_, _ = s.GetServerByName(ctx, "com.example/a")
+		_, _ = s.GetServerByName(ctx, "com.example/b")
+		_, _ = s.GetServerByName(ctx, "com.example/a")
+		_, _ = s.GetServerByName(ctx, "com.example/c")
+		require.Equal(t, 3, inner.calls["GetServerByName"])
+
+		// b was the least recently used entry when c was cached
+		_, _ = s.GetServerByName(ctx, "com.example/a")
+		assert.Equal(t, 3, inner.calls["GetServerByName"])
+		_, _ = s.GetServerByName(ctx, "com.example/b")
+		assert.Equal(t, 4, inner.calls["GetServerByName"])
+	})
+
+	t.Run("entries expire", func(t *testing.T) {
+		s, inner := newTestCachingService(10)
+		now := time.Now()
+		s.cache.now = func() time.Time { return now }
+
+		_, _ = s.GetServerByName(ctx, "com.example/a")
+		now = now.Add(30 * time.Second)
+		_, _ = s.GetServerByName(ctx, "com.example/a")
+		require.Equal(t, 1, inner.calls["GetServerByName"])
+
+		now = now.Add(time.Minute)
+		_, _ = s.GetServerByName(ctx, "com.example/a")
+		assert.Equal(t, 2, inner.calls["GetServerByName"])
+	})
+
+	t.Run("results loaded across an invalidation are not cached", func(t *testing.T) {
+		c := newLRUCache(10, time.Minute)
+		generation := c.currentGeneration()
+		c.invalidate("com.example/other")
+		c.put("key", "com.example/a", 1, generation)
+		_, ok := c.get("key")
+		assert.False(t, ok)
+	})
+}
+
+func TestCachingRegistryServiceReadReplicas(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewTestDBWithReplicas(t)
+	s := NewCachingRegistryService(NewRegistryService(db, &config.Config{}), 10, time.Minute, nil)
+
+	serverJSON := &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/replicated-server",
+		Description: "A server",
+		Version:     "1.0.0",
+	}
+	_, err := s.PublishServer(ctx, serverJSON, "github-at:someone")
+	require.NoError(t, err)
+
+	// A replica may not have replayed the write that invalidated the cache yet, so cache
+	// fills read from the primary
+	replicaAcquires := db.PoolStats()[1].AcquireCount
+	server, err := s.GetServerByName(ctx, serverJSON.Name)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", server.Server.Version)
+	_, err = s.GetServerByNameAndVersion(ctx, serverJSON.Name, "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, replicaAcquires, db.PoolStats()[1].AcquireCount)
+}
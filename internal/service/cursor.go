@@ -0,0 +1,141 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// serverCursorFormat is the version of the server listing cursor format. Cursors of other
+// versions are rejected, so the format can change without misreading old cursors.
+const serverCursorFormat = 1
+
+// serverCursor is the payload of an opaque server or version listing cursor: the sort key of
+// the last row of a page, and a fingerprint of the filters and ordering the page was listed with
+type serverCursor struct {
+	Format      int        `json:"v"`
+	Fingerprint string     `json:"f"`
+	ServerName  string     `json:"n"`
+	Version     string     `json:"ver"`
+	Timestamp   *time.Time `json:"t,omitempty"`
+}
+
+// serverListFingerprint identifies the filters and ordering of a server listing, so a cursor
+// cannot be used to continue a listing with different ones
+func serverListFingerprint(filter *database.ServerFilter, options *database.ServerListOptions) string {
+	if filter == nil {
+		filter = &database.ServerFilter{}
+	}
+	if options == nil {
+		options = &database.ServerListOptions{}
+	}
+	if options.OrderBy == "" {
+		options = &database.ServerListOptions{OrderBy: database.ServerOrderByName, Descending: options.Descending}
+	}
+
+	return listFingerprint(struct {
+		Filter  *database.ServerFilter
+		Options *database.ServerListOptions
+	}{filter, options})
+}
+
+// versionListFingerprint identifies the server and ordering of a version listing, so a cursor
+// cannot be used to continue a listing of another server or in another order
+func versionListFingerprint(serverName string, options *database.VersionListOptions) string {
+	if options == nil {
+		options = &database.VersionListOptions{}
+	}
+	if options.OrderBy == "" {
+		options = &database.VersionListOptions{OrderBy: database.VersionOrderByVersion, Descending: options.Descending}
+	}
+
+	return listFingerprint(struct {
+		ServerName string
+		Options    *database.VersionListOptions
+	}{serverName, options})
+}
+
+// listFingerprint returns a short hash of the JSON encoding of a listing's parameters
+func listFingerprint(parameters any) string {
+	// Marshalling plain structs of strings, booleans and times cannot fail
+	data, _ := json.Marshal(parameters)
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:16])
+}
+
+// encodeServerCursor encodes a listing position as an opaque cursor: the base64url-encoded
+// payload, followed by "." and its HMAC-SHA256 signature when a signing key is configured
+func (s *registryServiceImpl) encodeServerCursor(position *database.ServerListPosition, fingerprint string) string {
+	payload := serverCursor{
+		Format:      serverCursorFormat,
+		Fingerprint: fingerprint,
+		ServerName:  position.ServerName,
+		Version:     position.Version,
+	}
+	if !position.Timestamp.IsZero() {
+		payload.Timestamp = &position.Timestamp
+	}
+
+	// Marshalling a struct of strings and a time cannot fail
+	data, _ := json.Marshal(payload)
+	cursor := base64.RawURLEncoding.EncodeToString(data)
+	if signature := s.signCursor(data); signature != nil {
+		cursor += "." + base64.RawURLEncoding.EncodeToString(signature)
+	}
+	return cursor
+}
+
+// decodeServerCursor verifies a cursor and returns the position it continues after
+func (s *registryServiceImpl) decodeServerCursor(cursor, fingerprint string) (*database.ServerListPosition, error) {
+	encodedPayload, encodedSignature, signed := strings.Cut(cursor, ".")
+	data, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", database.ErrInvalidInput)
+	}
+
+	if expected := s.signCursor(data); expected != nil {
+		signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+		if !signed || err != nil || !hmac.Equal(signature, expected) {
+			return nil, fmt.Errorf("%w: invalid cursor signature", database.ErrInvalidInput)
+		}
+	}
+
+	var payload serverCursor
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", database.ErrInvalidInput)
+	}
+	if payload.Format != serverCursorFormat {
+		return nil, fmt.Errorf("%w: unsupported cursor version %d", database.ErrInvalidInput, payload.Format)
+	}
+	if payload.Fingerprint != fingerprint {
+		return nil, fmt.Errorf("%w: cursor was issued for different filters or ordering", database.ErrInvalidInput)
+	}
+	if payload.ServerName == "" || payload.Version == "" {
+		return nil, fmt.Errorf("%w: malformed cursor", database.ErrInvalidInput)
+	}
+
+	position := &database.ServerListPosition{
+		ServerName: payload.ServerName,
+		Version:    payload.Version,
+	}
+	if payload.Timestamp != nil {
+		position.Timestamp = *payload.Timestamp
+	}
+	return position, nil
+}
+
+// signCursor returns the HMAC-SHA256 signature of a cursor payload, or nil when cursors are not signed
+func (s *registryServiceImpl) signCursor(data []byte) []byte {
+	if s.cfg.CursorSigningKey == "" {
+		return nil
+	}
+	mac := hmac.New(sha256.New, []byte(s.cfg.CursorSigningKey))
+	mac.Write(data)
+	return mac.Sum(nil)
+}
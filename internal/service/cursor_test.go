@@ -0,0 +1,113 @@
+//nolint:testpackage
+package service
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerCursorRoundTrip(t *testing.T) {
+	position := &database.ServerListPosition{
+		ServerName: "com.example/server",
+		Version:    "1.0.0",
+		Timestamp:  time.Date(2025, 8, 7, 13, 15, 4, 280123000, time.UTC),
+	}
+	fingerprint := serverListFingerprint(nil, &database.ServerListOptions{OrderBy: database.ServerOrderByUpdatedAt})
+
+	for _, key := range []string{"", "test-cursor-key"} {
+		s := &registryServiceImpl{cfg: &config.Config{CursorSigningKey: key}}
+
+		cursor := s.encodeServerCursor(position, fingerprint)
+		decoded, err := s.decodeServerCursor(cursor, fingerprint)
+		require.NoError(t, err)
+		assert.Equal(t, position.ServerName, decoded.ServerName)
+		assert.Equal(t, position.Version, decoded.Version)
+		assert.True(t, position.Timestamp.Equal(decoded.Timestamp))
+	}
+}
+
+func TestServerCursorRejections(t *testing.T) {
+	signed := &registryServiceImpl{cfg: &config.Config{CursorSigningKey: "test-cursor-key"}}
+	unsigned := &registryServiceImpl{cfg: &config.Config{}}
+
+	position := &database.ServerListPosition{ServerName: "com.example/server", Version: "1.0.0"}
+	fingerprint := serverListFingerprint(&database.ServerFilter{SubstringName: stringPtr("server")}, nil)
+
+	tests := []struct {
+		name        string
+		service     *registryServiceImpl
+		cursor      string
+		fingerprint string
+	}{
+		{
+			name:        "raw name:version cursor",
+			service:     unsigned,
+			cursor:      "com.example/server:1.0.0",
+			fingerprint: fingerprint,
+		},
+		{
+			name:        "different filters",
+			service:     unsigned,
+			cursor:      unsigned.encodeServerCursor(position, fingerprint),
+			fingerprint: serverListFingerprint(&database.ServerFilter{SubstringName: stringPtr("other")}, nil),
+		},
+		{
+			name:        "different ordering",
+			service:     unsigned,
+			cursor:      unsigned.encodeServerCursor(position, fingerprint),
+			fingerprint: serverListFingerprint(&database.ServerFilter{SubstringName: stringPtr("server")}, &database.ServerListOptions{Descending: true}),
+		},
+		{
+			name:        "unsigned cursor when signing is enabled",
+			service:     signed,
+			cursor:      unsigned.encodeServerCursor(position, fingerprint),
+			fingerprint: fingerprint,
+		},
+		{
+			name:        "unsupported format version",
+			service:     unsigned,
+			cursor:      base64.RawURLEncoding.EncodeToString([]byte(`{"v":2,"f":"` + fingerprint + `","n":"com.example/server","ver":"1.0.0"}`)),
+			fingerprint: fingerprint,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.service.decodeServerCursor(tt.cursor, tt.fingerprint)
+			assert.ErrorIs(t, err, database.ErrInvalidInput)
+		})
+	}
+
+	t.Run("version cursor from another ordering", func(t *testing.T) {
+		versionPosition := &database.ServerListPosition{ServerName: "com.example/server", Version: "1.0.0"}
+		cursor := unsigned.encodeServerCursor(versionPosition, versionListFingerprint("com.example/server", nil))
+		_, err := unsigned.decodeServerCursor(cursor, versionListFingerprint("com.example/server",
+			&database.VersionListOptions{OrderBy: database.VersionOrderByPublishedAt, Descending: true}))
+		assert.ErrorIs(t, err, database.ErrInvalidInput)
+	})
+
+	t.Run("version cursor from another server", func(t *testing.T) {
+		versionPosition := &database.ServerListPosition{ServerName: "com.example/server", Version: "1.0.0"}
+		cursor := unsigned.encodeServerCursor(versionPosition, versionListFingerprint("com.example/server", nil))
+		_, err := unsigned.decodeServerCursor(cursor, versionListFingerprint("com.example/other", nil))
+		assert.ErrorIs(t, err, database.ErrInvalidInput)
+	})
+
+	t.Run("default ordering has the same fingerprint as name ordering", func(t *testing.T) {
+		assert.Equal(t,
+			serverListFingerprint(nil, nil),
+			serverListFingerprint(&database.ServerFilter{}, &database.ServerListOptions{OrderBy: database.ServerOrderByName}))
+	})
+
+	t.Run("default version ordering has the same fingerprint as version ordering", func(t *testing.T) {
+		assert.Equal(t,
+			versionListFingerprint("com.example/server", nil),
+			versionListFingerprint("com.example/server", &database.VersionListOptions{OrderBy: database.VersionOrderByVersion}))
+	})
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Kinds of change reported by DiffServerJSON
+const (
+	DiffChangeAdded   = "added"
+	DiffChangeRemoved = "removed"
+	DiffChangeChanged = "changed"
+)
+
+// arrayElementKeys are the fields, in order of preference, used to match array elements
+// (packages, remotes, arguments, environment variables, headers, ...) between two versions
+var arrayElementKeys = []string{"identifier", "name", "url"}
+
+// plainPathSegmentRe matches object keys that can be written as ".key" in a field path;
+// other keys (e.g. reverse-DNS _meta namespaces) are quoted as ["key"]
+var plainPathSegmentRe = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// DiffServerVersions compares the server.json of two versions of a server.
+// Either version may also be a dist-tag such as "latest".
+func (s *registryServiceImpl) DiffServerVersions(ctx context.Context, serverName, fromVersion, toVersion string) (*apiv0.ServerDiffResponse, error) {
+	from, err := s.getServerByVersionOrDistTag(ctx, serverName, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.getServerByVersionOrDistTag(ctx, serverName, toVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := DiffServerJSON(&from.Server, &to.Server)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv0.ServerDiffResponse{
+		From:    from.Server.Version,
+		To:      to.Server.Version,
+		Changes: changes,
+		Text:    RenderServerDiff(changes),
+	}, nil
+}
+
+// getServerByVersionOrDistTag resolves a version, falling back to a dist-tag of the same name
+func (s *registryServiceImpl) getServerByVersionOrDistTag(ctx context.Context, serverName, version string) (*apiv0.ServerResponse, error) {
+	server, err := s.db.GetServerByNameAndVersion(ctx, nil, serverName, version)
+	if errors.Is(err, database.ErrNotFound) {
+		return s.GetServerByDistTag(ctx, serverName, version)
+	}
+	return server, err
+}
+
+// DiffServerJSON returns the field-level changes between two server.json documents
+func DiffServerJSON(from, to *apiv0.ServerJSON) ([]apiv0.ServerDiffChange, error) {
+	fromValue, err := toGenericJSON(from)
+	if err != nil {
+		return nil, err
+	}
+	toValue, err := toGenericJSON(to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []apiv0.ServerDiffChange{}
+	diffValues("", fromValue, toValue, &changes)
+	return changes, nil
+}
+
+// RenderServerDiff renders changes as text, one per line:
+// "+ path: value" for additions, "- path: value" for removals and "~ path: old -> new" for changes
+func RenderServerDiff(changes []apiv0.ServerDiffChange) string {
+	var b strings.Builder
+	for _, change := range changes {
+		switch change.Type {
+		case DiffChangeAdded:
+			fmt.Fprintf(&b, "+ %s: %s\n", change.Path, renderDiffValue(change.To))
+		case DiffChangeRemoved:
+			fmt.Fprintf(&b, "- %s: %s\n", change.Path, renderDiffValue(change.From))
+		default:
+			fmt.Fprintf(&b, "~ %s: %s -> %s\n", change.Path, renderDiffValue(change.From), renderDiffValue(change.To))
+		}
+	}
+	return b.String()
+}
+
+// toGenericJSON converts a value to its JSON data model (maps, slices and scalars)
+// so the diff sees exactly what the API returns
+func toGenericJSON(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal server JSON: %w", err)
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	}
+	return generic, nil
+}
+
+func diffValues(path string, from, to any, changes *[]apiv0.ServerDiffChange) {
+	switch fromValue := from.(type) {
+	case map[string]any:
+		if toValue, ok := to.(map[string]any); ok {
+			diffObjects(path, fromValue, toValue, changes)
+			return
+		}
+	case []any:
+		if toValue, ok := to.([]any); ok {
+			diffArrays(path, fromValue, toValue, changes)
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		*changes = append(*changes, apiv0.ServerDiffChange{Path: path, Type: DiffChangeChanged, From: from, To: to})
+	}
+}
+
+func diffObjects(path string, from, to map[string]any, changes *[]apiv0.ServerDiffChange) {
+	keys := make([]string, 0, len(from)+len(to))
+	for key := range from {
+		keys = append(keys, key)
+	}
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fieldPath := joinFieldPath(path, key)
+		fromValue, inFrom := from[key]
+		toValue, inTo := to[key]
+		switch {
+		case !inTo:
+			*changes = append(*changes, apiv0.ServerDiffChange{Path: fieldPath, Type: DiffChangeRemoved, From: fromValue})
+		case !inFrom:
+			*changes = append(*changes, apiv0.ServerDiffChange{Path: fieldPath, Type: DiffChangeAdded, To: toValue})
+		default:
+			diffValues(fieldPath, fromValue, toValue, changes)
+		}
+	}
+}
+
+// diffArrays matches elements by their identifier, name or url when every element on both
+// sides has a unique one, so reordering or inserting an element does not show up as changes
+// to all following elements. Other arrays are compared by index.
+func diffArrays(path string, from, to []any, changes *[]apiv0.ServerDiffChange) {
+	keyField := arrayElementKeyField(from, to)
+	if keyField == "" {
+		for i := 0; i < len(from) || i < len(to); i++ {
+			elementPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(to):
+				*changes = append(*changes, apiv0.ServerDiffChange{Path: elementPath, Type: DiffChangeRemoved, From: from[i]})
+			case i >= len(from):
+				*changes = append(*changes, apiv0.ServerDiffChange{Path: elementPath, Type: DiffChangeAdded, To: to[i]})
+			default:
+				diffValues(elementPath, from[i], to[i], changes)
+			}
+		}
+		return
+	}
+
+	toByKey := make(map[string]any, len(to))
+	for _, element := range to {
+		toByKey[elementKey(element, keyField)] = element
+	}
+	fromKeys := make(map[string]bool, len(from))
+
+	for _, fromElement := range from {
+		key := elementKey(fromElement, keyField)
+		fromKeys[key] = true
+		elementPath := fmt.Sprintf("%s[%s=%s]", path, keyField, key)
+		if toElement, ok := toByKey[key]; ok {
+			diffValues(elementPath, fromElement, toElement, changes)
+		} else {
+			*changes = append(*changes, apiv0.ServerDiffChange{Path: elementPath, Type: DiffChangeRemoved, From: fromElement})
+		}
+	}
+
+	for _, toElement := range to {
+		key := elementKey(toElement, keyField)
+		if !fromKeys[key] {
+			elementPath := fmt.Sprintf("%s[%s=%s]", path, keyField, key)
+			*changes = append(*changes, apiv0.ServerDiffChange{Path: elementPath, Type: DiffChangeAdded, To: toElement})
+		}
+	}
+}
+
+// arrayElementKeyField returns the first of arrayElementKeys that is a non-empty string,
+// unique within each array, on every element of both arrays
+func arrayElementKeyField(arrays ...[]any) string {
+	for _, field := range arrayElementKeys {
+		if isElementKeyField(field, arrays...) {
+			return field
+		}
+	}
+	return ""
+}
+
+func isElementKeyField(field string, arrays ...[]any) bool {
+	for _, elements := range arrays {
+		seen := make(map[string]bool, len(elements))
+		for _, element := range elements {
+			key := elementKey(element, field)
+			if key == "" || seen[key] {
+				return false
+			}
+			seen[key] = true
+		}
+	}
+	return true
+}
+
+func elementKey(element any, field string) string {
+	object, ok := element.(map[string]any)
+	if !ok {
+		return ""
+	}
+	key, _ := object[field].(string)
+	return key
+}
+
+func joinFieldPath(path, key string) string {
+	if !plainPathSegmentRe.MatchString(key) {
+		return path + "[" + strconv.Quote(key) + "]"
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func renderDiffValue(v any) string {
+	var b strings.Builder
+	encoder := json.NewEncoder(&b)
+	// Keep URLs such as "https://example.com/?a=1&b=2" readable
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
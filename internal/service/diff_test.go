@@ -0,0 +1,123 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffServerJSON(t *testing.T) {
+	envVar := func(name string, isSecret bool) model.KeyValueInput {
+		return model.KeyValueInput{
+			Name:               name,
+			InputWithVariables: model.InputWithVariables{Input: model.Input{IsSecret: isSecret}},
+		}
+	}
+
+	from := &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/diff-server",
+		Description: "Diff test server",
+		Version:     "1.0.0",
+		Packages: []model.Package{
+			{
+				RegistryType:         model.RegistryTypeNPM,
+				Identifier:           "@example/server",
+				Version:              "1.0.0",
+				Transport:            model.Transport{Type: "stdio"},
+				EnvironmentVariables: []model.KeyValueInput{envVar("API_KEY", false), envVar("REGION", false)},
+				PackageArguments: []model.Argument{
+					{Type: model.ArgumentTypePositional, ValueHint: "path"},
+				},
+			},
+			{
+				RegistryType: model.RegistryTypeOCI,
+				Identifier:   "ghcr.io/example/server:1.0.0",
+				Transport:    model.Transport{Type: "stdio"},
+			},
+		},
+		Remotes: []model.Transport{
+			{Type: "sse", URL: "https://example.com/sse"},
+		},
+		Meta: &apiv0.ServerMeta{
+			PublisherProvided: map[string]interface{}{"tool": "publisher-cli"},
+		},
+	}
+
+	to := &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/diff-server",
+		Description: "Diff test server",
+		Version:     "1.1.0",
+		Packages: []model.Package{
+			{
+				RegistryType: model.RegistryTypeNPM,
+				Identifier:   "@example/server",
+				Version:      "1.1.0",
+				Transport:    model.Transport{Type: "stdio"},
+				// Reordered, with API_KEY now secret and a new variable
+				EnvironmentVariables: []model.KeyValueInput{envVar("DEBUG", false), envVar("REGION", false), envVar("API_KEY", true)},
+				PackageArguments: []model.Argument{
+					{Type: model.ArgumentTypePositional, ValueHint: "directory"},
+				},
+			},
+		},
+		Remotes: []model.Transport{
+			{Type: "streamable-http", URL: "https://example.com/mcp"},
+		},
+		Meta: &apiv0.ServerMeta{
+			PublisherProvided: map[string]interface{}{"tool": "publisher-cli", "toolVersion": "1.2.0"},
+		},
+	}
+
+	changes, err := service.DiffServerJSON(from, to)
+	require.NoError(t, err)
+
+	assert.Equal(t, []apiv0.ServerDiffChange{
+		{Path: `_meta["io.modelcontextprotocol.registry/publisher-provided"].toolVersion`, Type: service.DiffChangeAdded, To: "1.2.0"},
+		{Path: "packages[identifier=@example/server].environmentVariables[name=API_KEY].isSecret", Type: service.DiffChangeAdded, To: true},
+		{Path: "packages[identifier=@example/server].environmentVariables[name=DEBUG]", Type: service.DiffChangeAdded, To: map[string]any{"name": "DEBUG"}},
+		{Path: "packages[identifier=@example/server].packageArguments[0].valueHint", Type: service.DiffChangeChanged, From: "path", To: "directory"},
+		{Path: "packages[identifier=@example/server].version", Type: service.DiffChangeChanged, From: "1.0.0", To: "1.1.0"},
+		{Path: "packages[identifier=ghcr.io/example/server:1.0.0]", Type: service.DiffChangeRemoved, From: map[string]any{
+			"registryType": "oci",
+			"identifier":   "ghcr.io/example/server:1.0.0",
+			"transport":    map[string]any{"type": "stdio"},
+		}},
+		{Path: "remotes[url=https://example.com/sse]", Type: service.DiffChangeRemoved, From: map[string]any{"type": "sse", "url": "https://example.com/sse"}},
+		{Path: "remotes[url=https://example.com/mcp]", Type: service.DiffChangeAdded, To: map[string]any{"type": "streamable-http", "url": "https://example.com/mcp"}},
+		{Path: "version", Type: service.DiffChangeChanged, From: "1.0.0", To: "1.1.0"},
+	}, changes)
+}
+
+func TestDiffServerJSONIdentical(t *testing.T) {
+	server := &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/diff-server",
+		Description: "Diff test server",
+		Version:     "1.0.0",
+	}
+
+	changes, err := service.DiffServerJSON(server, server)
+	require.NoError(t, err)
+	assert.NotNil(t, changes)
+	assert.Empty(t, changes)
+	assert.Empty(t, service.RenderServerDiff(changes))
+}
+
+func TestRenderServerDiff(t *testing.T) {
+	text := service.RenderServerDiff([]apiv0.ServerDiffChange{
+		{Path: "remotes[url=https://example.com/mcp?a=1&b=2]", Type: service.DiffChangeAdded, To: map[string]any{"url": "https://example.com/mcp?a=1&b=2"}},
+		{Path: "title", Type: service.DiffChangeRemoved, From: "Old Title"},
+		{Path: "version", Type: service.DiffChangeChanged, From: "1.0.0", To: "1.1.0"},
+	})
+
+	assert.Equal(t, `+ remotes[url=https://example.com/mcp?a=1&b=2]: {"url":"https://example.com/mcp?a=1&b=2"}
+- title: "Old Title"
+~ version: "1.0.0" -> "1.1.0"
+`, text)
+}
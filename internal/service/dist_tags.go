@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// LatestDistTag is the registry-managed dist-tag that always points at the latest version
+const LatestDistTag = "latest"
+
+// distTagPattern restricts dist-tag names to short identifiers that cannot be confused with paths
+var distTagPattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9._-]{0,99}$`)
+
+// ValidateDistTag checks that a dist-tag name is well-formed and cannot be mistaken for a version
+func ValidateDistTag(tag string) error {
+	if !distTagPattern.MatchString(tag) {
+		return fmt.Errorf("%w: dist-tag %q must start with a letter and contain only letters, digits, '.', '_' or '-' (max 100 characters)", database.ErrInvalidInput, tag)
+	}
+	// Like npm, refuse tags that look like versions so /versions/{version} lookups stay unambiguous
+	if IsSemanticVersion(tag) {
+		return fmt.Errorf("%w: dist-tag %q must not be a version string", database.ErrInvalidInput, tag)
+	}
+	return nil
+}
+
+// GetDistTags retrieves all dist-tags of a server, including the registry-managed "latest" tag
+func (s *registryServiceImpl) GetDistTags(ctx context.Context, serverName string) (map[string]string, error) {
+	return s.getDistTags(ctx, nil, serverName)
+}
+
+// getDistTags merges the stored dist-tags with the "latest" tag derived from is_latest
+func (s *registryServiceImpl) getDistTags(ctx context.Context, tx pgx.Tx, serverName string) (map[string]string, error) {
+	latest, err := s.db.GetCurrentLatestVersion(ctx, tx, serverName)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := s.db.GetDistTags(ctx, tx, serverName)
+	if err != nil {
+		return nil, err
+	}
+	tags[LatestDistTag] = latest.Server.Version
+
+	return tags, nil
+}
+
+// GetServerByDistTag retrieves the version of a server that a dist-tag points at
+func (s *registryServiceImpl) GetServerByDistTag(ctx context.Context, serverName, tag string) (*apiv0.ServerResponse, error) {
+	if tag == LatestDistTag {
+		return s.db.GetServerByName(ctx, nil, serverName)
+	}
+
+	tags, err := s.db.GetDistTags(ctx, nil, serverName)
+	if err != nil {
+		return nil, err
+	}
+
+	version, ok := tags[tag]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+
+	return s.db.GetServerByNameAndVersion(ctx, nil, serverName, version)
+}
+
+// SetDistTag points a dist-tag of a server at one of its versions
+// Setting the "latest" tag moves the registry's latest marker to the given version
+func (s *registryServiceImpl) SetDistTag(ctx context.Context, serverName, tag, version string) (map[string]string, error) {
+	if err := ValidateDistTag(tag); err != nil {
+		return nil, err
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (map[string]string, error) {
+		// Serialize with publishes, which also move the latest marker
+		if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+
+		target, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
+		if err != nil {
+			return nil, err
+		}
+		if target.Meta.Official != nil && target.Meta.Official.Status == model.StatusDeleted {
+			return nil, fmt.Errorf("%w: cannot tag deleted version %s", database.ErrInvalidInput, version)
+		}
+
+		if tag == LatestDistTag {
+			if err := s.db.UnmarkAsLatest(ctx, tx, serverName); err != nil {
+				return nil, err
+			}
+			if err := s.db.MarkAsLatest(ctx, tx, serverName, version); err != nil {
+				return nil, err
+			}
+		} else if err := s.db.SetDistTag(ctx, tx, serverName, tag, version); err != nil {
+			return nil, err
+		}
+
+		return s.getDistTags(ctx, tx, serverName)
+	})
+}
+
+// DeleteDistTag removes a dist-tag from a server
+// The "latest" tag is managed by the registry and cannot be removed
+func (s *registryServiceImpl) DeleteDistTag(ctx context.Context, serverName, tag string) (map[string]string, error) {
+	if tag == LatestDistTag {
+		return nil, fmt.Errorf("%w: the %q dist-tag cannot be removed", database.ErrInvalidInput, LatestDistTag)
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (map[string]string, error) {
+		if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+
+		if err := s.db.DeleteDistTag(ctx, tx, serverName, tag); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, fmt.Errorf("%w: dist-tag %q", database.ErrNotFound, tag)
+			}
+			return nil, err
+		}
+
+		return s.getDistTags(ctx, tx, serverName)
+	})
+}
@@ -0,0 +1,169 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestValidateDistTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		wantErr bool
+	}{
+		{"simple", "next", false},
+		{"with dots and dashes", "release-1.x_candidate", false},
+		{"latest", "latest", false},
+		{"empty", "", true},
+		{"starts with digit", "1beta", true},
+		{"semver with v prefix", "v1.0.0", true},
+		{"contains slash", "beta/2", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.ValidateDistTag(tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateDistTag(%q) error = %v, wantErr %v", tt.tag, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPrereleaseDoesNotBecomeLatest(t *testing.T) {
+	ctx := context.Background()
+	registry := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false})
+
+	serverName := "com.example/prerelease-server"
+	for _, version := range []string{"1.0.0-beta.1", "1.0.0", "1.9.0", "2.0.0-beta.1"} {
+		_, err := registry.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        serverName,
+			Description: "Prerelease test server",
+			Version:     version,
+		})
+		require.NoError(t, err, "failed to create version %s", version)
+	}
+
+	latest, err := registry.GetServerByName(ctx, serverName)
+	require.NoError(t, err)
+	assert.Equal(t, "1.9.0", latest.Server.Version, "a prerelease must not displace the latest stable version")
+
+	// A prerelease is latest while no stable version exists
+	_, err = registry.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/prerelease-only-server",
+		Description: "Prerelease only server",
+		Version:     "0.1.0-alpha",
+	})
+	require.NoError(t, err)
+
+	latest, err = registry.GetServerByName(ctx, "com.example/prerelease-only-server")
+	require.NoError(t, err)
+	assert.Equal(t, "0.1.0-alpha", latest.Server.Version)
+}
+
+func TestDistTags(t *testing.T) {
+	ctx := context.Background()
+	registry := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false})
+
+	serverName := "com.example/dist-tag-server"
+	for _, version := range []string{"1.0.0", "1.1.0", "2.0.0-beta.1"} {
+		_, err := registry.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        serverName,
+			Description: "Dist-tag test server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("latest is derived from the latest version", func(t *testing.T) {
+		tags, err := registry.GetDistTags(ctx, serverName)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"latest": "1.1.0"}, tags)
+	})
+
+	t.Run("set and resolve a named tag", func(t *testing.T) {
+		tags, err := registry.SetDistTag(ctx, serverName, "next", "2.0.0-beta.1")
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0-beta.1", tags["next"])
+
+		resolved, err := registry.GetServerByDistTag(ctx, serverName, "next")
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0-beta.1", resolved.Server.Version)
+	})
+
+	t.Run("move a tag", func(t *testing.T) {
+		tags, err := registry.SetDistTag(ctx, serverName, "next", "1.1.0")
+		require.NoError(t, err)
+		assert.Equal(t, "1.1.0", tags["next"])
+	})
+
+	t.Run("setting latest moves the latest marker", func(t *testing.T) {
+		tags, err := registry.SetDistTag(ctx, serverName, "latest", "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", tags["latest"])
+
+		latest, err := registry.GetServerByName(ctx, serverName)
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", latest.Server.Version)
+		assert.True(t, latest.Meta.Official.IsLatest)
+	})
+
+	t.Run("tagging an unknown version fails", func(t *testing.T) {
+		_, err := registry.SetDistTag(ctx, serverName, "beta", "9.9.9")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("delete a tag", func(t *testing.T) {
+		tags, err := registry.DeleteDistTag(ctx, serverName, "next")
+		require.NoError(t, err)
+		assert.NotContains(t, tags, "next")
+
+		_, err = registry.GetServerByDistTag(ctx, serverName, "next")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("latest cannot be deleted", func(t *testing.T) {
+		_, err := registry.DeleteDistTag(ctx, serverName, "latest")
+		assert.ErrorIs(t, err, database.ErrInvalidInput)
+	})
+
+	t.Run("deleting a version removes its tags", func(t *testing.T) {
+		_, err := registry.SetDistTag(ctx, serverName, "beta", "2.0.0-beta.1")
+		require.NoError(t, err)
+
+		deleted := string(model.StatusDeleted)
+		current, err := registry.GetServerByNameAndVersion(ctx, serverName, "2.0.0-beta.1")
+		require.NoError(t, err)
+		_, err = registry.UpdateServer(ctx, serverName, "2.0.0-beta.1", &current.Server, &deleted, "", "")
+		require.NoError(t, err)
+
+		_, err = registry.GetServerByDistTag(ctx, serverName, "beta")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+		tags, err := registry.GetDistTags(ctx, serverName)
+		require.NoError(t, err)
+		assert.NotContains(t, tags, "beta")
+	})
+
+	t.Run("taking down a server removes its tags", func(t *testing.T) {
+		_, err := registry.SetDistTag(ctx, serverName, "stable", "1.1.0")
+		require.NoError(t, err)
+
+		_, err = registry.SetAllVersionsStatus(ctx, serverName, model.StatusDeleted, "Malware report")
+		require.NoError(t, err)
+
+		_, err = registry.GetServerByDistTag(ctx, serverName, "stable")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+}
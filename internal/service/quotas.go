@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// QuotaExceededError reports that a publish would exceed a publish quota
+type QuotaExceededError struct {
+	Quota database.PublishQuota
+	// Subject is the namespace, server name or publisher the quota applies to
+	Subject string
+	Limit   int
+	// ResetAt is when the next publish fits within the quota again, or the zero time for
+	// quotas that do not reset over time
+	ResetAt time.Time
+}
+
+func (e *QuotaExceededError) Error() string {
+	switch e.Quota {
+	case database.QuotaServersPerNamespace:
+		return fmt.Sprintf("publish quota exceeded: namespace %s already has the maximum of %d servers. Please reach out at https://github.com/modelcontextprotocol/registry to raise the quota", e.Subject, e.Limit)
+	case database.QuotaVersionsPerDay:
+		return fmt.Sprintf("publish quota exceeded: %d versions of %s were published in the last 24 hours, the maximum allowed. The quota resets at %s", e.Limit, e.Subject, e.ResetAt.UTC().Format(time.RFC3339))
+	case database.QuotaPublishesPerHour:
+		return fmt.Sprintf("publish quota exceeded: %s published %d server versions in the last hour, the maximum allowed. The quota resets at %s", e.Subject, e.Limit, e.ResetAt.UTC().Format(time.RFC3339))
+	default:
+		return fmt.Sprintf("publish quota %s of %d exceeded for %s", e.Quota, e.Limit, e.Subject)
+	}
+}
+
+// checkPublishQuotas checks that publishing a version of a server fits within the publish quotas.
+// isNewServer is whether the server has no versions yet, so that it counts against its namespace.
+// Each quota subject is locked before counting, always in the same order, so that concurrent
+// publishes of different servers cannot all pass a check and exceed the quota together.
+func (s *registryServiceImpl) checkPublishQuotas(ctx context.Context, tx pgx.Tx, serverName string, isNewServer bool, publishedBy string, now time.Time) error {
+	if isNewServer {
+		namespace, _, _ := strings.Cut(serverName, "/")
+		limit, err := s.publishQuotaLimit(ctx, tx, database.QuotaServersPerNamespace, namespace, s.cfg.PublishQuotaServersPerNamespace)
+		if err != nil {
+			return err
+		}
+		if limit > 0 {
+			if err := s.db.AcquirePublishQuotaLock(ctx, tx, database.QuotaServersPerNamespace, namespace); err != nil {
+				return err
+			}
+			count, err := s.db.CountNamespaceServers(ctx, tx, namespace)
+			if err != nil {
+				return err
+			}
+			if count >= limit {
+				return &QuotaExceededError{Quota: database.QuotaServersPerNamespace, Subject: namespace, Limit: limit}
+			}
+		}
+	}
+
+	if err := s.checkPublishWindow(ctx, tx, database.QuotaVersionsPerDay, serverName, s.cfg.PublishQuotaVersionsPerDay, 24*time.Hour, now, s.db.CountVersionsPublishedSince); err != nil {
+		return err
+	}
+	return s.checkPublishWindow(ctx, tx, database.QuotaPublishesPerHour, publishedBy, s.cfg.PublishQuotaPublishesPerHour, time.Hour, now, s.db.CountPublishesSince)
+}
+
+// checkPublishWindow checks a quota on the number of publishes of a subject within a sliding
+// window. Once exceeded, the quota resets when the earliest publish in the window leaves it.
+func (s *registryServiceImpl) checkPublishWindow(
+	ctx context.Context, tx pgx.Tx, quota database.PublishQuota, subject string, defaultLimit int, window time.Duration, now time.Time,
+	count func(ctx context.Context, tx pgx.Tx, subject string, since time.Time) (int, time.Time, error),
+) error {
+	limit, err := s.publishQuotaLimit(ctx, tx, quota, subject, defaultLimit)
+	if err != nil || limit <= 0 {
+		return err
+	}
+
+	if err := s.db.AcquirePublishQuotaLock(ctx, tx, quota, subject); err != nil {
+		return err
+	}
+	published, earliest, err := count(ctx, tx, subject, now.Add(-window))
+	if err != nil {
+		return err
+	}
+	if published >= limit {
+		return &QuotaExceededError{Quota: quota, Subject: subject, Limit: limit, ResetAt: earliest.Add(window)}
+	}
+	return nil
+}
+
+// publishQuotaLimit returns the limit of a quota for a subject: its override if an admin set one,
+// or the configured default. 0 means unlimited.
+func (s *registryServiceImpl) publishQuotaLimit(ctx context.Context, tx pgx.Tx, quota database.PublishQuota, subject string, defaultLimit int) (int, error) {
+	override, err := s.db.GetPublishQuotaOverride(ctx, tx, quota, subject)
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return defaultLimit, nil
+		}
+		return 0, err
+	}
+	return override.Limit, nil
+}
+
+// ListPublishQuotaOverrides retrieves every publish quota override
+func (s *registryServiceImpl) ListPublishQuotaOverrides(ctx context.Context) ([]*apiv0.PublishQuotaOverride, error) {
+	return s.db.ListPublishQuotaOverrides(ctx, nil)
+}
+
+// SetPublishQuotaOverride overrides a publish quota for a namespace, server or publisher
+func (s *registryServiceImpl) SetPublishQuotaOverride(ctx context.Context, quota database.PublishQuota, subject string, req *apiv0.PublishQuotaOverrideRequest) (*apiv0.PublishQuotaOverride, error) {
+	if err := validatePublishQuotaSubject(quota, subject); err != nil {
+		return nil, err
+	}
+	if req.Limit < 0 {
+		return nil, fmt.Errorf("%w: quota limit must be at least 0", database.ErrInvalidInput)
+	}
+	return s.db.SetPublishQuotaOverride(ctx, nil, quota, subject, req.Limit, req.Reason)
+}
+
+// DeletePublishQuotaOverride restores the configured publish quota for a namespace, server or publisher
+func (s *registryServiceImpl) DeletePublishQuotaOverride(ctx context.Context, quota database.PublishQuota, subject string) error {
+	return s.db.DeletePublishQuotaOverride(ctx, nil, quota, subject)
+}
+
+// validatePublishQuotaSubject checks that a subject is of the kind a quota applies to
+func validatePublishQuotaSubject(quota database.PublishQuota, subject string) error {
+	if subject == "" {
+		return fmt.Errorf("%w: quota subject is required", database.ErrInvalidInput)
+	}
+	switch quota {
+	case database.QuotaServersPerNamespace:
+		if strings.Contains(subject, "/") {
+			return fmt.Errorf("%w: the %s quota applies to namespaces, which cannot contain '/'", database.ErrInvalidInput, quota)
+		}
+	case database.QuotaVersionsPerDay:
+		if !strings.Contains(subject, "/") {
+			return fmt.Errorf("%w: the %s quota applies to server names, which must contain '/'", database.ErrInvalidInput, quota)
+		}
+	case database.QuotaPublishesPerHour:
+		// Publisher identities are free-form (auth method and subject)
+	default:
+		return fmt.Errorf("%w: unknown publish quota %q", database.ErrInvalidInput, quota)
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+//nolint:testpackage
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishServerQuotas(t *testing.T) {
+	ctx := context.Background()
+
+	serverJSON := func(name, version string) *apiv0.ServerJSON {
+		return &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Quota test server",
+			Version:     version,
+		}
+	}
+
+	t.Run("versions per day", func(t *testing.T) {
+		svc := NewRegistryService(database.NewTestDB(t), &config.Config{PublishQuotaVersionsPerDay: 2})
+
+		first, err := svc.PublishServer(ctx, serverJSON("com.example/daily", "1.0.0"), "github-at:alice")
+		require.NoError(t, err)
+		_, err = svc.PublishServer(ctx, serverJSON("com.example/daily", "1.0.1"), "github-at:bob")
+		require.NoError(t, err)
+
+		_, err = svc.PublishServer(ctx, serverJSON("com.example/daily", "1.0.2"), "github-at:alice")
+		var quotaErr *QuotaExceededError
+		require.ErrorAs(t, err, &quotaErr)
+		assert.Equal(t, database.QuotaVersionsPerDay, quotaErr.Quota)
+		assert.Equal(t, "com.example/daily", quotaErr.Subject)
+		assert.Equal(t, 2, quotaErr.Limit)
+		assert.WithinDuration(t, first.Meta.Official.PublishedAt.Add(24*time.Hour), quotaErr.ResetAt, time.Millisecond)
+		assert.Contains(t, err.Error(), "The quota resets at "+quotaErr.ResetAt.UTC().Format(time.RFC3339))
+
+		// Other servers have their own quota
+		_, err = svc.PublishServer(ctx, serverJSON("com.example/other", "1.0.0"), "github-at:alice")
+		require.NoError(t, err)
+
+		// An override replaces the configured quota
+		_, err = svc.SetPublishQuotaOverride(ctx, database.QuotaVersionsPerDay, "com.example/daily", &apiv0.PublishQuotaOverrideRequest{Limit: 3, Reason: "Nightly builds"})
+		require.NoError(t, err)
+		_, err = svc.PublishServer(ctx, serverJSON("com.example/daily", "1.0.2"), "github-at:alice")
+		require.NoError(t, err)
+		_, err = svc.PublishServer(ctx, serverJSON("com.example/daily", "1.0.3"), "github-at:alice")
+		require.ErrorAs(t, err, &quotaErr)
+		assert.Equal(t, 3, quotaErr.Limit)
+
+		// An override of 0 lifts the quota
+		_, err = svc.SetPublishQuotaOverride(ctx, database.QuotaVersionsPerDay, "com.example/daily", &apiv0.PublishQuotaOverrideRequest{Limit: 0})
+		require.NoError(t, err)
+		_, err = svc.PublishServer(ctx, serverJSON("com.example/daily", "1.0.3"), "github-at:alice")
+		require.NoError(t, err)
+	})
+
+	t.Run("servers per namespace", func(t *testing.T) {
+		svc := NewRegistryService(database.NewTestDB(t), &config.Config{PublishQuotaServersPerNamespace: 2})
+
+		for _, name := range []string{"com.example/one", "com.example/two"} {
+			_, err := svc.PublishServer(ctx, serverJSON(name, "1.0.0"), "github-at:alice")
+			require.NoError(t, err)
+		}
+
+		_, err := svc.PublishServer(ctx, serverJSON("com.example/three", "1.0.0"), "github-at:alice")
+		var quotaErr *QuotaExceededError
+		require.ErrorAs(t, err, &quotaErr)
+		assert.Equal(t, database.QuotaServersPerNamespace, quotaErr.Quota)
+		assert.Equal(t, "com.example", quotaErr.Subject)
+		assert.True(t, quotaErr.ResetAt.IsZero())
+
+		// New versions of existing servers and servers in sub-namespaces are not affected
+		_, err = svc.PublishServer(ctx, serverJSON("com.example/one", "1.1.0"), "github-at:alice")
+		require.NoError(t, err)
+		_, err = svc.PublishServer(ctx, serverJSON("com.example.team/three", "1.0.0"), "github-at:alice")
+		require.NoError(t, err)
+	})
+
+	t.Run("publishes per hour", func(t *testing.T) {
+		svc := NewRegistryService(database.NewTestDB(t), &config.Config{PublishQuotaPublishesPerHour: 2})
+
+		for i := range 2 {
+			_, err := svc.PublishServer(ctx, serverJSON(fmt.Sprintf("com.example/hourly-%d", i), "1.0.0"), "github-at:alice")
+			require.NoError(t, err)
+		}
+
+		_, err := svc.PublishServer(ctx, serverJSON("com.example/hourly-2", "1.0.0"), "github-at:alice")
+		var quotaErr *QuotaExceededError
+		require.ErrorAs(t, err, &quotaErr)
+		assert.Equal(t, database.QuotaPublishesPerHour, quotaErr.Quota)
+		assert.Equal(t, "github-at:alice", quotaErr.Subject)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), quotaErr.ResetAt, time.Minute)
+
+		// Other publishers have their own quota
+		_, err = svc.PublishServer(ctx, serverJSON("com.example/hourly-2", "1.0.0"), "github-at:bob")
+		require.NoError(t, err)
+
+		// Imports are not publishes of any publisher
+		_, err = svc.CreateServer(ctx, serverJSON("com.example/hourly-3", "1.0.0"))
+		require.NoError(t, err)
+	})
+
+	t.Run("unlimited by default", func(t *testing.T) {
+		svc := NewRegistryService(database.NewTestDB(t), &config.Config{})
+
+		for i := range 5 {
+			_, err := svc.PublishServer(ctx, serverJSON("com.example/unlimited", fmt.Sprintf("1.0.%d", i)), "github-at:alice")
+			require.NoError(t, err)
+		}
+	})
+}
+
+func TestPublishServerQuotasConcurrent(t *testing.T) {
+	ctx := context.Background()
+	svc := NewRegistryService(database.NewTestDB(t), &config.Config{
+		PublishQuotaServersPerNamespace: 3,
+		PublishQuotaPublishesPerHour:    5,
+	})
+
+	// Publishes of different servers are not serialized by the per-server publish lock
+	publishConcurrently := func(name, publisher func(i int) string) []error {
+		const concurrency = 10
+		errs := make([]error, concurrency)
+		var wg sync.WaitGroup
+		for i := range concurrency {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, errs[i] = svc.PublishServer(ctx, &apiv0.ServerJSON{
+					Schema:      model.CurrentSchemaURL,
+					Name:        name(i),
+					Description: "Quota test server",
+					Version:     "1.0.0",
+				}, publisher(i))
+			}()
+		}
+		wg.Wait()
+		return errs
+	}
+
+	countAllowed := func(t *testing.T, errs []error, quota database.PublishQuota) int {
+		t.Helper()
+		allowed := 0
+		for _, err := range errs {
+			if err == nil {
+				allowed++
+				continue
+			}
+			var quotaErr *QuotaExceededError
+			if assert.ErrorAs(t, err, &quotaErr) {
+				assert.Equal(t, quota, quotaErr.Quota)
+			}
+		}
+		return allowed
+	}
+
+	t.Run("servers per namespace", func(t *testing.T) {
+		errs := publishConcurrently(
+			func(i int) string { return fmt.Sprintf("com.example.crowded/server-%d", i) },
+			func(i int) string { return fmt.Sprintf("github-at:user-%d", i) },
+		)
+		assert.Equal(t, 3, countAllowed(t, errs, database.QuotaServersPerNamespace))
+	})
+
+	t.Run("publishes per hour", func(t *testing.T) {
+		errs := publishConcurrently(
+			func(i int) string { return fmt.Sprintf("com.example.busy-%d/server", i) },
+			func(int) string { return "github-at:busy" },
+		)
+		assert.Equal(t, 5, countAllowed(t, errs, database.QuotaPublishesPerHour))
+	})
+}
+
+func TestPublishQuotaOverrides(t *testing.T) {
+	ctx := context.Background()
+	svc := NewRegistryService(database.NewTestDB(t), &config.Config{})
+
+	overrides, err := svc.ListPublishQuotaOverrides(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, overrides)
+
+	override, err := svc.SetPublishQuotaOverride(ctx, database.QuotaServersPerNamespace, "com.example", &apiv0.PublishQuotaOverrideRequest{Limit: 500, Reason: "Large organization"})
+	require.NoError(t, err)
+	assert.Equal(t, "servers_per_namespace", override.Quota)
+	assert.Equal(t, "com.example", override.Subject)
+	assert.Equal(t, 500, override.Limit)
+	assert.Equal(t, "Large organization", override.Reason)
+	assert.False(t, override.UpdatedAt.IsZero())
+
+	_, err = svc.SetPublishQuotaOverride(ctx, database.QuotaPublishesPerHour, "github-oidc:ci", &apiv0.PublishQuotaOverrideRequest{Limit: 0})
+	require.NoError(t, err)
+
+	// Setting an override again replaces it
+	_, err = svc.SetPublishQuotaOverride(ctx, database.QuotaServersPerNamespace, "com.example", &apiv0.PublishQuotaOverrideRequest{Limit: 1000})
+	require.NoError(t, err)
+
+	overrides, err = svc.ListPublishQuotaOverrides(ctx)
+	require.NoError(t, err)
+	require.Len(t, overrides, 2)
+	assert.Equal(t, "publishes_per_hour", overrides[0].Quota)
+	assert.Equal(t, "servers_per_namespace", overrides[1].Quota)
+	assert.Equal(t, 1000, overrides[1].Limit)
+	assert.Empty(t, overrides[1].Reason)
+
+	require.NoError(t, svc.DeletePublishQuotaOverride(ctx, database.QuotaServersPerNamespace, "com.example"))
+	err = svc.DeletePublishQuotaOverride(ctx, database.QuotaServersPerNamespace, "com.example")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	invalid := []struct {
+		quota   database.PublishQuota
+		subject string
+		limit   int
+	}{
+		{"unknown", "com.example", 1},
+		{database.QuotaServersPerNamespace, "", 1},
+		{database.QuotaServersPerNamespace, "com.example/server", 1},
+		{database.QuotaVersionsPerDay, "com.example", 1},
+		{database.QuotaVersionsPerDay, "com.example/server", -1},
+	}
+	for _, tc := range invalid {
+		_, err := svc.SetPublishQuotaOverride(ctx, tc.quota, tc.subject, &apiv0.PublishQuotaOverrideRequest{Limit: tc.limit})
+		assert.ErrorIs(t, err, database.ErrInvalidInput, "quota %q, subject %q, limit %d: %v", tc.quota, tc.subject, tc.limit, err)
+	}
+}
@@ -10,8 +10,10 @@ import (
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/modelcontextprotocol/registry/internal/versioning"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/transparency"
 )
 
 const maxServerVersionsPerServer = 10000
@@ -30,22 +32,53 @@ func NewRegistryService(db database.Database, cfg *config.Config) RegistryServic
 	}
 }
 
-// ListServers returns registry entries with cursor-based pagination and optional filtering
-func (s *registryServiceImpl) ListServers(ctx context.Context, filter *database.ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error) {
+// ListServers returns registry entries in the requested order with opaque cursor-based pagination and optional filtering
+func (s *registryServiceImpl) ListServers(ctx context.Context, filter *database.ServerFilter, options *database.ServerListOptions, cursor string, limit int) ([]*apiv0.ServerResponse, string, error) {
 	// If limit is not set or negative, use a default limit
 	if limit <= 0 {
 		limit = 30
 	}
 
+	// A cursor only continues the listing it was issued for
+	fingerprint := serverListFingerprint(filter, options)
+	var after *database.ServerListPosition
+	if cursor != "" {
+		var err error
+		after, err = s.decodeServerCursor(cursor, fingerprint)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
 	// Use the database's ListServers method with pagination and filtering
-	serverRecords, nextCursor, err := s.db.ListServers(ctx, nil, filter, cursor, limit)
+	serverRecords, next, err := s.db.ListServers(ctx, nil, filter, options, after, limit)
 	if err != nil {
 		return nil, "", err
 	}
 
+	nextCursor := ""
+	if next != nil {
+		nextCursor = s.encodeServerCursor(next, fingerprint)
+	}
+
 	return serverRecords, nextCursor, nil
 }
 
+// CountServers returns the number of server versions matching a filter
+func (s *registryServiceImpl) CountServers(ctx context.Context, filter *database.ServerFilter) (int, error) {
+	return s.db.CountServers(ctx, nil, filter)
+}
+
+// ExportServers streams every server version matching a filter to fn, from a consistent snapshot
+func (s *registryServiceImpl) ExportServers(ctx context.Context, filter *database.ServerFilter, fn func(*apiv0.ServerResponse) error) error {
+	return s.db.ExportServers(ctx, nil, filter, fn)
+}
+
+// GetServerFacets counts the servers matching a filter per status, namespace, registry type and transport type
+func (s *registryServiceImpl) GetServerFacets(ctx context.Context, filter *database.ServerFilter) (*apiv0.ServerFacetsResponse, error) {
+	return s.db.GetServerFacets(ctx, nil, filter)
+}
+
 // GetServerByName retrieves the latest version of a server by its server name
 func (s *registryServiceImpl) GetServerByName(ctx context.Context, serverName string) (*apiv0.ServerResponse, error) {
 	serverRecord, err := s.db.GetServerByName(ctx, nil, serverName)
@@ -66,6 +99,15 @@ func (s *registryServiceImpl) GetServerByNameAndVersion(ctx context.Context, ser
 	return serverRecord, nil
 }
 
+// GetServerByContentDigest retrieves the server version whose current server.json has a canonical content digest
+func (s *registryServiceImpl) GetServerByContentDigest(ctx context.Context, digest string) (*apiv0.ServerResponse, error) {
+	if !isContentDigest(digest) {
+		return nil, fmt.Errorf("%w: digest must be a hex-encoded SHA-256", database.ErrInvalidInput)
+	}
+
+	return s.db.GetServerByContentDigest(ctx, nil, digest)
+}
+
 // GetAllVersionsByServerName retrieves all versions of a server by server name
 func (s *registryServiceImpl) GetAllVersionsByServerName(ctx context.Context, serverName string) ([]*apiv0.ServerResponse, error) {
 	serverRecords, err := s.db.GetAllVersionsByServerName(ctx, nil, serverName)
@@ -76,16 +118,95 @@ func (s *registryServiceImpl) GetAllVersionsByServerName(ctx context.Context, se
 	return serverRecords, nil
 }
 
+// ListServerVersions returns the versions of a server in the requested order with opaque cursor-based pagination
+func (s *registryServiceImpl) ListServerVersions(ctx context.Context, serverName string, options *database.VersionListOptions, cursor string, limit int) ([]*apiv0.ServerResponse, string, error) {
+	// If limit is not set or negative, use a default limit
+	if limit <= 0 {
+		limit = 30
+	}
+
+	// A cursor only continues the listing it was issued for
+	fingerprint := versionListFingerprint(serverName, options)
+	afterVersion := ""
+	if cursor != "" {
+		after, err := s.decodeServerCursor(cursor, fingerprint)
+		if err != nil {
+			return nil, "", err
+		}
+		afterVersion = after.Version
+	}
+
+	serverRecords, nextVersion, err := s.db.ListServerVersions(ctx, nil, serverName, options, afterVersion, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if nextVersion != "" {
+		nextCursor = s.encodeServerCursor(&database.ServerListPosition{ServerName: serverName, Version: nextVersion}, fingerprint)
+	}
+
+	return serverRecords, nextCursor, nil
+}
+
+// ResolveVersionRange retrieves the highest non-deleted version of a server that satisfies an
+// npm-style semantic version range (e.g. "^1.2.0", "~1.4", ">=1.0.0 <2.0.0")
+func (s *registryServiceImpl) ResolveVersionRange(ctx context.Context, serverName, constraint string) (*apiv0.ServerResponse, error) {
+	versionRange, err := ParseVersionRange(constraint)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", database.ErrInvalidInput, err)
+	}
+
+	serverRecords, err := s.db.GetAllVersionsByServerName(ctx, nil, serverName)
+	if err != nil {
+		return nil, err
+	}
+
+	var best *apiv0.ServerResponse
+	for _, record := range serverRecords {
+		if record.Meta.Official != nil && record.Meta.Official.Status == model.StatusDeleted {
+			continue
+		}
+		if !versionRange.Matches(record.Server.Version) {
+			continue
+		}
+		if best == nil || compareSemanticVersions(record.Server.Version, best.Server.Version) > 0 {
+			best = record
+		}
+	}
+
+	if best == nil {
+		return nil, database.ErrNotFound
+	}
+
+	return best, nil
+}
+
 // CreateServer creates a new server version
 func (s *registryServiceImpl) CreateServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
 	// Wrap the entire operation in a transaction
 	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
-		return s.createServerInTransaction(ctx, tx, req)
+		return s.createServerInTransaction(ctx, tx, req, "")
+	})
+}
+
+// PublishServer creates a new server version on behalf of a publisher, within the publish quotas
+func (s *registryServiceImpl) PublishServer(ctx context.Context, req *apiv0.ServerJSON, publishedBy string) (*apiv0.ServerResponse, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
+		return s.createServerInTransaction(ctx, tx, req, publishedBy)
 	})
 }
 
-// createServerInTransaction contains the actual CreateServer logic within a transaction
-func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx pgx.Tx, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+// createServerInTransaction contains the actual CreateServer logic within a transaction.
+// The namespace denylist and publish quotas only apply to versions with a publisher.
+func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx pgx.Tx, req *apiv0.ServerJSON, publishedBy string) (*apiv0.ServerResponse, error) {
+	// Refuse blocked namespaces before validating packages against their registries
+	if publishedBy != "" {
+		if err := s.checkNamespaceNotBlocked(ctx, tx, req.Name); err != nil {
+			return nil, err
+		}
+	}
+
 	// Validate the request
 	if err := validators.ValidatePublishRequest(ctx, *req, s.cfg); err != nil {
 		return nil, err
@@ -122,20 +243,28 @@ func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx
 		return nil, database.ErrInvalidVersion
 	}
 
+	if publishedBy != "" {
+		if err := s.checkPublishQuotas(ctx, tx, serverJSON.Name, versionCount == 0, publishedBy, publishTime); err != nil {
+			return nil, err
+		}
+	}
+
 	// Get current latest version to determine if new version should be latest
 	currentLatest, err := s.db.GetCurrentLatestVersion(ctx, tx, serverJSON.Name)
 	if err != nil && !errors.Is(err, database.ErrNotFound) {
 		return nil, err
 	}
 
-	// Determine if this version should be marked as latest
+	// Determine if this version should be marked as latest, using the new version's scheme
+	// Prereleases only become latest while no stable version exists
 	isNewLatest := true
 	if currentLatest != nil {
 		var existingPublishedAt time.Time
 		if currentLatest.Meta.Official != nil {
 			existingPublishedAt = currentLatest.Meta.Official.PublishedAt
 		}
-		isNewLatest = CompareVersions(
+		isNewLatest = versioning.CompareForLatest(
+			versioning.ForServer(&serverJSON),
 			serverJSON.Version,
 			currentLatest.Server.Version,
 			publishTime,
@@ -159,7 +288,27 @@ func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx
 	}
 
 	// Insert new server version
-	return s.db.CreateServer(ctx, tx, &serverJSON, officialMeta)
+	created, err := s.db.CreateServer(ctx, tx, &serverJSON, officialMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	if publishedBy != "" {
+		if err := s.db.SetServerPublisher(ctx, tx, serverJSON.Name, serverJSON.Version, publishedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	// The original publication is the first revision of the version's edit history
+	revision, err := s.db.CreateServerRevision(ctx, tx, serverJSON.Name, serverJSON.Version, &serverJSON, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.appendLogEntry(ctx, tx, transparency.ActionPublish, revision); err != nil {
+		return nil, err
+	}
+
+	return created, nil
 }
 
 // validateNoDuplicateRemoteURLs checks that no other server is using the same remote URLs
@@ -169,7 +318,7 @@ func (s *registryServiceImpl) validateNoDuplicateRemoteURLs(ctx context.Context,
 		// Use filter to find servers with this remote URL
 		filter := &database.ServerFilter{RemoteURL: &remote.URL}
 
-		conflictingServers, _, err := s.db.ListServers(ctx, tx, filter, "", 1000)
+		conflictingServers, _, err := s.db.ListServers(ctx, tx, filter, nil, nil, 1000)
 		if err != nil {
 			return fmt.Errorf("failed to check remote URL conflict: %w", err)
 		}
@@ -177,7 +326,7 @@ func (s *registryServiceImpl) validateNoDuplicateRemoteURLs(ctx context.Context,
 		// Check if any conflicting server has a different name
 		for _, conflictingServer := range conflictingServers {
 			if conflictingServer.Server.Name != serverDetail.Name {
-				return fmt.Errorf("remote URL %s is already used by server %s", remote.URL, conflictingServer.Server.Name)
+				return fmt.Errorf("%w: remote URL %s is already used by server %s", database.ErrInvalidInput, remote.URL, conflictingServer.Server.Name)
 			}
 		}
 	}
@@ -185,16 +334,17 @@ func (s *registryServiceImpl) validateNoDuplicateRemoteURLs(ctx context.Context,
 	return nil
 }
 
-// UpdateServer updates an existing server with new details
-func (s *registryServiceImpl) UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string) (*apiv0.ServerResponse, error) {
+// UpdateServer updates an existing server with new details. If expectedDigest is set, the
+// update only applies while it is still the content digest of the server version.
+func (s *registryServiceImpl) UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string, editedBy, expectedDigest string) (*apiv0.ServerResponse, error) {
 	// Wrap the entire operation in a transaction
 	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
-		return s.updateServerInTransaction(ctx, tx, serverName, version, req, newStatus)
+		return s.updateServerInTransaction(ctx, tx, serverName, version, req, newStatus, editedBy, expectedDigest)
 	})
 }
 
 // updateServerInTransaction contains the actual UpdateServer logic within a transaction
-func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx pgx.Tx, serverName, version string, req *apiv0.ServerJSON, newStatus *string) (*apiv0.ServerResponse, error) {
+func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx pgx.Tx, serverName, version string, req *apiv0.ServerJSON, newStatus *string, editedBy, expectedDigest string) (*apiv0.ServerResponse, error) {
 	// Get current server to check if it's deleted or being deleted
 	currentServer, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
 	if err != nil {
@@ -210,7 +360,7 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 
 	// Validate the request, potentially skipping registry validation for deleted servers
 	if err := validators.ValidateUpdateRequest(ctx, *req, s.cfg, skipRegistryValidation); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", database.ErrInvalidInput, err)
 	}
 
 	// Acquire advisory lock to prevent concurrent edits of servers with same name
@@ -218,6 +368,17 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 		return nil, err
 	}
 
+	// Check the expected content under the lock, so no concurrent edit can slip in between
+	if expectedDigest != "" {
+		lockedServer, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
+		if err != nil {
+			return nil, err
+		}
+		if lockedServer.Meta.Official == nil || lockedServer.Meta.Official.ContentDigest != expectedDigest {
+			return nil, fmt.Errorf("%w: server version has been modified", database.ErrPreconditionFailed)
+		}
+	}
+
 	// Merge the request with the current server, preserving metadata
 	updatedServer := *req
 
@@ -232,9 +393,24 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 		return nil, err
 	}
 
+	// Keep the previous content retrievable by recording the edit as a new revision
+	revision, err := s.db.CreateServerRevision(ctx, tx, serverName, version, &updatedServer, editedBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.appendLogEntry(ctx, tx, transparency.ActionEdit, revision); err != nil {
+		return nil, err
+	}
+
 	// Handle status change if provided
 	if newStatus != nil {
-		updatedWithStatus, err := s.db.SetServerStatus(ctx, tx, serverName, version, *newStatus)
+		// Keep the status message and replacement unless the status actually changes
+		var details *database.StatusDetails
+		if official := currentServer.Meta.Official; official != nil && string(official.Status) == *newStatus {
+			details = &database.StatusDetails{Message: official.StatusMessage, ReplacedBy: official.ReplacedBy}
+		}
+
+		updatedWithStatus, err := s.db.SetServerStatus(ctx, tx, serverName, version, *newStatus, details)
 		if err != nil {
 			return nil, err
 		}
@@ -243,3 +419,16 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 
 	return updatedServerResponse, nil
 }
+
+// isContentDigest reports whether s is a hex-encoded SHA-256, as stored by the database
+func isContentDigest(s string) bool {
+	if len(s) != 64 {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
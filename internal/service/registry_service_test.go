@@ -4,6 +4,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -528,7 +529,7 @@ func TestUpdateServer(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := service.UpdateServer(ctx, tt.serverName, tt.version, tt.updatedServer, tt.newStatus)
+			result, err := service.UpdateServer(ctx, tt.serverName, tt.version, tt.updatedServer, tt.newStatus, "", "")
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -581,7 +582,7 @@ func TestUpdateServer_SkipValidationForDeletedServers(t *testing.T) {
 
 	// First, set server to deleted status
 	deletedStatus := string(model.StatusDeleted)
-	_, err = service.UpdateServer(ctx, serverName, version, invalidServer, &deletedStatus)
+	_, err = service.UpdateServer(ctx, serverName, version, invalidServer, &deletedStatus, "", "")
 	require.NoError(t, err, "should be able to set server to deleted (validation should be skipped)")
 
 	// Verify server is now deleted
@@ -606,7 +607,7 @@ func TestUpdateServer_SkipValidationForDeletedServers(t *testing.T) {
 	}
 
 	// This should succeed despite invalid packages because server is deleted
-	result, err := service.UpdateServer(ctx, serverName, version, updatedInvalidServer, nil)
+	result, err := service.UpdateServer(ctx, serverName, version, updatedInvalidServer, nil, "", "")
 	assert.NoError(t, err, "updating deleted server should skip registry validation")
 	assert.NotNil(t, result)
 	assert.Equal(t, "Updated description for deleted server", result.Server.Description)
@@ -636,7 +637,7 @@ func TestUpdateServer_SkipValidationForDeletedServers(t *testing.T) {
 
 	// Update server and set to deleted in same operation - should skip validation
 	newDeletedStatus := string(model.StatusDeleted)
-	result2, err := service.UpdateServer(ctx, "com.example/being-deleted-test", "1.0.0", activeServer, &newDeletedStatus)
+	result2, err := service.UpdateServer(ctx, "com.example/being-deleted-test", "1.0.0", activeServer, &newDeletedStatus, "", "")
 	assert.NoError(t, err, "updating server being set to deleted should skip registry validation")
 	assert.NotNil(t, result2)
 	assert.Equal(t, model.StatusDeleted, result2.Meta.Official.Status)
@@ -705,18 +706,18 @@ func TestListServers(t *testing.T) {
 			expectedCount: 2,
 		},
 		{
-			name:   "cursor pagination",
+			name:   "raw cursors are rejected",
 			filter: nil,
 			cursor: "com.example/server-alpha",
 			limit:  10,
-			// Should return servers after 'server-alpha' alphabetically
-			expectedCount: 2,
+			// Cursors are opaque; only values returned as nextCursor are accepted
+			expectError: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			results, nextCursor, err := service.ListServers(ctx, tt.filter, tt.cursor, tt.limit)
+			results, nextCursor, err := service.ListServers(ctx, tt.filter, nil, tt.cursor, tt.limit)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -734,6 +735,77 @@ func TestListServers(t *testing.T) {
 	}
 }
 
+func TestListServersCursors(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, CursorSigningKey: "test-cursor-key"})
+
+	for _, name := range []string{"com.example/cursor-alpha", "com.example/cursor-beta", "com.example/cursor-gamma"} {
+		_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Cursor test server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+
+	filter := &database.ServerFilter{SubstringName: stringPtr("cursor-")}
+	descending := &database.ServerListOptions{OrderBy: database.ServerOrderByPublishedAt, Descending: true}
+
+	t.Run("follow cursors through every page", func(t *testing.T) {
+		var names []string
+		cursor := ""
+		for {
+			results, nextCursor, err := service.ListServers(ctx, filter, descending, cursor, 1)
+			require.NoError(t, err)
+			for _, result := range results {
+				names = append(names, result.Server.Name)
+			}
+			if nextCursor == "" {
+				break
+			}
+			cursor = nextCursor
+		}
+		assert.Equal(t, []string{"com.example/cursor-gamma", "com.example/cursor-beta", "com.example/cursor-alpha"}, names)
+	})
+
+	_, cursor, err := service.ListServers(ctx, filter, descending, "", 1)
+	require.NoError(t, err)
+	require.NotEmpty(t, cursor)
+
+	t.Run("cursor with different filters is rejected", func(t *testing.T) {
+		_, _, err := service.ListServers(ctx, &database.ServerFilter{SubstringName: stringPtr("alpha")}, descending, cursor, 1)
+		assert.ErrorIs(t, err, database.ErrInvalidInput)
+	})
+
+	t.Run("cursor with different ordering is rejected", func(t *testing.T) {
+		_, _, err := service.ListServers(ctx, filter, nil, cursor, 1)
+		assert.ErrorIs(t, err, database.ErrInvalidInput)
+	})
+
+	t.Run("tampered cursor is rejected", func(t *testing.T) {
+		payload, _, _ := strings.Cut(cursor, ".")
+		_, _, err := service.ListServers(ctx, filter, descending, payload, 1)
+		assert.ErrorIs(t, err, database.ErrInvalidInput)
+
+		_, _, err = service.ListServers(ctx, filter, descending, payload+".c2lnbmF0dXJl", 1)
+		assert.ErrorIs(t, err, database.ErrInvalidInput)
+	})
+
+	t.Run("cursor signed with another key is rejected", func(t *testing.T) {
+		otherService := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, CursorSigningKey: "other-cursor-key"})
+		_, _, err := otherService.ListServers(ctx, filter, descending, cursor, 1)
+		assert.ErrorIs(t, err, database.ErrInvalidInput)
+	})
+
+	t.Run("count matches the listing", func(t *testing.T) {
+		count, err := service.CountServers(ctx, filter)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+}
+
 func TestVersionComparison(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
@@ -786,6 +858,75 @@ func TestVersionComparison(t *testing.T) {
 	assert.Equal(t, 1, latestCount, "Exactly one version should be marked as latest")
 }
 
+func TestVersionComparisonWithVersionSchemes(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+
+	tests := []struct {
+		name           string
+		serverName     string
+		versionScheme  string
+		packages       []model.Package
+		versions       []string
+		expectedLatest string
+		expectedOrder  []string
+	}{
+		{
+			name:           "pypi packages use PEP 440",
+			serverName:     "com.example/pep440-server",
+			packages:       []model.Package{{RegistryType: model.RegistryTypePyPI, Identifier: "example-server", Version: "1.0"}},
+			versions:       []string{"1.0", "1.0.post1", "1.1rc1", "0.9"},
+			expectedLatest: "1.0.post1",
+			expectedOrder:  []string{"1.1rc1", "1.0.post1", "1.0", "0.9"},
+		},
+		{
+			name:           "explicit calver",
+			serverName:     "com.example/calver-server",
+			versionScheme:  "calver",
+			versions:       []string{"2025.9.30", "2025.10.3", "2025.10.3-beta", "2025.1.15"},
+			expectedLatest: "2025.10.3",
+			expectedOrder:  []string{"2025.10.3", "2025.10.3-beta", "2025.9.30", "2025.1.15"},
+		},
+		{
+			name:           "nuget packages use four-part versions",
+			serverName:     "com.example/nuget-server",
+			packages:       []model.Package{{RegistryType: model.RegistryTypeNuGet, Identifier: "Example.Server", Version: "1.0.0"}},
+			versions:       []string{"1.0.0.9", "1.0.0.10", "1.0"},
+			expectedLatest: "1.0.0.10",
+			expectedOrder:  []string{"1.0.0.10", "1.0.0.9", "1.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, version := range tt.versions {
+				_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+					Schema:        model.CurrentSchemaURL,
+					Name:          tt.serverName,
+					Description:   "Version scheme test server",
+					Version:       version,
+					VersionScheme: tt.versionScheme,
+					Packages:      tt.packages,
+				})
+				require.NoError(t, err, "Failed to create version %s", version)
+			}
+
+			latest, err := service.GetServerByName(ctx, tt.serverName)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedLatest, latest.Server.Version)
+
+			allVersions, err := service.GetAllVersionsByServerName(ctx, tt.serverName)
+			require.NoError(t, err)
+			order := make([]string, len(allVersions))
+			for i, v := range allVersions {
+				order[i] = v.Server.Version
+			}
+			assert.Equal(t, tt.expectedOrder, order)
+		})
+	}
+}
+
 // Helper functions
 func stringPtr(s string) *string {
 	return &s
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListServerRevisions retrieves the edit history of a server version, oldest first
+func (s *registryServiceImpl) ListServerRevisions(ctx context.Context, serverName, version string) ([]*apiv0.ServerRevision, error) {
+	return s.db.ListServerRevisions(ctx, nil, serverName, version)
+}
+
+// GetServerRevision retrieves a specific revision of a server version
+func (s *registryServiceImpl) GetServerRevision(ctx context.Context, serverName, version string, revision int) (*apiv0.ServerRevision, error) {
+	return s.db.GetServerRevision(ctx, nil, serverName, version, revision)
+}
+
+// RestoreServerRevision makes an earlier revision the current content of a server version.
+// The restore is itself an edit: history is append-only, so it is recorded as a new revision
+// and the revisions in between stay retrievable.
+func (s *registryServiceImpl) RestoreServerRevision(ctx context.Context, serverName, version string, revision int, editedBy string) (*apiv0.ServerResponse, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
+		target, err := s.db.GetServerRevision(ctx, tx, serverName, version, revision)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.updateServerInTransaction(ctx, tx, serverName, version, &target.Server, nil, editedBy, "")
+	})
+}
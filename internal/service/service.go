@@ -5,20 +5,83 @@ import (
 
 	"github.com/modelcontextprotocol/registry/internal/database"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/modelcontextprotocol/registry/pkg/transparency"
 )
 
 // RegistryService defines the interface for registry operations
 type RegistryService interface {
-	// ListServers retrieve all servers with optional filtering
-	ListServers(ctx context.Context, filter *database.ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error)
+	// ListServers retrieve a page of servers with optional filtering in the requested order, continuing from an opaque cursor
+	ListServers(ctx context.Context, filter *database.ServerFilter, options *database.ServerListOptions, cursor string, limit int) ([]*apiv0.ServerResponse, string, error)
+	// CountServers count the server versions matching a filter
+	CountServers(ctx context.Context, filter *database.ServerFilter) (int, error)
+	// ExportServers stream every server version matching a filter to fn in listing order
+	ExportServers(ctx context.Context, filter *database.ServerFilter, fn func(*apiv0.ServerResponse) error) error
+	// GetServerFacets count the servers matching a filter per status, namespace, registry type and transport type
+	GetServerFacets(ctx context.Context, filter *database.ServerFilter) (*apiv0.ServerFacetsResponse, error)
 	// GetServerByName retrieve latest version of a server by server name
 	GetServerByName(ctx context.Context, serverName string) (*apiv0.ServerResponse, error)
 	// GetServerByNameAndVersion retrieve specific version of a server by server name and version
 	GetServerByNameAndVersion(ctx context.Context, serverName string, version string) (*apiv0.ServerResponse, error)
+	// GetServerByContentDigest retrieve the server version whose current server.json has a canonical content digest
+	GetServerByContentDigest(ctx context.Context, digest string) (*apiv0.ServerResponse, error)
 	// GetAllVersionsByServerName retrieve all versions of a server by server name
 	GetAllVersionsByServerName(ctx context.Context, serverName string) ([]*apiv0.ServerResponse, error)
-	// CreateServer creates a new server version
+	// ListServerVersions retrieve a page of the versions of a server in the requested order
+	ListServerVersions(ctx context.Context, serverName string, options *database.VersionListOptions, cursor string, limit int) ([]*apiv0.ServerResponse, string, error)
+	// ResolveVersionRange retrieve the highest non-deleted version of a server matching a semver range
+	ResolveVersionRange(ctx context.Context, serverName, constraint string) (*apiv0.ServerResponse, error)
+	// DiffServerVersions compare the server.json of two versions of a server
+	DiffServerVersions(ctx context.Context, serverName, fromVersion, toVersion string) (*apiv0.ServerDiffResponse, error)
+	// CreateServer creates a new server version, without publish quotas (e.g. for imports)
 	CreateServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error)
-	// UpdateServer updates an existing server and optionally its status
-	UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string) (*apiv0.ServerResponse, error)
+	// PublishServer creates a new server version on behalf of a publisher, failing with a *QuotaExceededError over a publish quota
+	PublishServer(ctx context.Context, req *apiv0.ServerJSON, publishedBy string) (*apiv0.ServerResponse, error)
+	// UpdateServer updates an existing server and optionally its status, recording the edit as a new revision.
+	// A non-empty expectedDigest makes the update conditional on the current content digest.
+	UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string, editedBy, expectedDigest string) (*apiv0.ServerResponse, error)
+	// SetServerVersionStatus deprecates or undeprecates a server version with an optional message and replacement
+	SetServerVersionStatus(ctx context.Context, serverName, version string, req *apiv0.ServerStatusRequest) (*apiv0.ServerResponse, error)
+	// SetAllVersionsStatus changes the status of every version of a server atomically, recording a reason
+	SetAllVersionsStatus(ctx context.Context, serverName string, status model.Status, reason string) ([]*apiv0.ServerResponse, error)
+	// ListServerRevisions retrieve the edit history of a server version, oldest first
+	ListServerRevisions(ctx context.Context, serverName, version string) ([]*apiv0.ServerRevision, error)
+	// GetServerRevision retrieve a specific revision of a server version
+	GetServerRevision(ctx context.Context, serverName, version string, revision int) (*apiv0.ServerRevision, error)
+	// RestoreServerRevision makes an earlier revision the current content of a server version
+	RestoreServerRevision(ctx context.Context, serverName, version string, revision int, editedBy string) (*apiv0.ServerResponse, error)
+	// GetLogTreeHead retrieve the current size and root hash of the transparency log, unsigned
+	GetLogTreeHead(ctx context.Context) (*transparency.SignedTreeHead, error)
+	// GetLogInclusionProof prove that the log entry of a server version is in the transparency log of treeSize entries
+	GetLogInclusionProof(ctx context.Context, serverName, version string, revision int, treeSize int64) (*transparency.InclusionProof, error)
+	// GetLogConsistencyProof prove that the transparency log of first entries is a prefix of the log of second entries
+	GetLogConsistencyProof(ctx context.Context, first, second int64) (*transparency.ConsistencyProof, error)
+	// GetDistTags retrieve all dist-tags of a server, including the registry-managed "latest" tag
+	GetDistTags(ctx context.Context, serverName string) (map[string]string, error)
+	// GetServerByDistTag retrieve the version of a server that a dist-tag points at
+	GetServerByDistTag(ctx context.Context, serverName, tag string) (*apiv0.ServerResponse, error)
+	// SetDistTag points a dist-tag of a server at one of its versions
+	SetDistTag(ctx context.Context, serverName, tag, version string) (map[string]string, error)
+	// DeleteDistTag removes a dist-tag from a server
+	DeleteDistTag(ctx context.Context, serverName, tag string) (map[string]string, error)
+	// ListPublishQuotaOverrides retrieve every publish quota override
+	ListPublishQuotaOverrides(ctx context.Context) ([]*apiv0.PublishQuotaOverride, error)
+	// SetPublishQuotaOverride overrides a publish quota for a namespace, server or publisher
+	SetPublishQuotaOverride(ctx context.Context, quota database.PublishQuota, subject string, req *apiv0.PublishQuotaOverrideRequest) (*apiv0.PublishQuotaOverride, error)
+	// DeletePublishQuotaOverride restores the configured publish quota for a namespace, server or publisher
+	DeletePublishQuotaOverride(ctx context.Context, quota database.PublishQuota, subject string) error
+	// ListBlockedNamespaces retrieve every namespace blocked from publishing
+	ListBlockedNamespaces(ctx context.Context) ([]*apiv0.BlockedNamespace, error)
+	// BlockNamespace blocks a namespace and its sub-namespaces from publishing
+	BlockNamespace(ctx context.Context, namespace, reason, blockedBy string) (*apiv0.BlockedNamespace, error)
+	// UnblockNamespace allows a blocked namespace to publish again
+	UnblockNamespace(ctx context.Context, namespace string) error
+	// CreateAPIToken creates an API token for an owner, returning its secret only this once
+	CreateAPIToken(ctx context.Context, owner string, req *apiv0.APITokenCreateRequest) (*apiv0.APITokenCreateResponse, error)
+	// ListAPITokens retrieve the API tokens of an owner, newest first
+	ListAPITokens(ctx context.Context, owner string) ([]*apiv0.APIToken, error)
+	// RevokeAPIToken revokes an API token of an owner
+	RevokeAPIToken(ctx context.Context, id, owner string) error
+	// ExchangeAPIToken retrieve the unexpired API token with a secret, recording that it was used
+	ExchangeAPIToken(ctx context.Context, secret string) (*apiv0.APIToken, error)
 }
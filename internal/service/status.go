@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/versioning"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// SetServerVersionStatus deprecates or undeprecates a server version on behalf of its publisher
+// Only active <-> deprecated transitions are allowed; deleting is reserved for admins
+func (s *registryServiceImpl) SetServerVersionStatus(ctx context.Context, serverName, version string, req *apiv0.ServerStatusRequest) (*apiv0.ServerResponse, error) {
+	if err := validateServerStatusRequest(serverName, version, req); err != nil {
+		return nil, err
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
+		if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+
+		current, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
+		if err != nil {
+			return nil, err
+		}
+		if current.Meta.Official != nil && current.Meta.Official.Status == model.StatusDeleted {
+			return nil, fmt.Errorf("%w: cannot change status of deleted version %s", database.ErrInvalidInput, version)
+		}
+
+		if req.ReplacedBy != nil {
+			if err := s.validateReplacement(ctx, tx, req.ReplacedBy); err != nil {
+				return nil, err
+			}
+		}
+
+		var details *database.StatusDetails
+		if req.Status == model.StatusDeprecated {
+			details = &database.StatusDetails{
+				Message:    req.Message,
+				ReplacedBy: req.ReplacedBy,
+			}
+		}
+
+		return s.db.SetServerStatus(ctx, tx, serverName, version, string(req.Status), details)
+	})
+}
+
+// SetAllVersionsStatus changes the status of every version of a server in one transaction,
+// recording the reason as the status message of each changed version:
+//   - deleted deletes every version that is not deleted yet
+//   - deprecated deprecates every active version
+//   - active undeprecates every deprecated version; deleted versions stay deleted
+//
+// If the latest version ends up deleted, the latest marker moves to the highest remaining version,
+// or is cleared if every version is deleted.
+func (s *registryServiceImpl) SetAllVersionsStatus(ctx context.Context, serverName string, status model.Status, reason string) ([]*apiv0.ServerResponse, error) {
+	var fromStatuses []model.Status
+	switch status {
+	case model.StatusDeleted:
+		fromStatuses = []model.Status{model.StatusActive, model.StatusDeprecated}
+	case model.StatusDeprecated:
+		fromStatuses = []model.Status{model.StatusActive}
+	case model.StatusActive:
+		if reason != "" {
+			return nil, fmt.Errorf("%w: a reason can only be given when deprecating or deleting", database.ErrInvalidInput)
+		}
+		fromStatuses = []model.Status{model.StatusDeprecated}
+	default:
+		return nil, fmt.Errorf("%w: invalid status %q", database.ErrInvalidInput, status)
+	}
+
+	var details *database.StatusDetails
+	if reason != "" {
+		details = &database.StatusDetails{Message: reason}
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) ([]*apiv0.ServerResponse, error) {
+		// Serialize with publishes so no version is published mid-change
+		if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+
+		versions, err := s.db.GetAllVersionsByServerName(ctx, tx, serverName)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, version := range versions {
+			if version.Meta.Official == nil || !slices.Contains(fromStatuses, version.Meta.Official.Status) {
+				continue
+			}
+			updated, err := s.db.SetServerStatus(ctx, tx, serverName, version.Server.Version, string(status), details)
+			if err != nil {
+				return nil, err
+			}
+			versions[i] = updated
+		}
+
+		return s.moveLatestOffDeletedVersion(ctx, tx, serverName, versions)
+	})
+}
+
+// moveLatestOffDeletedVersion marks the highest non-deleted version as latest when the current
+// latest version is deleted. If every version is deleted, no version is marked as latest, so the
+// server no longer has a latest version.
+func (s *registryServiceImpl) moveLatestOffDeletedVersion(ctx context.Context, tx pgx.Tx, serverName string, versions []*apiv0.ServerResponse) ([]*apiv0.ServerResponse, error) {
+	var currentLatest, best *apiv0.ServerResponse
+	for _, version := range versions {
+		if version.Meta.Official == nil {
+			continue
+		}
+		if version.Meta.Official.IsLatest {
+			currentLatest = version
+		}
+		if version.Meta.Official.Status == model.StatusDeleted {
+			continue
+		}
+		if best == nil || versioning.CompareForLatest(
+			versioning.ForServer(&version.Server),
+			version.Server.Version,
+			best.Server.Version,
+			version.Meta.Official.PublishedAt,
+			best.Meta.Official.PublishedAt,
+		) > 0 {
+			best = version
+		}
+	}
+
+	if currentLatest != nil && currentLatest.Meta.Official.Status != model.StatusDeleted {
+		return versions, nil
+	}
+	if currentLatest == nil && best == nil {
+		return versions, nil
+	}
+
+	if err := s.db.UnmarkAsLatest(ctx, tx, serverName); err != nil {
+		return nil, err
+	}
+	if best != nil {
+		if err := s.db.MarkAsLatest(ctx, tx, serverName, best.Server.Version); err != nil {
+			return nil, err
+		}
+	}
+
+	// Reload so the returned versions reflect the new latest marker
+	return s.db.GetAllVersionsByServerName(ctx, tx, serverName)
+}
+
+// validateServerStatusRequest checks the parts of a status change that do not need the database
+func validateServerStatusRequest(serverName, version string, req *apiv0.ServerStatusRequest) error {
+	switch req.Status {
+	case model.StatusDeprecated:
+	case model.StatusActive:
+		if req.Message != "" || req.ReplacedBy != nil {
+			return fmt.Errorf("%w: a message or replacement can only be given when deprecating", database.ErrInvalidInput)
+		}
+	default:
+		return fmt.Errorf("%w: status must be %q or %q", database.ErrInvalidInput, model.StatusActive, model.StatusDeprecated)
+	}
+
+	if req.ReplacedBy != nil {
+		if req.ReplacedBy.ServerName == "" {
+			return fmt.Errorf("%w: replacement server name is required", database.ErrInvalidInput)
+		}
+		if req.ReplacedBy.ServerName == serverName && req.ReplacedBy.Version == version {
+			return fmt.Errorf("%w: a version cannot be replaced by itself", database.ErrInvalidInput)
+		}
+	}
+
+	return nil
+}
+
+// validateReplacement checks that a replacement points at a published, non-deleted server or version
+func (s *registryServiceImpl) validateReplacement(ctx context.Context, tx pgx.Tx, replacement *apiv0.ServerReference) error {
+	var target *apiv0.ServerResponse
+	var err error
+	if replacement.Version != "" {
+		target, err = s.db.GetServerByNameAndVersion(ctx, tx, replacement.ServerName, replacement.Version)
+	} else {
+		target, err = s.db.GetServerByName(ctx, tx, replacement.ServerName)
+	}
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return fmt.Errorf("%w: replacement %s not found", database.ErrInvalidInput, formatServerReference(replacement))
+		}
+		return err
+	}
+
+	if target.Meta.Official != nil && target.Meta.Official.Status == model.StatusDeleted {
+		return fmt.Errorf("%w: replacement %s is deleted", database.ErrInvalidInput, formatServerReference(replacement))
+	}
+
+	return nil
+}
+
+func formatServerReference(ref *apiv0.ServerReference) string {
+	if ref.Version == "" {
+		return ref.ServerName
+	}
+	return ref.ServerName + "@" + ref.Version
+}
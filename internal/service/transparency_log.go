@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/canonicaljson"
+	"github.com/modelcontextprotocol/registry/pkg/transparency"
+)
+
+// appendLogEntry records a stored server.json in the transparency log, in the transaction that stores it
+func (s *registryServiceImpl) appendLogEntry(ctx context.Context, tx pgx.Tx, action string, revision *apiv0.ServerRevision) error {
+	digest, err := canonicaljson.Digest(&revision.Server)
+	if err != nil {
+		return fmt.Errorf("failed to digest server JSON: %w", err)
+	}
+
+	_, err = s.db.AppendLogEntry(ctx, tx, &transparency.LogEntry{
+		ServerName: revision.Server.Name,
+		Version:    revision.Server.Version,
+		Revision:   revision.Revision,
+		Action:     action,
+		SHA256:     digest,
+		Timestamp:  time.Now().UTC(),
+	})
+	return err
+}
+
+// GetLogTreeHead returns the current size and root hash of the transparency log, unsigned
+func (s *registryServiceImpl) GetLogTreeHead(ctx context.Context) (*transparency.SignedTreeHead, error) {
+	size, err := s.db.GetLogTreeSize(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.logTreeHead(ctx, size)
+}
+
+// GetLogInclusionProof proves that the latest log entry of a server version, or the entry of a
+// specific revision if revision > 0, is in the log of treeSize entries (0 for the current size)
+func (s *registryServiceImpl) GetLogInclusionProof(ctx context.Context, serverName, version string, revision int, treeSize int64) (*transparency.InclusionProof, error) {
+	size, err := s.logSize(ctx, treeSize)
+	if err != nil {
+		return nil, err
+	}
+
+	index, entry, err := s.db.FindLogEntry(ctx, nil, serverName, version, revision)
+	if err != nil {
+		return nil, err
+	}
+	if index >= size {
+		return nil, fmt.Errorf("%w: the log entry has index %d, which is not in a tree of size %d", database.ErrInvalidInput, index, size)
+	}
+
+	hashes, err := transparency.ProveInclusion(ctx, index, size, s.logSubtreeHash)
+	if err != nil {
+		return nil, err
+	}
+	head, err := s.logTreeHead(ctx, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transparency.InclusionProof{
+		LogIndex: index,
+		Entry:    *entry,
+		Hashes:   hashes,
+		TreeHead: *head,
+	}, nil
+}
+
+// GetLogConsistencyProof proves that the log of first entries is a prefix of the log of
+// second entries (0 for the current size)
+func (s *registryServiceImpl) GetLogConsistencyProof(ctx context.Context, first, second int64) (*transparency.ConsistencyProof, error) {
+	size, err := s.logSize(ctx, second)
+	if err != nil {
+		return nil, err
+	}
+	if first < 1 || first > size {
+		return nil, fmt.Errorf("%w: first tree size must be between 1 and %d", database.ErrInvalidInput, size)
+	}
+
+	hashes, err := transparency.ProveConsistency(ctx, first, size, s.logSubtreeHash)
+	if err != nil {
+		return nil, err
+	}
+	firstHead, err := s.logTreeHead(ctx, first)
+	if err != nil {
+		return nil, err
+	}
+	secondHead, err := s.logTreeHead(ctx, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transparency.ConsistencyProof{
+		First:  *firstHead,
+		Second: *secondHead,
+		Hashes: hashes,
+	}, nil
+}
+
+// logSize resolves a requested tree size, where 0 is the current size
+func (s *registryServiceImpl) logSize(ctx context.Context, treeSize int64) (int64, error) {
+	size, err := s.db.GetLogTreeSize(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	if treeSize == 0 {
+		return size, nil
+	}
+	if treeSize < 0 || treeSize > size {
+		return 0, fmt.Errorf("%w: tree size must be between 1 and the log size %d", database.ErrInvalidInput, size)
+	}
+	return treeSize, nil
+}
+
+func (s *registryServiceImpl) logTreeHead(ctx context.Context, size int64) (*transparency.SignedTreeHead, error) {
+	root, err := transparency.RootHash(ctx, size, s.logSubtreeHash)
+	if err != nil {
+		return nil, err
+	}
+	return &transparency.SignedTreeHead{
+		TreeHead: transparency.TreeHead{TreeSize: size, RootHash: root},
+	}, nil
+}
+
+// logSubtreeHash reads the complete subtrees proofs are built from. They never change once
+// written, so reads outside of a transaction see a consistent tree for any size up to the
+// size read before.
+func (s *registryServiceImpl) logSubtreeHash(ctx context.Context, level int, index int64) ([]byte, error) {
+	return s.db.GetLogSubtreeHash(ctx, nil, level, index)
+}
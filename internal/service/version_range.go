@@ -0,0 +1,337 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/versioning"
+)
+
+// VersionRange is a parsed npm-style semantic version range.
+// Supported syntaxes match those rejected by publish validation as "range-like":
+// caret (^1.2.3), tilde (~1.2.3), comparators (>=1.0.0 <2.0.0), x-ranges (1.x, 1.2.*),
+// hyphen ranges (1.2.3 - 2.0.0) and unions of any of these (^1.0.0 || ^2.0.0).
+type VersionRange struct {
+	// sets are OR-ed together; the comparators within a set are AND-ed
+	sets [][]versionComparator
+}
+
+// versionComparator is a single primitive comparison such as ">=1.2.3"
+type versionComparator struct {
+	op      string // one of "<", "<=", ">", ">=", "="
+	version string // full semantic version without "v" prefix
+	// explicitPrerelease is set when the user wrote a prerelease on this comparator,
+	// which opts prereleases of the same major.minor.patch into matching (npm semantics)
+	explicitPrerelease bool
+}
+
+var (
+	// partialVersionRe matches a possibly partial version with x-range wildcards
+	partialVersionRe = regexp.MustCompile(`^v?(\*|x|X|0|[1-9]\d*)(?:\.(\*|x|X|0|[1-9]\d*)(?:\.(\*|x|X|0|[1-9]\d*)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?)?)?$`)
+	// operatorSpaceRe removes whitespace between an operator and its version (">= 1.2.3")
+	operatorSpaceRe = regexp.MustCompile(`(<=|>=|<|>|=|~|\^)\s+`)
+	// hyphenRangeSplitRe splits a hyphen range ("1.2.3 - 2.3.4")
+	hyphenRangeSplitRe = regexp.MustCompile(`^(\S+)\s+-\s+(\S+)$`)
+)
+
+// partialVersion is a parsed, possibly incomplete version; nil parts are wildcards or missing
+type partialVersion struct {
+	major, minor, patch *int
+	prerelease          string
+}
+
+// ParseVersionRange parses an npm-style semantic version range
+func ParseVersionRange(constraint string) (*VersionRange, error) {
+	constraint = strings.TrimSpace(constraint)
+	if len(constraint) > 256 {
+		return nil, fmt.Errorf("version range is too long")
+	}
+
+	vr := &VersionRange{}
+	for _, part := range strings.Split(constraint, "||") {
+		set, err := parseComparatorSet(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid version range %q: %w", constraint, err)
+		}
+		vr.sets = append(vr.sets, set)
+	}
+
+	return vr, nil
+}
+
+// Matches reports whether a version satisfies the range.
+// Only semantic versions can match. Prereleases only match when a comparator in the same
+// set explicitly names a prerelease of the same major.minor.patch, so "^1.0.0" never
+// resolves to "1.1.0-beta".
+func (vr *VersionRange) Matches(version string) bool {
+	if !IsSemanticVersion(version) {
+		return false
+	}
+
+	for _, set := range vr.sets {
+		if setMatches(set, version) {
+			return true
+		}
+	}
+	return false
+}
+
+func setMatches(set []versionComparator, version string) bool {
+	for _, c := range set {
+		if !c.matches(version) {
+			return false
+		}
+	}
+
+	if !versioning.IsPrerelease(versioning.Semver, version) {
+		return true
+	}
+
+	// Prereleases need an explicit opt-in on the same major.minor.patch tuple
+	core := versionCore(version)
+	for _, c := range set {
+		if c.explicitPrerelease && versionCore(c.version) == core {
+			return true
+		}
+	}
+	return false
+}
+
+func (c versionComparator) matches(version string) bool {
+	cmp := compareSemanticVersions(version, c.version)
+	switch c.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	default:
+		return cmp == 0
+	}
+}
+
+// versionCore strips the "v" prefix, prerelease and build metadata from a semantic version
+func versionCore(version string) string {
+	core := strings.TrimPrefix(version, "v")
+	if idx := strings.IndexAny(core, "-+"); idx != -1 {
+		core = core[:idx]
+	}
+	return core
+}
+
+func parseComparatorSet(input string) ([]versionComparator, error) {
+	// Empty ranges and bare wildcards match every stable version
+	if input == "" {
+		return []versionComparator{{op: ">=", version: "0.0.0"}}, nil
+	}
+
+	if m := hyphenRangeSplitRe.FindStringSubmatch(input); m != nil {
+		return parseHyphenRange(m[1], m[2])
+	}
+
+	input = operatorSpaceRe.ReplaceAllString(input, "$1")
+
+	var set []versionComparator
+	for _, token := range strings.Fields(input) {
+		comparators, err := parseRangeToken(token)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, comparators...)
+	}
+	return set, nil
+}
+
+func parseRangeToken(token string) ([]versionComparator, error) {
+	switch {
+	case strings.HasPrefix(token, "^"):
+		pv, err := parsePartialVersion(token[1:])
+		if err != nil {
+			return nil, err
+		}
+		return caretComparators(pv), nil
+	case strings.HasPrefix(token, "~"):
+		// Accept npm's "~>" alias
+		pv, err := parsePartialVersion(strings.TrimPrefix(token[1:], ">"))
+		if err != nil {
+			return nil, err
+		}
+		return tildeComparators(pv), nil
+	}
+
+	op := ""
+	for _, candidate := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(token, candidate) {
+			op = candidate
+			break
+		}
+	}
+
+	pv, err := parsePartialVersion(token[len(op):])
+	if err != nil {
+		return nil, err
+	}
+	return primitiveComparators(op, pv), nil
+}
+
+func parseHyphenRange(from, to string) ([]versionComparator, error) {
+	lower, err := parsePartialVersion(from)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := parsePartialVersion(to)
+	if err != nil {
+		return nil, err
+	}
+
+	var set []versionComparator
+	if lower.major != nil {
+		set = append(set, versionComparator{op: ">=", version: lower.floor(), explicitPrerelease: lower.prerelease != ""})
+	}
+	switch {
+	case upper.major == nil:
+		// Open upper bound
+	case upper.patch != nil:
+		set = append(set, versionComparator{op: "<=", version: upper.floor(), explicitPrerelease: upper.prerelease != ""})
+	default:
+		set = append(set, versionComparator{op: "<", version: upper.nextBoundary()})
+	}
+
+	if len(set) == 0 {
+		set = append(set, versionComparator{op: ">=", version: "0.0.0"})
+	}
+	return set, nil
+}
+
+func parsePartialVersion(input string) (partialVersion, error) {
+	m := partialVersionRe.FindStringSubmatch(input)
+	if m == nil {
+		return partialVersion{}, fmt.Errorf("%q is not a valid version", input)
+	}
+
+	var pv partialVersion
+	parts := []**int{&pv.major, &pv.minor, &pv.patch}
+	for i, raw := range m[1:4] {
+		if raw == "" || raw == "*" || raw == "x" || raw == "X" {
+			break
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return partialVersion{}, fmt.Errorf("%q is not a valid version", input)
+		}
+		*parts[i] = &n
+	}
+
+	// A prerelease is only meaningful on a complete version
+	if pv.patch != nil {
+		pv.prerelease = m[4]
+	}
+	return pv, nil
+}
+
+// floor returns the lowest version matched by the partial version (e.g. "1.2" -> "1.2.0")
+func (pv partialVersion) floor() string {
+	v := fmt.Sprintf("%d.%d.%d", deref(pv.major), deref(pv.minor), deref(pv.patch))
+	if pv.prerelease != "" {
+		v += "-" + pv.prerelease
+	}
+	return v
+}
+
+// nextBoundary returns the exclusive upper bound of a partial version, such that
+// every version "inside" it sorts below ("1" -> "2.0.0-0", "1.2" -> "1.3.0-0")
+func (pv partialVersion) nextBoundary() string {
+	switch {
+	case pv.minor == nil:
+		return fmt.Sprintf("%d.0.0-0", deref(pv.major)+1)
+	case pv.patch == nil:
+		return fmt.Sprintf("%d.%d.0-0", deref(pv.major), deref(pv.minor)+1)
+	default:
+		return fmt.Sprintf("%d.%d.%d-0", deref(pv.major), deref(pv.minor), deref(pv.patch)+1)
+	}
+}
+
+// primitiveComparators desugars "<op><partial>" into comparators on complete versions
+func primitiveComparators(op string, pv partialVersion) []versionComparator {
+	explicit := pv.prerelease != ""
+
+	if pv.major == nil {
+		// "*", ">=*" and "<=*" match everything; "<*" and ">*" match nothing
+		if op == "<" || op == ">" {
+			return []versionComparator{{op: "<", version: "0.0.0-0"}}
+		}
+		return []versionComparator{{op: ">=", version: "0.0.0"}}
+	}
+
+	if pv.patch != nil {
+		if op == "" {
+			op = "="
+		}
+		return []versionComparator{{op: op, version: pv.floor(), explicitPrerelease: explicit}}
+	}
+
+	// Partial versions behave like x-ranges
+	switch op {
+	case "", "=":
+		return []versionComparator{
+			{op: ">=", version: pv.floor()},
+			{op: "<", version: pv.nextBoundary()},
+		}
+	case ">":
+		return []versionComparator{{op: ">=", version: pv.nextBoundary()}}
+	case ">=":
+		return []versionComparator{{op: ">=", version: pv.floor()}}
+	case "<":
+		return []versionComparator{{op: "<", version: pv.floor() + "-0"}}
+	default: // "<="
+		return []versionComparator{{op: "<", version: pv.nextBoundary()}}
+	}
+}
+
+// tildeComparators desugars "~1.2.3" (patch-level changes) into comparators
+func tildeComparators(pv partialVersion) []versionComparator {
+	if pv.major == nil {
+		return []versionComparator{{op: ">=", version: "0.0.0"}}
+	}
+
+	upper := partialVersion{major: pv.major, minor: pv.minor}
+	return []versionComparator{
+		{op: ">=", version: pv.floor(), explicitPrerelease: pv.prerelease != ""},
+		{op: "<", version: upper.nextBoundary()},
+	}
+}
+
+// caretComparators desugars "^1.2.3" (changes that do not modify the left-most non-zero
+// component) into comparators
+func caretComparators(pv partialVersion) []versionComparator {
+	if pv.major == nil {
+		return []versionComparator{{op: ">=", version: "0.0.0"}}
+	}
+
+	var upper partialVersion
+	switch {
+	case *pv.major != 0 || pv.minor == nil:
+		upper = partialVersion{major: pv.major}
+	case *pv.minor != 0 || pv.patch == nil:
+		upper = partialVersion{major: pv.major, minor: pv.minor}
+	default:
+		upper = partialVersion{major: pv.major, minor: pv.minor, patch: pv.patch}
+	}
+
+	return []versionComparator{
+		{op: ">=", version: pv.floor(), explicitPrerelease: pv.prerelease != ""},
+		{op: "<", version: upper.nextBoundary()},
+	}
+}
+
+func deref(n *int) int {
+	if n == nil {
+		return 0
+	}
+	return *n
+}
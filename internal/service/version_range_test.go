@@ -0,0 +1,102 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+func TestVersionRangeMatches(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		// Caret ranges
+		{"^1.2.3", "1.2.3", true},
+		{"^1.2.3", "1.9.0", true},
+		{"^1.2.3", "2.0.0", false},
+		{"^1.2.3", "1.2.2", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.3", true},
+		{"^0.0.3", "0.0.4", false},
+		{"^1.x", "1.5.0", true},
+		{"^0.x", "0.9.0", true},
+		{"^0.x", "1.0.0", false},
+
+		// Tilde ranges
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1.2", "1.2.0", true},
+		{"~1", "1.9.9", true},
+		{"~1", "2.0.0", false},
+
+		// Comparator ranges
+		{">=1.0.0", "1.0.0", true},
+		{">=1.0.0 <2.0.0", "1.5.0", true},
+		{">=1.0.0 <2.0.0", "2.0.0", false},
+		{">= 1.0.0 < 2.0.0", "1.5.0", true},
+		{">1.2", "1.2.9", false},
+		{">1.2", "1.3.0", true},
+		{"<=1.2", "1.2.9", true},
+		{"<=1.2", "1.3.0", false},
+		{"<1.2", "1.1.9", true},
+		{"<1.2", "1.2.0", false},
+		{"=1.2.3", "1.2.3", true},
+		{"1.2.3", "1.2.4", false},
+
+		// X-ranges
+		{"1.x", "1.4.0", true},
+		{"1.x", "2.0.0", false},
+		{"1.2.*", "1.2.7", true},
+		{"1.2.*", "1.3.0", false},
+		{"*", "3.1.4", true},
+		{"", "3.1.4", true},
+
+		// Hyphen ranges
+		{"1.2.3 - 2.3.4", "2.3.4", true},
+		{"1.2.3 - 2.3.4", "2.3.5", false},
+		{"1.2 - 2.3", "2.3.9", true},
+		{"1.2 - 2.3", "2.4.0", false},
+		{"1.2 - 2.3", "1.1.9", false},
+
+		// Unions
+		{"^1.0.0 || ^3.0.0", "3.1.0", true},
+		{"^1.0.0 || ^3.0.0", "2.1.0", false},
+
+		// Prereleases only match when explicitly requested on the same tuple
+		{"^1.0.0", "1.1.0-beta", false},
+		{"^1.2.3-beta.1", "1.2.3-beta.2", true},
+		{"^1.2.3-beta.1", "1.2.4-beta.1", false},
+		{"^1.2.3-beta.1", "1.5.0", true},
+		{">=2.0.0-rc.1", "2.0.0-rc.2", true},
+		{"<2.0.0", "2.0.0-beta", false},
+
+		// Non-semver versions never match
+		{"*", "snapshot", false},
+		{"^1.0.0", "1.0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint+" "+tt.version, func(t *testing.T) {
+			vr, err := service.ParseVersionRange(tt.constraint)
+			if err != nil {
+				t.Fatalf("ParseVersionRange(%q) returned error: %v", tt.constraint, err)
+			}
+			if got := vr.Matches(tt.version); got != tt.want {
+				t.Errorf("ParseVersionRange(%q).Matches(%q) = %v, want %v", tt.constraint, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseVersionRangeInvalid(t *testing.T) {
+	for _, constraint := range []string{"^", "~abc", ">=1.a", "1.2.3.4", "latest", "^1.0.0 || >=x.y"} {
+		t.Run(constraint, func(t *testing.T) {
+			if _, err := service.ParseVersionRange(constraint); err == nil {
+				t.Errorf("ParseVersionRange(%q) expected error, got nil", constraint)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/modelcontextprotocol/registry/internal/versioning"
 	"golang.org/x/mod/semver"
 )
 
@@ -59,28 +60,8 @@ func compareSemanticVersions(version1 string, version2 string) int {
 // 1. If both versions are valid semver, use semantic version comparison
 // 2. If neither are valid semver, use publication timestamp (return 0 to indicate equal for sorting)
 // 3. If one is semver and one is not, the semver version is always considered higher
+//
+// Servers using another version scheme are compared with versioning.Compare.
 func CompareVersions(version1 string, version2 string, timestamp1 time.Time, timestamp2 time.Time) int {
-	isSemver1 := IsSemanticVersion(version1)
-	isSemver2 := IsSemanticVersion(version2)
-
-	if isSemver1 && isSemver2 {
-		// Both are semver - use semantic comparison
-		return compareSemanticVersions(version1, version2)
-	}
-
-	if !isSemver1 && !isSemver2 {
-		// Neither are semver - use timestamp comparison
-		if timestamp1.Before(timestamp2) {
-			return -1
-		} else if timestamp1.After(timestamp2) {
-			return 1
-		}
-		return 0
-	}
-
-	// One is semver, one is not - semver is always higher
-	if isSemver1 && !isSemver2 {
-		return 1
-	}
-	return -1
+	return versioning.Compare(versioning.Semver, version1, version2, timestamp1, timestamp2)
 }
@@ -0,0 +1,95 @@
+package snapshot
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Publisher periodically produces signed manifests of every server version in the registry
+type Publisher struct {
+	registry   service.RegistryService
+	signingKey ed25519.PrivateKey
+	interval   time.Duration
+
+	mu     sync.RWMutex
+	latest *SignedManifest
+}
+
+// NewPublisher creates a publisher that signs manifests with signingKey and, once running,
+// produces a new manifest every interval
+func NewPublisher(registry service.RegistryService, signingKey ed25519.PrivateKey, interval time.Duration) *Publisher {
+	return &Publisher{registry: registry, signingKey: signingKey, interval: interval}
+}
+
+// Latest returns the last signed manifest, or nil if none has been generated yet
+func (p *Publisher) Latest() *SignedManifest {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.latest
+}
+
+// Refresh generates and signs a new manifest, which replaces the latest one
+func (p *Publisher) Refresh(ctx context.Context) error {
+	manifest, err := BuildManifest(ctx, p.registry)
+	if err != nil {
+		return err
+	}
+	signed, err := Sign(manifest, p.signingKey)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.latest = signed
+	return nil
+}
+
+// Run generates a manifest immediately and then every interval until ctx is done. The previous
+// manifest is served until a new one is ready, so requests never wait for an export.
+func (p *Publisher) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.Refresh(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Failed to generate snapshot manifest: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// BuildManifest lists every server version in the registry, including deleted ones, with its digest
+func BuildManifest(ctx context.Context, registry service.RegistryService) (*Manifest, error) {
+	manifest := &Manifest{
+		Format:      ManifestFormat,
+		GeneratedAt: time.Now().UTC(),
+		Servers:     []ManifestServer{},
+	}
+
+	err := registry.ExportServers(ctx, nil, func(server *apiv0.ServerResponse) error {
+		entry, err := manifestServer(server)
+		if err != nil {
+			return err
+		}
+		manifest.Servers = append(manifest.Servers, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export servers: %w", err)
+	}
+
+	return manifest, nil
+}
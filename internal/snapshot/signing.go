@@ -0,0 +1,111 @@
+package snapshot
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/pkg/canonicaljson"
+)
+
+// SignatureAlgorithm is the only algorithm snapshot manifests are signed with
+const SignatureAlgorithm = "ed25519"
+
+var (
+	// ErrUnsigned is returned when verifying a manifest without a signature
+	ErrUnsigned = errors.New("snapshot manifest is not signed")
+	// ErrInvalidSignature is returned when a manifest signature does not verify with the expected key
+	ErrInvalidSignature = errors.New("snapshot manifest signature is invalid")
+)
+
+// SignedManifest is a manifest with the signature over its canonical JSON encoding
+type SignedManifest struct {
+	Manifest  Manifest   `json:"manifest"`
+	Signature *Signature `json:"signature,omitempty"`
+}
+
+// Signature is an Ed25519 signature over the canonical JSON (RFC 8785) encoding of a manifest.
+// PublicKey identifies the signing key; verifiers must compare it to a key they trust.
+type Signature struct {
+	Algorithm string `json:"algorithm"`
+	PublicKey string `json:"publicKey"`
+	Value     string `json:"value"`
+}
+
+// Sign signs a manifest; a nil key leaves it unsigned
+func Sign(manifest *Manifest, key ed25519.PrivateKey) (*SignedManifest, error) {
+	signed := &SignedManifest{Manifest: *manifest}
+	if key == nil {
+		return signed, nil
+	}
+
+	payload, err := canonicaljson.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	signed.Signature = &Signature{
+		Algorithm: SignatureAlgorithm,
+		PublicKey: base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey)),
+		Value:     base64.StdEncoding.EncodeToString(ed25519.Sign(key, payload)),
+	}
+	return signed, nil
+}
+
+// Verify checks that the manifest is signed by publicKey
+func (m *SignedManifest) Verify(publicKey ed25519.PublicKey) error {
+	if m.Signature == nil {
+		return ErrUnsigned
+	}
+	if m.Signature.Algorithm != SignatureAlgorithm {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidSignature, m.Signature.Algorithm)
+	}
+	if m.Signature.PublicKey != base64.StdEncoding.EncodeToString(publicKey) {
+		return fmt.Errorf("%w: signed by an unexpected key", ErrInvalidSignature)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(m.Signature.Value)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	payload, err := canonicaljson.Marshal(&m.Manifest)
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if !ed25519.Verify(publicKey, payload, signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// SigningKey returns the key snapshot manifests are signed with: the snapshot signing key if one is
+// configured, otherwise the registry's JWT signing key. Both are hex-encoded 32-byte Ed25519 seeds.
+func SigningKey(cfg *config.Config) (ed25519.PrivateKey, error) {
+	seedHex := cfg.SnapshotSigningKey
+	if seedHex == "" {
+		seedHex = cfg.JWTPrivateKey
+	}
+
+	seed, err := hex.DecodeString(seedHex)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot signing key must be a valid hex-encoded string: %w", err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("snapshot signing key seed must be exactly %d bytes for Ed25519, got %d bytes", ed25519.SeedSize, len(seed))
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// ParsePublicKey parses a base64-encoded Ed25519 public key, as published in signed manifests
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	key, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("public key must be base64-encoded: %w", err)
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("public key must be exactly %d bytes for Ed25519, got %d bytes", ed25519.PublicKeySize, len(key))
+	}
+	return ed25519.PublicKey(key), nil
+}
@@ -0,0 +1,137 @@
+package snapshot_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/snapshot"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	publicKey := testSigningKey.Public().(ed25519.PublicKey)
+	manifest := &snapshot.Manifest{
+		Format:      snapshot.ManifestFormat,
+		GeneratedAt: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+		Servers: []snapshot.ManifestServer{
+			{Name: "com.example/server", Version: "1.0.0", SHA256: "0000000000000000000000000000000000000000000000000000000000000000"},
+		},
+	}
+
+	signed, err := snapshot.Sign(manifest, testSigningKey)
+	require.NoError(t, err)
+	require.NotNil(t, signed.Signature)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(publicKey), signed.Signature.PublicKey)
+
+	t.Run("valid signature survives a JSON round trip", func(t *testing.T) {
+		data, err := json.Marshal(signed)
+		require.NoError(t, err)
+		var decoded snapshot.SignedManifest
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.NoError(t, decoded.Verify(publicKey))
+	})
+
+	t.Run("tampered manifest", func(t *testing.T) {
+		tampered := *signed
+		tampered.Manifest.Servers = []snapshot.ManifestServer{{Name: "com.example/server", Version: "1.0.0", SHA256: "ff"}}
+		assert.ErrorIs(t, tampered.Verify(publicKey), snapshot.ErrInvalidSignature)
+	})
+
+	t.Run("unexpected key", func(t *testing.T) {
+		otherKey := ed25519.NewKeyFromSeed(append(make([]byte, ed25519.SeedSize-1), 1))
+		assert.ErrorIs(t, signed.Verify(otherKey.Public().(ed25519.PublicKey)), snapshot.ErrInvalidSignature)
+	})
+
+	t.Run("unsigned manifest", func(t *testing.T) {
+		unsigned, err := snapshot.Sign(manifest, nil)
+		require.NoError(t, err)
+		assert.ErrorIs(t, unsigned.Verify(publicKey), snapshot.ErrUnsigned)
+	})
+}
+
+func TestSigningKey(t *testing.T) {
+	jwtKey := "0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"
+	snapshotKey := "2122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f40"
+
+	key, err := snapshot.SigningKey(&config.Config{JWTPrivateKey: jwtKey})
+	require.NoError(t, err)
+	assert.Equal(t, jwtKey, hexSeed(key))
+
+	key, err = snapshot.SigningKey(&config.Config{JWTPrivateKey: jwtKey, SnapshotSigningKey: snapshotKey})
+	require.NoError(t, err)
+	assert.Equal(t, snapshotKey, hexSeed(key))
+
+	_, err = snapshot.SigningKey(&config.Config{})
+	assert.Error(t, err)
+	_, err = snapshot.SigningKey(&config.Config{SnapshotSigningKey: "not-hex"})
+	assert.Error(t, err)
+}
+
+func TestParsePublicKey(t *testing.T) {
+	publicKey := testSigningKey.Public().(ed25519.PublicKey)
+
+	parsed, err := snapshot.ParsePublicKey(base64.StdEncoding.EncodeToString(publicKey))
+	require.NoError(t, err)
+	assert.Equal(t, publicKey, parsed)
+
+	_, err = snapshot.ParsePublicKey("not base64!")
+	assert.Error(t, err)
+	_, err = snapshot.ParsePublicKey(base64.StdEncoding.EncodeToString([]byte("too short")))
+	assert.Error(t, err)
+}
+
+func TestPublisher(t *testing.T) {
+	registry := &exportOnlyRegistry{servers: []*apiv0.ServerResponse{
+		testServer("com.example/alpha", "1.0.0", model.StatusActive, true),
+	}}
+
+	publisher := snapshot.NewPublisher(registry, testSigningKey, time.Hour)
+	assert.Nil(t, publisher.Latest())
+
+	require.NoError(t, publisher.Refresh(context.Background()))
+	first := publisher.Latest()
+	require.NotNil(t, first)
+	require.NoError(t, first.Verify(testSigningKey.Public().(ed25519.PublicKey)))
+	require.Len(t, first.Manifest.Servers, 1)
+
+	// Until the next refresh the same manifest is served, even if the registry changed
+	registry.servers = append(registry.servers, testServer("com.example/beta", "1.0.0", model.StatusActive, true))
+	assert.Same(t, first, publisher.Latest())
+
+	require.NoError(t, publisher.Refresh(context.Background()))
+	assert.Len(t, publisher.Latest().Manifest.Servers, 2)
+}
+
+func TestPublisherRun(t *testing.T) {
+	registry := &exportOnlyRegistry{servers: []*apiv0.ServerResponse{
+		testServer("com.example/alpha", "1.0.0", model.StatusActive, true),
+	}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	publisher := snapshot.NewPublisher(registry, testSigningKey, time.Hour)
+	done := make(chan struct{})
+	go func() {
+		publisher.Run(ctx)
+		close(done)
+	}()
+
+	// A manifest is generated as soon as the publisher runs, without waiting for the interval
+	require.Eventually(t, func() bool { return publisher.Latest() != nil }, 5*time.Second, 10*time.Millisecond)
+	assert.Len(t, publisher.Latest().Manifest.Servers, 1)
+
+	cancel()
+	<-done
+}
+
+func hexSeed(key ed25519.PrivateKey) string {
+	return hex.EncodeToString(key.Seed())
+}
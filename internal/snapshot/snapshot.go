@@ -0,0 +1,322 @@
+// Package snapshot renders the read API of the registry as a static file tree, so that a
+// plain static web server or object store can serve registry reads, and produces signed
+// manifests of the canonical digest of every server version, so that mirrors can verify the
+// data they copy came from the registry.
+package snapshot
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/canonicaljson"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+const (
+	// ManifestFormat is the version of the manifest format
+	ManifestFormat = 1
+	// ManifestName is the file name of the manifest, at the root of the snapshot
+	ManifestName = "manifest.json"
+	// IndexFile is the file a directory-style URL path is served from
+	IndexFile = "index.json"
+	// PagesDir holds the server list pages after the first, as <n>.json. Namespaces cannot
+	// start with an underscore, so it never collides with a server directory.
+	PagesDir = "_pages"
+)
+
+// Manifest lists every server version with the digest of its server.json and, for static
+// snapshots, indexes the files of the snapshot with their content hashes
+type Manifest struct {
+	Format      int              `json:"format"`
+	GeneratedAt time.Time        `json:"generatedAt"`
+	Prefix      string           `json:"prefix,omitempty"`
+	PageSize    int              `json:"pageSize,omitempty"`
+	Servers     []ManifestServer `json:"servers"`
+	Files       []ManifestFile   `json:"files,omitempty"`
+}
+
+// ManifestServer is a server version with the SHA-256 of the canonical JSON (RFC 8785)
+// encoding of its server.json
+type ManifestServer struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	SHA256  string `json:"sha256"`
+}
+
+// ManifestFile is a file of a snapshot, with its path relative to the snapshot root
+type ManifestFile struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Generator renders registry snapshots
+type Generator struct {
+	registry   service.RegistryService
+	prefix     string
+	pageSize   int
+	signingKey ed25519.PrivateKey
+}
+
+// NewGenerator creates a generator for the read API under prefix (e.g. "v0"), with pageSize
+// servers per server list page. Manifests are signed with signingKey, unless it is nil.
+func NewGenerator(registry service.RegistryService, prefix string, pageSize int, signingKey ed25519.PrivateKey) *Generator {
+	return &Generator{registry: registry, prefix: prefix, pageSize: pageSize, signingKey: signingKey}
+}
+
+// snapshotWriter writes the files of a snapshot and records them for the manifest
+type snapshotWriter struct {
+	root  string
+	files []ManifestFile
+}
+
+// Generate renders the snapshot into outputDir, which must be empty or not exist yet, and
+// returns its signed manifest. Everything is read in one pass from a consistent registry snapshot:
+//
+//	<prefix>/servers/index.json                                first server list page
+//	<prefix>/servers/_pages/<n>.json                           server list page n, for ?cursor=<n>
+//	<prefix>/servers/<name>/versions/index.json                every version of a server
+//	<prefix>/servers/<name>/versions/<version>/index.json      a version, including "latest"
+//
+// Pagination is deterministic: the same registry content always yields the same pages.
+func (g *Generator) Generate(ctx context.Context, outputDir string) (*SignedManifest, error) {
+	if g.pageSize <= 0 {
+		return nil, fmt.Errorf("page size must be positive")
+	}
+	if err := checkEmptyDir(outputDir); err != nil {
+		return nil, err
+	}
+
+	w := &snapshotWriter{root: outputDir}
+	pages := &pageWriter{generator: g, writer: w}
+	var serverVersions []*apiv0.ServerResponse
+	manifestServers := []ManifestServer{}
+
+	// Versions arrive ordered by server name, then version, so each server is complete when the name changes
+	err := g.registry.ExportServers(ctx, nil, func(server *apiv0.ServerResponse) error {
+		if len(serverVersions) > 0 && serverVersions[0].Server.Name != server.Server.Name {
+			if err := g.writeServer(w, serverVersions); err != nil {
+				return err
+			}
+			serverVersions = nil
+		}
+		serverVersions = append(serverVersions, server)
+
+		entry, err := manifestServer(server)
+		if err != nil {
+			return err
+		}
+		manifestServers = append(manifestServers, entry)
+
+		// Like the server list, pages leave out deleted versions
+		if server.Meta.Official != nil && server.Meta.Official.Status == model.StatusDeleted {
+			return nil
+		}
+		return pages.add(server)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export servers: %w", err)
+	}
+	if len(serverVersions) > 0 {
+		if err := g.writeServer(w, serverVersions); err != nil {
+			return nil, err
+		}
+	}
+	if err := pages.flush(true); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(w.files, func(i, j int) bool { return w.files[i].Path < w.files[j].Path })
+	manifest := &Manifest{
+		Format:      ManifestFormat,
+		GeneratedAt: time.Now().UTC(),
+		Prefix:      g.prefix,
+		PageSize:    g.pageSize,
+		Servers:     manifestServers,
+		Files:       w.files,
+	}
+	signed, err := Sign(manifest, g.signingKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeJSONFile(filepath.Join(outputDir, ManifestName), signed); err != nil {
+		return nil, err
+	}
+
+	return signed, nil
+}
+
+// manifestServer returns the manifest entry of a server version
+func manifestServer(server *apiv0.ServerResponse) (ManifestServer, error) {
+	digest, err := canonicaljson.Digest(&server.Server)
+	if err != nil {
+		return ManifestServer{}, fmt.Errorf("failed to digest %s@%s: %w", server.Server.Name, server.Server.Version, err)
+	}
+	return ManifestServer{Name: server.Server.Name, Version: server.Server.Version, SHA256: digest}, nil
+}
+
+// writeServer writes the version list and the version documents of a server
+func (g *Generator) writeServer(w *snapshotWriter, versions []*apiv0.ServerResponse) error {
+	serverName := versions[0].Server.Name
+	if !isSafePath(serverName, 2) {
+		return fmt.Errorf("server name %q cannot be used as a snapshot path", serverName)
+	}
+	versionsDir := path.Join(g.prefix, "servers", serverName, "versions")
+
+	// The version list is ordered like the API default: highest version first
+	listed := slices.Clone(versions)
+	slices.Reverse(listed)
+	if err := w.write(path.Join(versionsDir, IndexFile), serverList(listed, "")); err != nil {
+		return err
+	}
+
+	for _, version := range versions {
+		if !isSafePath(version.Server.Version, 1) || version.Server.Version == "latest" {
+			return fmt.Errorf("version %q of %s cannot be used as a snapshot path", version.Server.Version, serverName)
+		}
+		if err := w.write(path.Join(versionsDir, version.Server.Version, IndexFile), version); err != nil {
+			return err
+		}
+		if version.Meta.Official != nil && version.Meta.Official.IsLatest {
+			if err := w.write(path.Join(versionsDir, "latest", IndexFile), version); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// isSafePath reports whether p consists of exactly n path segments that can be used as directory
+// names without escaping the snapshot tree or colliding with its index files
+func isSafePath(p string, n int) bool {
+	parts := strings.Split(p, "/")
+	if len(parts) != n {
+		return false
+	}
+	for _, part := range parts {
+		if part == "" || part == "." || part == ".." || part == IndexFile || strings.ContainsRune(part, '\\') {
+			return false
+		}
+	}
+	return true
+}
+
+// pageWriter splits the server list into pages of the generator's page size
+type pageWriter struct {
+	generator *Generator
+	writer    *snapshotWriter
+	page      int
+	servers   []*apiv0.ServerResponse
+}
+
+func (p *pageWriter) add(server *apiv0.ServerResponse) error {
+	// A full page is only written once the next server shows there is a next page
+	if len(p.servers) == p.generator.pageSize {
+		if err := p.flush(false); err != nil {
+			return err
+		}
+	}
+	p.servers = append(p.servers, server)
+	return nil
+}
+
+// flush writes the buffered page; the last page has no next cursor
+func (p *pageWriter) flush(last bool) error {
+	p.page++
+	nextCursor := ""
+	if !last {
+		nextCursor = strconv.Itoa(p.page + 1)
+	}
+
+	file := path.Join(p.generator.prefix, "servers", IndexFile)
+	if p.page > 1 {
+		file = path.Join(p.generator.prefix, "servers", PagesDir, strconv.Itoa(p.page)+".json")
+	}
+	if err := p.writer.write(file, serverList(p.servers, nextCursor)); err != nil {
+		return err
+	}
+
+	p.servers = nil
+	return nil
+}
+
+func serverList(servers []*apiv0.ServerResponse, nextCursor string) *apiv0.ServerListResponse {
+	values := make([]apiv0.ServerResponse, len(servers))
+	for i, server := range servers {
+		values[i] = *server
+	}
+	return &apiv0.ServerListResponse{
+		Servers: values,
+		Metadata: apiv0.Metadata{
+			NextCursor: nextCursor,
+			Count:      len(values),
+		},
+	}
+}
+
+// write writes a JSON document to a path relative to the snapshot root and records its hash
+func (w *snapshotWriter) write(relPath string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", relPath, err)
+	}
+
+	file := filepath.Join(w.root, filepath.FromSlash(relPath))
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil { //nolint:gosec // Snapshots are public, world-readable files
+		return fmt.Errorf("failed to create directory for %s: %w", relPath, err)
+	}
+	if err := os.WriteFile(file, data, 0644); err != nil { //nolint:gosec // Snapshots are public, world-readable files
+		return fmt.Errorf("failed to write %s: %w", relPath, err)
+	}
+
+	sum := sha256.Sum256(data)
+	w.files = append(w.files, ManifestFile{
+		Path:   relPath,
+		Size:   int64(len(data)),
+		SHA256: hex.EncodeToString(sum[:]),
+	})
+	return nil
+}
+
+func writeJSONFile(file string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", filepath.Base(file), err)
+	}
+	if err := os.WriteFile(file, append(data, '\n'), 0644); err != nil { //nolint:gosec // Snapshots are public, world-readable files
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(file), err)
+	}
+	return nil
+}
+
+// checkEmptyDir makes sure a snapshot does not mix with the files of an earlier one
+func checkEmptyDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return os.MkdirAll(dir, 0755) //nolint:gosec // Snapshots are public, world-readable files
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read output directory: %w", err)
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("output directory %s is not empty", dir)
+	}
+	return nil
+}
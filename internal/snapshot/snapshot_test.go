@@ -0,0 +1,180 @@
+package snapshot_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/snapshot"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/canonicaljson"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exportOnlyRegistry serves a fixed export, in the order the database exports versions
+type exportOnlyRegistry struct {
+	service.RegistryService
+	servers []*apiv0.ServerResponse
+}
+
+func (r *exportOnlyRegistry) ExportServers(_ context.Context, _ *database.ServerFilter, fn func(*apiv0.ServerResponse) error) error {
+	for _, server := range r.servers {
+		if err := fn(server); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func testServer(name, version string, status model.Status, isLatest bool) *apiv0.ServerResponse {
+	return &apiv0.ServerResponse{
+		Server: apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Snapshot test server",
+			Version:     version,
+		},
+		Meta: apiv0.ResponseMeta{
+			Official: &apiv0.RegistryExtensions{Status: status, IsLatest: isLatest},
+		},
+	}
+}
+
+var testSigningKey = ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+
+func readJSON(t *testing.T, file string, v any) {
+	t.Helper()
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, v))
+}
+
+func TestGenerate(t *testing.T) {
+	registry := &exportOnlyRegistry{servers: []*apiv0.ServerResponse{
+		testServer("com.example/alpha", "1.0.0", model.StatusActive, false),
+		testServer("com.example/alpha", "1.1.0", model.StatusActive, true),
+		testServer("com.example/beta", "1.0.0", model.StatusDeleted, true),
+		testServer("com.example/gamma", "0.1.0", model.StatusDeprecated, true),
+	}}
+	outputDir := filepath.Join(t.TempDir(), "snapshot")
+
+	manifest, err := snapshot.NewGenerator(registry, "v0", 2, testSigningKey).Generate(context.Background(), outputDir)
+	require.NoError(t, err)
+
+	t.Run("server list pages skip deleted versions", func(t *testing.T) {
+		var first, second apiv0.ServerListResponse
+		readJSON(t, filepath.Join(outputDir, "v0", "servers", "index.json"), &first)
+		readJSON(t, filepath.Join(outputDir, "v0", "servers", "_pages", "2.json"), &second)
+
+		require.Len(t, first.Servers, 2)
+		assert.Equal(t, "1.0.0", first.Servers[0].Server.Version)
+		assert.Equal(t, "1.1.0", first.Servers[1].Server.Version)
+		assert.Equal(t, "2", first.Metadata.NextCursor)
+
+		require.Len(t, second.Servers, 1)
+		assert.Equal(t, "com.example/gamma", second.Servers[0].Server.Name)
+		assert.Empty(t, second.Metadata.NextCursor)
+		assert.NoFileExists(t, filepath.Join(outputDir, "v0", "servers", "_pages", "3.json"))
+	})
+
+	t.Run("version lists are ordered highest first", func(t *testing.T) {
+		var versions apiv0.ServerListResponse
+		readJSON(t, filepath.Join(outputDir, "v0", "servers", "com.example", "alpha", "versions", "index.json"), &versions)
+		require.Len(t, versions.Servers, 2)
+		assert.Equal(t, "1.1.0", versions.Servers[0].Server.Version)
+		assert.Equal(t, "1.0.0", versions.Servers[1].Server.Version)
+		assert.Equal(t, 2, versions.Metadata.Count)
+	})
+
+	t.Run("version documents include latest and deleted versions", func(t *testing.T) {
+		var latest, deleted apiv0.ServerResponse
+		readJSON(t, filepath.Join(outputDir, "v0", "servers", "com.example", "alpha", "versions", "latest", "index.json"), &latest)
+		assert.Equal(t, "1.1.0", latest.Server.Version)
+
+		readJSON(t, filepath.Join(outputDir, "v0", "servers", "com.example", "beta", "versions", "1.0.0", "index.json"), &deleted)
+		assert.Equal(t, model.StatusDeleted, deleted.Meta.Official.Status)
+	})
+
+	t.Run("manifest hashes every file", func(t *testing.T) {
+		var signed snapshot.SignedManifest
+		readJSON(t, filepath.Join(outputDir, snapshot.ManifestName), &signed)
+		written := signed.Manifest
+		assert.Equal(t, manifest.Manifest.Files, written.Files)
+		assert.Equal(t, snapshot.ManifestFormat, written.Format)
+		assert.Equal(t, "v0", written.Prefix)
+		assert.Equal(t, 2, written.PageSize)
+
+		// 2 pages, and 3 servers with a version list, their versions and a latest document
+		assert.Len(t, written.Files, 2+3+4+3)
+		for _, file := range written.Files {
+			data, err := os.ReadFile(filepath.Join(outputDir, filepath.FromSlash(file.Path)))
+			require.NoError(t, err)
+			sum := sha256.Sum256(data)
+			assert.Equal(t, hex.EncodeToString(sum[:]), file.SHA256, file.Path)
+			assert.Equal(t, int64(len(data)), file.Size, file.Path)
+		}
+	})
+
+	t.Run("manifest lists the digest of every version", func(t *testing.T) {
+		var signed snapshot.SignedManifest
+		readJSON(t, filepath.Join(outputDir, snapshot.ManifestName), &signed)
+		require.NoError(t, signed.Verify(testSigningKey.Public().(ed25519.PublicKey)))
+
+		require.Len(t, signed.Manifest.Servers, len(registry.servers))
+		for i, server := range registry.servers {
+			digest, err := canonicaljson.Digest(&server.Server)
+			require.NoError(t, err)
+			assert.Equal(t, snapshot.ManifestServer{Name: server.Server.Name, Version: server.Server.Version, SHA256: digest}, signed.Manifest.Servers[i])
+		}
+	})
+
+	t.Run("output is deterministic", func(t *testing.T) {
+		again, err := snapshot.NewGenerator(registry, "v0", 2, testSigningKey).Generate(context.Background(), filepath.Join(t.TempDir(), "snapshot"))
+		require.NoError(t, err)
+		assert.Equal(t, manifest.Manifest.Files, again.Manifest.Files)
+		assert.Equal(t, manifest.Manifest.Servers, again.Manifest.Servers)
+	})
+
+	t.Run("output directory must be empty", func(t *testing.T) {
+		_, err := snapshot.NewGenerator(registry, "v0", 2, testSigningKey).Generate(context.Background(), outputDir)
+		assert.ErrorContains(t, err, "not empty")
+	})
+}
+
+func TestGenerateEmptyRegistry(t *testing.T) {
+	outputDir := t.TempDir()
+
+	manifest, err := snapshot.NewGenerator(&exportOnlyRegistry{}, "v0.1", 100, nil).Generate(context.Background(), outputDir)
+	require.NoError(t, err)
+	require.Len(t, manifest.Manifest.Files, 1)
+	assert.Equal(t, "v0.1/servers/index.json", manifest.Manifest.Files[0].Path)
+	assert.Empty(t, manifest.Manifest.Servers)
+	assert.Nil(t, manifest.Signature)
+
+	var page apiv0.ServerListResponse
+	readJSON(t, filepath.Join(outputDir, "v0.1", "servers", "index.json"), &page)
+	assert.Empty(t, page.Servers)
+	assert.Empty(t, page.Metadata.NextCursor)
+}
+
+func TestGenerateRejectsUnsafePaths(t *testing.T) {
+	for _, server := range []*apiv0.ServerResponse{
+		testServer("com.example/escape", "..", model.StatusActive, true),
+		testServer("com.example/escape", "1.0.0/../../x", model.StatusActive, true),
+		testServer("com.example/escape", "latest", model.StatusActive, true),
+		testServer("index.json/escape", "1.0.0", model.StatusActive, true),
+	} {
+		registry := &exportOnlyRegistry{servers: []*apiv0.ServerResponse{server}}
+		_, err := snapshot.NewGenerator(registry, "v0", 100, nil).Generate(context.Background(), t.TempDir())
+		assert.ErrorContains(t, err, "cannot be used as a snapshot path", server.Server.Name+"@"+server.Server.Version)
+	}
+}
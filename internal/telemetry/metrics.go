@@ -9,11 +9,14 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/contrib/instrumentation/runtime"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
 )
 
 const (
@@ -32,6 +35,17 @@ type Metrics struct {
 
 	// Up tracks the health of the service
 	Up metric.Int64Gauge
+
+	// CacheHits tracks the number of reads answered from the read cache
+	CacheHits metric.Int64Counter
+
+	// CacheMisses tracks the number of reads that went to the database
+	CacheMisses metric.Int64Counter
+
+	// CacheInvalidations tracks the number of read cache invalidations
+	CacheInvalidations metric.Int64Counter
+
+	meter metric.Meter
 }
 
 // ShutdownFunc is a delegate that shuts down the OpenTelemetry components.
@@ -73,14 +87,117 @@ func NewMetrics(meter metric.Meter) (*Metrics, error) {
 		return nil, fmt.Errorf("failed to create service up gauge: %w", err)
 	}
 
+	cacheHits, err := meter.Int64Counter(
+		Namespace+".cache.hits",
+		metric.WithDescription("Total number of reads answered from the read cache"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache hit counter: %w", err)
+	}
+
+	cacheMisses, err := meter.Int64Counter(
+		Namespace+".cache.misses",
+		metric.WithDescription("Total number of reads not found in the read cache"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache miss counter: %w", err)
+	}
+
+	cacheInvalidations, err := meter.Int64Counter(
+		Namespace+".cache.invalidations",
+		metric.WithDescription("Total number of read cache invalidations"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache invalidation counter: %w", err)
+	}
+
 	return &Metrics{
-		Requests:        req,
-		RequestDuration: reqDuration,
-		ErrorCount:      errCount,
-		Up:              up,
+		Requests:           req,
+		RequestDuration:    reqDuration,
+		ErrorCount:         errCount,
+		Up:                 up,
+		CacheHits:          cacheHits,
+		CacheMisses:        cacheMisses,
+		CacheInvalidations: cacheInvalidations,
+		meter:              meter,
 	}, nil
 }
 
+// ObservePools reports the statistics of the database connection pools, read from stats each
+// time metrics are collected, with the name of the pool as the "pool" attribute
+func (m *Metrics) ObservePools(stats func() []database.PoolStats) error {
+	connections, err := m.meter.Int64ObservableGauge(
+		Namespace+".db.pool.connections",
+		metric.WithDescription("Open database connections, by state (idle or acquired)"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create pool connections gauge: %w", err)
+	}
+
+	maxConnections, err := m.meter.Int64ObservableGauge(
+		Namespace+".db.pool.max_connections",
+		metric.WithDescription("Maximum size of the database connection pool"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create pool max connections gauge: %w", err)
+	}
+
+	healthy, err := m.meter.Int64ObservableGauge(
+		Namespace+".db.pool.healthy",
+		metric.WithDescription("Whether reads are routed to the database connection pool (1 for healthy, 0 for unhealthy)"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create pool health gauge: %w", err)
+	}
+
+	acquires, err := m.meter.Int64ObservableCounter(
+		Namespace+".db.pool.acquires",
+		metric.WithDescription("Total number of database connections acquired from the pool"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create pool acquire counter: %w", err)
+	}
+
+	emptyAcquires, err := m.meter.Int64ObservableCounter(
+		Namespace+".db.pool.empty_acquires",
+		metric.WithDescription("Total number of database connection acquisitions that had to wait for or open a connection"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create pool empty acquire counter: %w", err)
+	}
+
+	acquireDuration, err := m.meter.Float64ObservableCounter(
+		Namespace+".db.pool.acquire.duration",
+		metric.WithDescription("Total time spent acquiring database connections in seconds"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create pool acquire duration counter: %w", err)
+	}
+
+	_, err = m.meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		for _, pool := range stats() {
+			name := attribute.String("pool", pool.Name)
+			attrs := metric.WithAttributes(name)
+			o.ObserveInt64(connections, int64(pool.IdleConns), metric.WithAttributes(name, attribute.String("state", "idle")))
+			o.ObserveInt64(connections, int64(pool.AcquiredConns), metric.WithAttributes(name, attribute.String("state", "acquired")))
+			o.ObserveInt64(maxConnections, int64(pool.MaxConns), attrs)
+			if pool.Healthy {
+				o.ObserveInt64(healthy, 1, attrs)
+			} else {
+				o.ObserveInt64(healthy, 0, attrs)
+			}
+			o.ObserveInt64(acquires, pool.AcquireCount, attrs)
+			o.ObserveInt64(emptyAcquires, pool.EmptyAcquireCount, attrs)
+			o.ObserveFloat64(acquireDuration, pool.AcquireDuration.Seconds(), attrs)
+		}
+		return nil
+	}, connections, maxConnections, healthy, acquires, emptyAcquires, acquireDuration)
+	if err != nil {
+		return fmt.Errorf("failed to register pool metrics callback: %w", err)
+	}
+	return nil
+}
+
 func NewPrometheusMeterProvider(res *resource.Resource, exp *prometheus.Exporter) (*sdkmetric.MeterProvider, error) {
 	if exp == nil {
 		return nil, errors.New("exporter cannot be nil")
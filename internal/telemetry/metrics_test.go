@@ -1,16 +1,20 @@
 package telemetry_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/noop"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/resource"
 
+	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
 
@@ -82,3 +86,50 @@ func TestNewPrometheusMeterProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestObservePools(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test")
+	metrics, err := telemetry.NewMetrics(meter)
+	require.NoError(t, err)
+
+	err = metrics.ObservePools(func() []database.PoolStats {
+		return []database.PoolStats{
+			{Name: "primary", Healthy: true, MaxConns: 30, IdleConns: 3, AcquiredConns: 2, AcquireCount: 10},
+			{Name: "replica-1", Healthy: false, MaxConns: 30},
+		}
+	})
+	require.NoError(t, err)
+
+	var collected metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &collected))
+	require.Len(t, collected.ScopeMetrics, 1)
+
+	gauges := map[string]metricdata.Gauge[int64]{}
+	var names []string
+	for _, m := range collected.ScopeMetrics[0].Metrics {
+		names = append(names, m.Name)
+		if gauge, ok := m.Data.(metricdata.Gauge[int64]); ok {
+			gauges[m.Name] = gauge
+		}
+	}
+	assert.ElementsMatch(t, []string{
+		"mcp_registry.db.pool.connections",
+		"mcp_registry.db.pool.max_connections",
+		"mcp_registry.db.pool.healthy",
+		"mcp_registry.db.pool.acquires",
+		"mcp_registry.db.pool.empty_acquires",
+		"mcp_registry.db.pool.acquire.duration",
+	}, names)
+
+	// Idle and acquired connections of both pools
+	assert.Len(t, gauges["mcp_registry.db.pool.connections"].DataPoints, 4)
+	for _, point := range gauges["mcp_registry.db.pool.healthy"].DataPoints {
+		pool, _ := point.Attributes.Value("pool")
+		if pool.AsString() == "primary" {
+			assert.Equal(t, int64(1), point.Value)
+		} else {
+			assert.Equal(t, int64(0), point.Value)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/versioning"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
@@ -84,6 +85,10 @@ func ValidateServerJSON(serverJSON *apiv0.ServerJSON, opts ValidationOptions) *V
 	versionResult := validateVersion(ctx.Field("version"), serverJSON.Version)
 	result.Merge(versionResult)
 
+	// Validate version scheme if provided
+	versionSchemeResult := validateVersionScheme(ctx.Field("versionScheme"), serverJSON.VersionScheme)
+	result.Merge(versionSchemeResult)
+
 	// Validate repository
 	repoResult := validateRepository(ctx.Field("repository"), serverJSON.Repository)
 	result.Merge(repoResult)
@@ -346,6 +351,28 @@ func validateVersion(ctx *ValidationContext, version string) *ValidationResult {
 	return result
 }
 
+// validateVersionScheme validates that an explicit version scheme is one the registry supports
+func validateVersionScheme(ctx *ValidationContext, scheme string) *ValidationResult {
+	result := &ValidationResult{Valid: true, Issues: []ValidationIssue{}}
+
+	// Skip validation if version scheme is not provided (optional field)
+	if scheme == "" {
+		return result
+	}
+
+	if _, ok := versioning.Lookup(scheme); !ok {
+		issue := NewValidationIssueFromError(
+			ValidationIssueTypeSemantic,
+			ctx.String(),
+			fmt.Errorf("%w: %q (supported: %s)", ErrUnknownVersionScheme, scheme, strings.Join(versioning.Names(), ", ")),
+			"unknown-version-scheme",
+		)
+		result.AddIssue(issue)
+	}
+
+	return result
+}
+
 // looksLikeVersionRange detects common semver range syntaxes and wildcard patterns.
 // that indicate the value is not a single, specific version.
 // Examples that should return true:
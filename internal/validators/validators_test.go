@@ -2209,6 +2209,42 @@ func TestValidateTitle(t *testing.T) {
 	}
 }
 
+func TestValidateVersionScheme(t *testing.T) {
+	tests := []struct {
+		name          string
+		versionScheme string
+		expectedError string
+	}{
+		{name: "Empty version scheme is allowed (optional field)", versionScheme: ""},
+		{name: "semver", versionScheme: "semver"},
+		{name: "pep440", versionScheme: "pep440"},
+		{name: "calver", versionScheme: "calver"},
+		{name: "nuget", versionScheme: "nuget"},
+		{name: "Rejects unknown version scheme", versionScheme: "maven", expectedError: "unknown version scheme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverDetail := apiv0.ServerJSON{
+				Schema:        model.CurrentSchemaURL,
+				Name:          "com.example/test-server",
+				Description:   "A test server",
+				Version:       "2025.10.3",
+				VersionScheme: tt.versionScheme,
+			}
+
+			result := validators.ValidateServerJSON(&serverDetail, validators.ValidationSchemaVersionAndSemantic)
+			err := result.FirstError()
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			}
+		})
+	}
+}
+
 // Helper function for creating string pointers in tests
 func stringPtr(s string) *string {
 	return &s
@@ -0,0 +1,31 @@
+package versioning
+
+import (
+	"regexp"
+	"strings"
+)
+
+// calverRe matches calendar versions: a two- or four-digit year followed by one or more
+// numeric parts (e.g. "2025.10.3", "24.04", "2025.10.03.1"), with an optional semver-style
+// modifier ("2025.10.3-beta.1") and build metadata
+var calverRe = regexp.MustCompile(`^v?(\d{2}|\d{4})((?:\.\d+)+)(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// calverScheme implements calendar versioning (https://calver.org).
+// Parts compare numerically so zero-padded and unpadded dates are equivalent, a version with
+// more parts sorts above its prefix (2025.10 < 2025.10.1), and a modifier marks a prerelease.
+type calverScheme struct{}
+
+func (calverScheme) Name() string { return "calver" }
+
+func (calverScheme) Parse(version string) (Version, bool) {
+	m := calverRe.FindStringSubmatch(version)
+	if m == nil {
+		return Version{}, false
+	}
+
+	parts := append([]string{m[1]}, strings.Split(strings.TrimPrefix(m[2], "."), ".")...)
+	modifier := m[3]
+
+	key := encodeNumbers(parts) + encodeStability(modifier)
+	return Version{key: key, prerelease: modifier != ""}, true
+}
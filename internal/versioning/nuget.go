@@ -0,0 +1,34 @@
+package versioning
+
+import (
+	"regexp"
+	"strings"
+)
+
+// nugetRe matches NuGet package versions: one to four numeric parts with an optional
+// prerelease label and build metadata (e.g. "1.0", "4.2.1.7", "2.0.0-Preview.3+abc")
+var nugetRe = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:\.(\d+))?(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// nugetScheme implements NuGet package versioning
+// (https://learn.microsoft.com/nuget/concepts/package-versioning).
+// Missing parts default to zero (1.0 == 1.0.0 == 1.0.0.0), the fourth "revision" part is
+// significant, and prerelease labels compare case-insensitively.
+type nugetScheme struct{}
+
+func (nugetScheme) Name() string { return "nuget" }
+
+func (nugetScheme) Parse(version string) (Version, bool) {
+	m := nugetRe.FindStringSubmatch(version)
+	if m == nil {
+		return Version{}, false
+	}
+
+	var b strings.Builder
+	for _, part := range m[1:5] {
+		b.WriteString(encodeNumber(part))
+	}
+	prerelease := strings.ToLower(m[5])
+	b.WriteString(encodeStability(prerelease))
+
+	return Version{key: b.String(), prerelease: prerelease != ""}, true
+}
@@ -0,0 +1,103 @@
+package versioning
+
+import (
+	"regexp"
+	"strings"
+)
+
+// pep440Re matches PEP 440 versions including the alternative spellings that PEP 440
+// normalizes (e.g. "1.0-beta2", "1.0.post1", "1.0-1", "1!2.0.dev3", "1.0+ubuntu.1")
+var pep440Re = regexp.MustCompile(`(?i)^v?` +
+	`(?:(\d+)!)?` + // epoch
+	`(\d+(?:\.\d+)*)` + // release
+	`(?:[-_.]?(alpha|a|beta|b|preview|pre|c|rc)[-_.]?(\d+)?)?` + // pre-release
+	`(?:-(\d+)|[-_.]?(post|rev|r)[-_.]?(\d+)?)?` + // post-release
+	`(?:[-_.]?(dev)[-_.]?(\d+)?)?` + // development release
+	`(?:\+([a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`) // local version
+
+// pep440Scheme implements Python's PEP 440 version ordering
+// (https://peps.python.org/pep-0440/#summary-of-permitted-suffixes-and-relative-ordering):
+// 1.0.dev1 < 1.0a1.dev1 < 1.0a1 < 1.0a1.post1 < 1.0b1 < 1.0rc1 < 1.0 < 1.0+local < 1.0.post1
+type pep440Scheme struct{}
+
+func (pep440Scheme) Name() string { return "pep440" }
+
+func (pep440Scheme) Parse(version string) (Version, bool) {
+	m := pep440Re.FindStringSubmatch(strings.TrimSpace(version))
+	if m == nil {
+		return Version{}, false
+	}
+	epoch, release := m[1], m[2]
+	preLabel, preNumber := strings.ToLower(m[3]), m[4]
+	postNumber, postLabel := m[5], m[6]
+	if postNumber == "" {
+		postNumber = m[7]
+	}
+	hasPost := m[5] != "" || postLabel != ""
+	hasDev, devNumber := m[8] != "", m[9]
+	local := strings.ToLower(m[10])
+
+	var b strings.Builder
+	b.WriteString(encodeNumber(epoch))
+
+	// Trailing zeros are not significant: 1.0 == 1.0.0
+	segments := strings.Split(release, ".")
+	for len(segments) > 0 && strings.Trim(segments[len(segments)-1], "0") == "" {
+		segments = segments[:len(segments)-1]
+	}
+	b.WriteString(encodeNumbers(segments))
+
+	switch {
+	case preLabel != "":
+		b.WriteString(pep440PreReleaseRank(preLabel) + encodeNumber(preNumber))
+	case hasDev && !hasPost:
+		// 1.0.dev1 sorts below every pre-release of 1.0
+		b.WriteString("0")
+	default:
+		b.WriteString("4")
+	}
+
+	if hasPost {
+		b.WriteString("1" + encodeNumber(postNumber))
+	} else {
+		b.WriteString("0")
+	}
+
+	if hasDev {
+		b.WriteString("0" + encodeNumber(devNumber))
+	} else {
+		b.WriteString("1")
+	}
+
+	// Local versions sort above the public version they are based on. Numeric segments
+	// sort above alphanumeric ones and compare numerically.
+	if local == "" {
+		b.WriteString("0")
+	} else {
+		b.WriteString("1")
+		for _, segment := range strings.FieldsFunc(local, func(r rune) bool {
+			return r == '-' || r == '_' || r == '.'
+		}) {
+			if isNumeric(segment) {
+				b.WriteString("1" + encodeNumber(segment))
+			} else {
+				b.WriteString("0" + segment)
+			}
+			b.WriteString(",")
+		}
+	}
+
+	return Version{key: b.String(), prerelease: preLabel != "" || hasDev}, true
+}
+
+// pep440PreReleaseRank normalizes the alternative pre-release spellings (alpha, beta, c, pre, preview)
+func pep440PreReleaseRank(label string) string {
+	switch label {
+	case "a", "alpha":
+		return "1"
+	case "b", "beta":
+		return "2"
+	default: // rc, c, pre, preview
+		return "3"
+	}
+}
@@ -0,0 +1,212 @@
+// Package versioning implements the version numbering schemes the registry understands
+// and the ordering rules used to pick the latest version of a server and to sort versions.
+package versioning
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Scheme is a version numbering scheme such as semantic versioning or PEP 440
+type Scheme interface {
+	// Name is the identifier used for the scheme in server.json's versionScheme field
+	Name() string
+	// Parse reports whether the version is valid in this scheme and returns its parsed form
+	Parse(version string) (Version, bool)
+}
+
+// Version is a version parsed by a Scheme
+type Version struct {
+	// key is a string whose byte order matches the scheme's version precedence
+	key        string
+	prerelease bool
+}
+
+// Compare returns -1, 0 or +1 depending on whether v sorts below, equal to or above other.
+// Both versions must have been parsed by the same scheme.
+func (v Version) Compare(other Version) int {
+	return strings.Compare(v.key, other.key)
+}
+
+// IsPrerelease reports whether the version is a prerelease (alpha, beta, rc, dev, ...)
+func (v Version) IsPrerelease() bool {
+	return v.prerelease
+}
+
+// Supported version schemes
+var (
+	Semver Scheme = semverScheme{}
+	PEP440 Scheme = pep440Scheme{}
+	Calver Scheme = calverScheme{}
+	NuGet  Scheme = nugetScheme{}
+)
+
+var schemes = []Scheme{Semver, PEP440, Calver, NuGet}
+
+// Lookup returns the scheme with the given name
+func Lookup(name string) (Scheme, bool) {
+	for _, s := range schemes {
+		if s.Name() == name {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// Names returns the names of all supported schemes
+func Names() []string {
+	names := make([]string, 0, len(schemes))
+	for _, s := range schemes {
+		names = append(names, s.Name())
+	}
+	return names
+}
+
+// ForServer returns the version scheme of a server version.
+// An explicit versionScheme wins; otherwise the registry type of the first package decides
+// (PyPI packages use PEP 440, NuGet packages use NuGet versioning) and everything else
+// defaults to semantic versioning.
+func ForServer(server *apiv0.ServerJSON) Scheme {
+	if server == nil {
+		return Semver
+	}
+
+	if server.VersionScheme != "" {
+		if s, ok := Lookup(server.VersionScheme); ok {
+			return s
+		}
+	}
+
+	if len(server.Packages) > 0 {
+		switch server.Packages[0].RegistryType {
+		case model.RegistryTypePyPI:
+			return PEP440
+		case model.RegistryTypeNuGet:
+			return NuGet
+		}
+	}
+
+	return Semver
+}
+
+// Compare orders two versions of the same server using the given scheme:
+// 1. If both versions are valid in the scheme, use the scheme's precedence rules
+// 2. If neither is valid, use publication timestamp
+// 3. If only one is valid, the valid version is always considered higher
+func Compare(scheme Scheme, version1, version2 string, timestamp1, timestamp2 time.Time) int {
+	v1, ok1 := scheme.Parse(version1)
+	v2, ok2 := scheme.Parse(version2)
+
+	switch {
+	case ok1 && ok2:
+		return v1.Compare(v2)
+	case ok1:
+		return 1
+	case ok2:
+		return -1
+	}
+
+	if timestamp1.Before(timestamp2) {
+		return -1
+	} else if timestamp1.After(timestamp2) {
+		return 1
+	}
+	return 0
+}
+
+// CompareForLatest orders two versions when deciding which one should be marked as latest.
+// It follows Compare, except that a stable version always ranks above a prerelease.
+func CompareForLatest(scheme Scheme, version1, version2 string, timestamp1, timestamp2 time.Time) int {
+	isPrerelease1 := IsPrerelease(scheme, version1)
+	isPrerelease2 := IsPrerelease(scheme, version2)
+
+	if isPrerelease1 && !isPrerelease2 {
+		return -1
+	}
+	if !isPrerelease1 && isPrerelease2 {
+		return 1
+	}
+
+	return Compare(scheme, version1, version2, timestamp1, timestamp2)
+}
+
+// IsPrerelease reports whether the version is a prerelease in the given scheme.
+// Versions that are not valid in the scheme are never considered prereleases.
+func IsPrerelease(scheme Scheme, version string) bool {
+	v, ok := scheme.Parse(version)
+	return ok && v.IsPrerelease()
+}
+
+// SortKey encodes a version into a string whose byte order (COLLATE "C") matches Compare,
+// so the database can sort and paginate versions:
+//   - versions valid in the scheme sort by the scheme's precedence rules
+//   - other versions sort below them, ordered by publication time
+func SortKey(scheme Scheme, version string, publishedAt time.Time) string {
+	if v, ok := scheme.Parse(version); ok {
+		return "1" + v.key
+	}
+	return "0" + publishedAt.UTC().Format("20060102150405.000000000")
+}
+
+// encodeNumber length-prefixes a decimal number so that byte order matches numeric order.
+// Leading zeros are not significant. Versions are at most 255 characters, so three digits
+// of length always suffice.
+func encodeNumber(digits string) string {
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		digits = "0"
+	}
+	return fmt.Sprintf("%03d%s", len(digits), digits)
+}
+
+// encodeNumbers encodes a variable-length list of numbers. The "." terminator sorts below
+// every digit, so a list sorts below any longer list it is a prefix of.
+func encodeNumbers(numbers []string) string {
+	var b strings.Builder
+	for _, n := range numbers {
+		b.WriteString(encodeNumber(n))
+	}
+	b.WriteString(".")
+	return b.String()
+}
+
+// encodeStability encodes semver-style dot-separated prerelease identifiers.
+// Stable versions ("~") sort above every prerelease ("-..."). Numeric identifiers sort
+// below alphanumeric ones and compare numerically; alphanumeric identifiers compare in
+// ASCII order; a shorter list of otherwise equal identifiers sorts first.
+func encodeStability(prerelease string) string {
+	if prerelease == "" {
+		return "~"
+	}
+
+	var b strings.Builder
+	b.WriteString("-")
+	for i, identifier := range strings.Split(prerelease, ".") {
+		if i > 0 {
+			// "," sorts below every identifier character
+			b.WriteString(",")
+		}
+		if isNumeric(identifier) {
+			b.WriteString("0" + encodeNumber(identifier))
+		} else {
+			b.WriteString("1" + identifier)
+		}
+	}
+	return b.String()
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,251 @@
+package versioning_test
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/versioning"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemeOrdering(t *testing.T) {
+	tests := []struct {
+		name   string
+		scheme versioning.Scheme
+		// ordered lists valid versions in ascending order
+		ordered []string
+	}{
+		{
+			name:   "semver",
+			scheme: versioning.Semver,
+			ordered: []string{
+				"0.0.1", "0.9.0",
+				"1.0.0-1", "1.0.0-2", "1.0.0-10",
+				"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-alpha-x",
+				"1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1",
+				"1.0.0", "1.2.0", "1.9.0", "1.10.0", "2.0.0", "10.0.0",
+			},
+		},
+		{
+			name:   "pep440",
+			scheme: versioning.PEP440,
+			ordered: []string{
+				"0.1", "0.9.post1",
+				"1.0.dev1", "1.0.dev2",
+				"1.0a1.dev1", "1.0a1", "1.0a1.post1", "1.0a2",
+				"1.0b1", "1.0rc1", "1.0rc2",
+				"1.0", "1.0+abc", "1.0+abc.5", "1.0+5",
+				"1.0.post1.dev1", "1.0.post1", "1.0.post2",
+				"1.0.1", "1.9", "1.10",
+				"1!0.1",
+			},
+		},
+		{
+			name:   "calver",
+			scheme: versioning.Calver,
+			ordered: []string{
+				"2024.12.31", "2025.1-beta", "2025.1",
+				"2025.10", "2025.10.3-rc.1", "2025.10.3", "2025.10.3.1", "2025.10.12", "2026.1.1",
+			},
+		},
+		{
+			name:   "nuget",
+			scheme: versioning.NuGet,
+			ordered: []string{
+				"1.0.0-alpha", "1.0.0-Beta", "1.0.0-beta.2", "1.0.0-rc",
+				"1.0", "1.0.0.1", "1.0.1", "1.2.0.10", "1.10", "2.0.0.0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := make([]versioning.Version, len(tt.ordered))
+			for i, v := range tt.ordered {
+				var ok bool
+				parsed[i], ok = tt.scheme.Parse(v)
+				require.True(t, ok, "%q should be a valid %s version", v, tt.scheme.Name())
+			}
+
+			for i := 1; i < len(parsed); i++ {
+				assert.Equal(t, -1, parsed[i-1].Compare(parsed[i]), "%q should sort below %q", tt.ordered[i-1], tt.ordered[i])
+				assert.Equal(t, 1, parsed[i].Compare(parsed[i-1]), "%q should sort above %q", tt.ordered[i], tt.ordered[i-1])
+			}
+		})
+	}
+}
+
+func TestSchemeEquivalentVersions(t *testing.T) {
+	tests := []struct {
+		scheme versioning.Scheme
+		a, b   string
+	}{
+		{versioning.Semver, "1.2.3", "v1.2.3"},
+		{versioning.Semver, "1.2.3", "1.2.3+build.5"},
+		{versioning.PEP440, "1.0", "1.0.0"},
+		{versioning.PEP440, "1.0a1", "1.0.alpha.1"},
+		{versioning.PEP440, "1.0rc1", "1.0c1"},
+		{versioning.PEP440, "1.0.post1", "1.0-1"},
+		{versioning.PEP440, "1.0.post1", "1.0.rev1"},
+		{versioning.PEP440, "1.0.dev0", "1.0-dev"},
+		{versioning.PEP440, "1.0", "0!1.0"},
+		{versioning.Calver, "2025.01.03", "2025.1.3"},
+		{versioning.NuGet, "1.0", "1.0.0.0"},
+		{versioning.NuGet, "1.0.0-BETA", "1.0.0-beta"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scheme.Name()+" "+tt.a+" == "+tt.b, func(t *testing.T) {
+			a, ok := tt.scheme.Parse(tt.a)
+			require.True(t, ok)
+			b, ok := tt.scheme.Parse(tt.b)
+			require.True(t, ok)
+			assert.Equal(t, 0, a.Compare(b))
+		})
+	}
+}
+
+func TestSchemeInvalidVersions(t *testing.T) {
+	tests := []struct {
+		scheme  versioning.Scheme
+		version string
+	}{
+		{versioning.Semver, "1.0"},
+		{versioning.Semver, "2025.10.03"},
+		{versioning.Semver, "snapshot"},
+		{versioning.PEP440, "1.0-beta.x"},
+		{versioning.PEP440, "latest"},
+		{versioning.Calver, "1.2.3"},
+		{versioning.Calver, "2025"},
+		{versioning.NuGet, "1.2.3.4.5"},
+		{versioning.NuGet, "release"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scheme.Name()+" "+tt.version, func(t *testing.T) {
+			_, ok := tt.scheme.Parse(tt.version)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestIsPrerelease(t *testing.T) {
+	tests := []struct {
+		scheme   versioning.Scheme
+		version  string
+		expected bool
+	}{
+		{versioning.Semver, "1.0.0", false},
+		{versioning.Semver, "1.0.0-beta.1", true},
+		{versioning.Semver, "snapshot", false},
+		{versioning.PEP440, "1.0", false},
+		{versioning.PEP440, "1.0.post1", false},
+		{versioning.PEP440, "1.0rc1", true},
+		{versioning.PEP440, "1.0.dev3", true},
+		{versioning.Calver, "2025.10.3", false},
+		{versioning.Calver, "2025.10.3-beta", true},
+		{versioning.NuGet, "4.2.1.7", false},
+		{versioning.NuGet, "4.2.1-preview1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scheme.Name()+" "+tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.expected, versioning.IsPrerelease(tt.scheme, tt.version))
+		})
+	}
+}
+
+func TestForServer(t *testing.T) {
+	tests := []struct {
+		name     string
+		server   *apiv0.ServerJSON
+		expected versioning.Scheme
+	}{
+		{
+			name:     "defaults to semver",
+			server:   &apiv0.ServerJSON{},
+			expected: versioning.Semver,
+		},
+		{
+			name:     "npm package uses semver",
+			server:   &apiv0.ServerJSON{Packages: []model.Package{{RegistryType: model.RegistryTypeNPM}}},
+			expected: versioning.Semver,
+		},
+		{
+			name:     "pypi package uses pep440",
+			server:   &apiv0.ServerJSON{Packages: []model.Package{{RegistryType: model.RegistryTypePyPI}}},
+			expected: versioning.PEP440,
+		},
+		{
+			name:     "nuget package uses nuget",
+			server:   &apiv0.ServerJSON{Packages: []model.Package{{RegistryType: model.RegistryTypeNuGet}}},
+			expected: versioning.NuGet,
+		},
+		{
+			name: "explicit scheme wins over package type",
+			server: &apiv0.ServerJSON{
+				VersionScheme: "calver",
+				Packages:      []model.Package{{RegistryType: model.RegistryTypePyPI}},
+			},
+			expected: versioning.Calver,
+		},
+		{
+			name:     "unknown explicit scheme falls back",
+			server:   &apiv0.ServerJSON{VersionScheme: "unknown"},
+			expected: versioning.Semver,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected.Name(), versioning.ForServer(tt.server).Name())
+		})
+	}
+}
+
+func TestCompareForLatest(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	// PEP 440 post-releases are stable and newer than their release
+	assert.Equal(t, 1, versioning.CompareForLatest(versioning.PEP440, "1.0.post1", "1.0", now, earlier))
+	// Prereleases never displace a stable version
+	assert.Equal(t, -1, versioning.CompareForLatest(versioning.PEP440, "2.0rc1", "1.0", now, earlier))
+	// Calver compares numerically, not lexicographically
+	assert.Equal(t, 1, versioning.CompareForLatest(versioning.Calver, "2025.10.3", "2025.9.30", now, earlier))
+	// Versions invalid in the scheme fall back to publication time and sort below valid ones
+	assert.Equal(t, 1, versioning.CompareForLatest(versioning.Calver, "nightly-b", "nightly-a", now, earlier))
+	assert.Equal(t, -1, versioning.CompareForLatest(versioning.Calver, "nightly", "2025.1.1", now, earlier))
+}
+
+func TestSortKey(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Versions invalid in the scheme sort first, ordered by publish time
+	ordered := []struct {
+		version     string
+		publishedAt time.Time
+	}{
+		{"snapshot", base},
+		{"nightly", base.Add(time.Hour)},
+		{"1.0.dev1", base},
+		{"1.0rc1", base},
+		{"1.0", base},
+		{"1.0.post1", base},
+		{"1.10", base},
+	}
+
+	keys := make([]string, len(ordered))
+	for i, v := range ordered {
+		keys[i] = versioning.SortKey(versioning.PEP440, v.version, v.publishedAt)
+	}
+	assert.True(t, sort.StringsAreSorted(keys), "sort keys should be in ascending version order: %v", keys)
+
+	assert.Equal(t,
+		versioning.SortKey(versioning.PEP440, "1.0", base),
+		versioning.SortKey(versioning.PEP440, "1.0.0", base.Add(time.Hour)))
+}
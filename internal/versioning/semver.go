@@ -0,0 +1,43 @@
+package versioning
+
+import (
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// semverScheme implements Semantic Versioning 2.0.0 (https://semver.org).
+// Versions must have exactly three parts; a leading "v" is tolerated and build metadata is ignored.
+type semverScheme struct{}
+
+func (semverScheme) Name() string { return "semver" }
+
+func (semverScheme) Parse(version string) (Version, bool) {
+	v := version
+	if !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+	// The semver package also accepts "v1" and "v1.2", so the part count is checked separately
+	if !semver.IsValid(v) {
+		return Version{}, false
+	}
+
+	prerelease := strings.TrimPrefix(semver.Prerelease(v), "-")
+	core := strings.TrimPrefix(v, "v")
+	if idx := strings.IndexAny(core, "-+"); idx != -1 {
+		core = core[:idx]
+	}
+
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return Version{}, false
+	}
+
+	var b strings.Builder
+	for _, part := range parts {
+		b.WriteString(encodeNumber(part))
+	}
+	b.WriteString(encodeStability(prerelease))
+
+	return Version{key: b.String(), prerelease: prerelease != ""}, true
+}
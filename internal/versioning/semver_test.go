@@ -0,0 +1,109 @@
+package versioning_test
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/versioning"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSemverSortKey(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Expected ascending order, following semver precedence with non-semver versions
+	// first (ordered by publish time)
+	ordered := []struct {
+		version     string
+		publishedAt time.Time
+	}{
+		{"snapshot", base},
+		{"2025.06.18", base.Add(time.Hour)},
+		{"0.0.1", base},
+		{"0.9.0", base},
+		{"1.0.0-1", base},
+		{"1.0.0-2", base},
+		{"1.0.0-10", base},
+		{"1.0.0-alpha", base},
+		{"1.0.0-alpha.1", base},
+		{"1.0.0-alpha.beta", base},
+		{"1.0.0-alpha-x", base},
+		{"1.0.0-beta", base},
+		{"1.0.0-beta.2", base},
+		{"1.0.0-beta.11", base},
+		{"1.0.0-rc.1", base},
+		{"1.0.0", base},
+		{"1.2.0", base},
+		{"1.9.0", base},
+		{"1.10.0", base},
+		{"2.0.0", base},
+		{"10.0.0", base},
+	}
+
+	keys := make([]string, len(ordered))
+	for i, v := range ordered {
+		keys[i] = versioning.SortKey(versioning.Semver, v.version, v.publishedAt)
+	}
+
+	assert.True(t, sort.StringsAreSorted(keys), "sort keys should be in ascending version order: %v", keys)
+}
+
+func TestSemverSortKeyIgnoresPrefixAndBuildMetadata(t *testing.T) {
+	now := time.Now()
+	assert.Equal(t, versioning.SortKey(versioning.Semver, "1.2.3", now), versioning.SortKey(versioning.Semver, "v1.2.3", now))
+	assert.Equal(t, versioning.SortKey(versioning.Semver, "1.2.3", now), versioning.SortKey(versioning.Semver, "1.2.3+build.5", now))
+	assert.NotEqual(t, versioning.SortKey(versioning.Semver, "1.0", now), versioning.SortKey(versioning.Semver, "1.0.0", now))
+}
+
+func TestSemverIsPrerelease(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		want    bool
+	}{
+		{"stable", "1.0.0", false},
+		{"stable with build metadata", "1.0.0+20130313144700", false},
+		{"alpha", "1.0.0-alpha", true},
+		{"beta with number", "2.0.0-beta.1", true},
+		{"rc with build metadata", "2.0.0-rc.1+build.5", true},
+		{"non-semver with hyphen", "snapshot-1", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, versioning.IsPrerelease(versioning.Semver, tt.version))
+		})
+	}
+}
+
+func TestSemverCompareForLatest(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+	later := now.Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		version1   string
+		version2   string
+		timestamp1 time.Time
+		timestamp2 time.Time
+		want       int
+	}{
+		{"stable beats newer prerelease", "1.9.0", "2.0.0-beta.1", now, now, 1},
+		{"prerelease loses to older stable", "2.0.0-beta.1", "1.9.0", now, now, -1},
+		{"prerelease vs prerelease uses semver", "2.0.0-beta.2", "2.0.0-beta.1", now, now, 1},
+		{"stable vs stable uses semver", "1.10.0", "1.9.0", now, now, 1},
+		{"stable release of prerelease", "2.0.0", "2.0.0-rc.1", now, now, 1},
+		{"non-semver beats prerelease", "snapshot", "1.0.0-alpha", now, now, 1},
+		{"neither semver uses timestamps", "snapshot", "nightly", later, earlier, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := versioning.CompareForLatest(versioning.Semver, tt.version1, tt.version2, tt.timestamp1, tt.timestamp2)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
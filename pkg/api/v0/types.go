@@ -7,10 +7,18 @@ import (
 )
 
 type RegistryExtensions struct {
-	Status      model.Status `json:"status" enum:"active,deprecated,deleted" doc:"Server lifecycle status"`
-	PublishedAt time.Time    `json:"publishedAt" format:"date-time" doc:"Timestamp when the server was first published to the registry"`
-	UpdatedAt   time.Time    `json:"updatedAt,omitempty" format:"date-time" doc:"Timestamp when the server entry was last updated"`
-	IsLatest    bool         `json:"isLatest" doc:"Whether this is the latest version of the server"`
+	Status        model.Status     `json:"status" enum:"active,deprecated,deleted" doc:"Server lifecycle status"`
+	StatusMessage string           `json:"statusMessage,omitempty" doc:"Optional explanation of the status, such as a deprecation notice" example:"This version has a security issue, please upgrade"`
+	ReplacedBy    *ServerReference `json:"replacedBy,omitempty" doc:"Optional server or version to use instead of this deprecated version"`
+	PublishedAt   time.Time        `json:"publishedAt" format:"date-time" doc:"Timestamp when the server was first published to the registry"`
+	UpdatedAt     time.Time        `json:"updatedAt,omitempty" format:"date-time" doc:"Timestamp when the server entry was last updated"`
+	IsLatest      bool             `json:"isLatest" doc:"Whether this is the latest version of the server"`
+	ContentDigest string           `json:"contentDigest,omitempty" doc:"Hex-encoded SHA-256 of the canonical JSON (RFC 8785) encoding of the current server.json" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+}
+
+type ServerReference struct {
+	ServerName string `json:"serverName" minLength:"3" maxLength:"200" doc:"Name of the referenced server" example:"io.github.user/weather"`
+	Version    string `json:"version,omitempty" doc:"Optional version of the referenced server. Refers to its latest version if omitted." example:"2.0.0"`
 }
 
 type ResponseMeta struct {
@@ -32,20 +40,132 @@ type ServerMeta struct {
 }
 
 type ServerJSON struct {
-	Schema      string            `json:"$schema" required:"true" minLength:"1" format:"uri" doc:"JSON Schema URI for this server.json format" example:"https://static.modelcontextprotocol.io/schemas/2025-12-11/server.schema.json"`
-	Name        string            `json:"name" minLength:"3" maxLength:"200" pattern:"^[a-zA-Z0-9.-]+/[a-zA-Z0-9._-]+$" doc:"Server name in reverse-DNS format. Must contain exactly one forward slash separating namespace from server name." example:"io.github.user/weather"`
-	Description string            `json:"description" minLength:"1" maxLength:"100" doc:"Clear human-readable explanation of server functionality." example:"MCP server providing weather data and forecasts via OpenWeatherMap API"`
-	Title       string            `json:"title,omitempty" minLength:"1" maxLength:"100" doc:"Optional human-readable title or display name for the MCP server." example:"Weather API"`
-	Repository  *model.Repository `json:"repository,omitempty" doc:"Optional repository metadata for the MCP server source code."`
-	Version     string            `json:"version" doc:"Version string for this server. SHOULD follow semantic versioning." example:"1.0.2"`
-	WebsiteURL  string            `json:"websiteUrl,omitempty" format:"uri" doc:"Optional URL to the server's homepage, documentation, or project website." example:"https://modelcontextprotocol.io/examples"`
-	Icons       []model.Icon      `json:"icons,omitempty" doc:"Optional set of sized icons that the client can display in a user interface."`
-	Packages    []model.Package   `json:"packages,omitempty" doc:"Array of package configurations"`
-	Remotes     []model.Transport `json:"remotes,omitempty" doc:"Array of remote configurations"`
-	Meta        *ServerMeta       `json:"_meta,omitempty" doc:"Extension metadata using reverse DNS namespacing for vendor-specific data"`
+	Schema        string            `json:"$schema" required:"true" minLength:"1" format:"uri" doc:"JSON Schema URI for this server.json format" example:"https://static.modelcontextprotocol.io/schemas/2025-12-11/server.schema.json"`
+	Name          string            `json:"name" minLength:"3" maxLength:"200" pattern:"^[a-zA-Z0-9.-]+/[a-zA-Z0-9._-]+$" doc:"Server name in reverse-DNS format. Must contain exactly one forward slash separating namespace from server name." example:"io.github.user/weather"`
+	Description   string            `json:"description" minLength:"1" maxLength:"100" doc:"Clear human-readable explanation of server functionality." example:"MCP server providing weather data and forecasts via OpenWeatherMap API"`
+	Title         string            `json:"title,omitempty" minLength:"1" maxLength:"100" doc:"Optional human-readable title or display name for the MCP server." example:"Weather API"`
+	Repository    *model.Repository `json:"repository,omitempty" doc:"Optional repository metadata for the MCP server source code."`
+	Version       string            `json:"version" doc:"Version string for this server. SHOULD follow semantic versioning." example:"1.0.2"`
+	VersionScheme string            `json:"versionScheme,omitempty" enum:"semver,pep440,calver,nuget" doc:"Optional version scheme used to order this server's versions. Defaults to pep440 for PyPI packages, nuget for NuGet packages and semver otherwise." example:"semver"`
+	WebsiteURL    string            `json:"websiteUrl,omitempty" format:"uri" doc:"Optional URL to the server's homepage, documentation, or project website." example:"https://modelcontextprotocol.io/examples"`
+	Icons         []model.Icon      `json:"icons,omitempty" doc:"Optional set of sized icons that the client can display in a user interface."`
+	Packages      []model.Package   `json:"packages,omitempty" doc:"Array of package configurations"`
+	Remotes       []model.Transport `json:"remotes,omitempty" doc:"Array of remote configurations"`
+	Meta          *ServerMeta       `json:"_meta,omitempty" doc:"Extension metadata using reverse DNS namespacing for vendor-specific data"`
 }
 
 type Metadata struct {
 	NextCursor string `json:"nextCursor,omitempty" doc:"Pagination cursor for retrieving the next page of results. Use this exact value in the cursor query parameter of your next request."`
 	Count      int    `json:"count" doc:"Number of items in current page"`
+	Total      *int   `json:"total,omitempty" doc:"Total number of items matching the filters across all pages (only when requested with include_total)"`
+}
+
+type DistTagsResponse struct {
+	DistTags map[string]string `json:"distTags" doc:"Map of dist-tag names to the server versions they point at. The 'latest' tag is managed by the registry." example:"{\"latest\":\"1.2.0\",\"next\":\"2.0.0-beta.1\"}"`
+}
+
+type DistTagRequest struct {
+	Version string `json:"version" minLength:"1" doc:"Server version the dist-tag should point at" example:"2.0.0-beta.1"`
+}
+
+type ServerFacetsResponse struct {
+	Total         int            `json:"total" doc:"Number of servers with at least one version matching the filters"`
+	Status        map[string]int `json:"status" doc:"Number of matching servers per version status" example:"{\"active\":120,\"deprecated\":4}"`
+	Namespace     map[string]int `json:"namespace" doc:"Number of matching servers per namespace (the 100 largest namespaces)" example:"{\"io.github.example\":3}"`
+	RegistryType  map[string]int `json:"registryType" doc:"Number of matching servers per package registry type" example:"{\"npm\":80,\"oci\":25}"`
+	TransportType map[string]int `json:"transportType" doc:"Number of matching servers per package or remote transport type" example:"{\"stdio\":100,\"streamable-http\":30}"`
+}
+
+type ServerStatusRequest struct {
+	Status     model.Status     `json:"status" enum:"active,deprecated" doc:"New status of the server version" example:"deprecated"`
+	Message    string           `json:"message,omitempty" maxLength:"500" doc:"Optional deprecation message shown to users" example:"This version has a security issue, please upgrade"`
+	ReplacedBy *ServerReference `json:"replacedBy,omitempty" doc:"Optional server or version that replaces the deprecated version"`
+}
+
+type ServerRevision struct {
+	Revision  int        `json:"revision" doc:"Revision number of the server version. Revision 1 is the original publication." example:"2"`
+	Server    ServerJSON `json:"server" doc:"Server configuration and metadata as of this revision"`
+	EditedBy  string     `json:"editedBy,omitempty" doc:"Identity of the editor that created this revision (auth method and subject). Empty for the original publication." example:"github-at:octocat"`
+	CreatedAt time.Time  `json:"createdAt" format:"date-time" doc:"Timestamp when this revision was created"`
+}
+
+type ServerRevisionListResponse struct {
+	Revisions []ServerRevision `json:"revisions" doc:"Revisions of the server version, oldest first"`
+	Metadata  Metadata         `json:"metadata" doc:"Pagination metadata"`
+}
+
+type ServerDiffChange struct {
+	Path string `json:"path" doc:"Field path of the change. Array elements are addressed by identifier, name or url when they have one (e.g. packages[identifier=@example/server].environmentVariables[name=API_KEY]) and by index otherwise." example:"packages[identifier=@example/server].version"`
+	Type string `json:"type" enum:"added,removed,changed" doc:"Kind of change" example:"changed"`
+	From any    `json:"from,omitempty" doc:"Value in the 'from' version (absent for added fields)"`
+	To   any    `json:"to,omitempty" doc:"Value in the 'to' version (absent for removed fields)"`
+}
+
+type ServerDiffResponse struct {
+	From    string             `json:"from" doc:"Version the diff starts from" example:"1.0.0"`
+	To      string             `json:"to" doc:"Version the diff ends at" example:"1.1.0"`
+	Changes []ServerDiffChange `json:"changes" doc:"Field-level changes between the two versions' server.json"`
+	Text    string             `json:"text" doc:"Human-readable rendering of the changes, one per line"`
+}
+
+type PublishQuotaOverride struct {
+	Quota     string    `json:"quota" enum:"servers_per_namespace,versions_per_day,publishes_per_hour" doc:"Quota that is overridden" example:"versions_per_day"`
+	Subject   string    `json:"subject" doc:"Namespace, server name or publisher identity (auth method and subject) the override applies to, depending on the quota" example:"com.example/my-server"`
+	Limit     int       `json:"limit" doc:"Quota of the subject, replacing the configured default. 0 means unlimited." example:"500"`
+	Reason    string    `json:"reason,omitempty" doc:"Why the quota was overridden" example:"Nightly builds"`
+	UpdatedAt time.Time `json:"updatedAt" format:"date-time" doc:"Timestamp when the override was last set"`
+}
+
+type PublishQuotaOverrideRequest struct {
+	Limit  int    `json:"limit" minimum:"0" doc:"Quota of the subject, replacing the configured default. 0 means unlimited." example:"500"`
+	Reason string `json:"reason,omitempty" maxLength:"500" doc:"Optional reason for the override" example:"Nightly builds"`
+}
+
+type PublishQuotaOverrideListResponse struct {
+	Overrides []PublishQuotaOverride `json:"overrides" doc:"Quota overrides, ordered by quota and subject"`
+}
+
+type BlockedNamespace struct {
+	Namespace string    `json:"namespace" doc:"Blocked namespace. Its sub-namespaces are blocked too." example:"io.github.spammer"`
+	Reason    string    `json:"reason,omitempty" doc:"Why the namespace was blocked" example:"Spam"`
+	BlockedBy string    `json:"blockedBy,omitempty" doc:"Identity of the admin that blocked the namespace (auth method and subject)" example:"github-at:octocat"`
+	CreatedAt time.Time `json:"createdAt" format:"date-time" doc:"Timestamp when the namespace was blocked"`
+}
+
+type BlockNamespaceRequest struct {
+	Reason string `json:"reason,omitempty" maxLength:"500" doc:"Optional reason for blocking the namespace" example:"Spam"`
+}
+
+type BlockedNamespaceListResponse struct {
+	Namespaces []BlockedNamespace `json:"namespaces" doc:"Blocked namespaces, ordered by namespace"`
+}
+
+type APITokenPermission struct {
+	Action   string `json:"action" enum:"publish,edit" doc:"Action the token may perform" example:"publish"`
+	Resource string `json:"resource" doc:"Server name pattern the action applies to, either an exact server name or a prefix ending with '*'" example:"io.github.octocat/*"`
+}
+
+type APIToken struct {
+	ID          string               `json:"id" doc:"Identifier of the API token, used to revoke it" example:"3f2a9c4b1d8e7f60"`
+	Description string               `json:"description" doc:"What the API token is used for" example:"Release workflow on our CI"`
+	Owner       string               `json:"owner" doc:"Identity of the user that created the API token (auth method and subject)" example:"github-at:octocat"`
+	Permissions []APITokenPermission `json:"permissions" doc:"Permissions granted by the API token"`
+	CreatedAt   time.Time            `json:"createdAt" format:"date-time" doc:"Timestamp when the API token was created"`
+	ExpiresAt   time.Time            `json:"expiresAt" format:"date-time" doc:"Timestamp after which the API token can no longer be exchanged"`
+	LastUsedAt  *time.Time           `json:"lastUsedAt,omitempty" format:"date-time" doc:"Timestamp when the API token was last exchanged for a Registry JWT"`
+}
+
+type APITokenCreateRequest struct {
+	Description string               `json:"description" minLength:"1" maxLength:"200" doc:"What the API token is used for" example:"Release workflow on our CI"`
+	Permissions []APITokenPermission `json:"permissions" minItems:"1" doc:"Permissions granted by the API token, each within the permissions of the Registry JWT creating it"`
+	ExpiresAt   time.Time            `json:"expiresAt" format:"date-time" doc:"Timestamp after which the API token can no longer be exchanged" example:"2026-12-31T00:00:00Z"`
+}
+
+type APITokenCreateResponse struct {
+	APIToken
+	Token string `json:"token" doc:"The API token. It is only returned on creation, so store it securely." example:"mcpr_8J2n0c4QmV7yXk1pR5tW9zB3dF6hL0sA2eG4iK8mO1u"`
+}
+
+type APITokenListResponse struct {
+	Tokens []APIToken `json:"tokens" doc:"API tokens of the authenticated user, newest first"`
 }
@@ -0,0 +1,71 @@
+package canonicaljson_test
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/pkg/canonicaljson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransform(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "sorts members and drops whitespace",
+			input:    `{ "b": 1, "a": [ true, false, null ], "c": { "z": "", "y": {} } }`,
+			expected: `{"a":[true,false,null],"b":1,"c":{"y":{},"z":""}}`,
+		},
+		{
+			name:     "sorts by UTF-16 code units",
+			input:    `{"😀": 1, "ﬁ": 2, "a": 3}`,
+			expected: `{"a":3,"😀":1,"ﬁ":2}`,
+		},
+		{
+			name:     "escapes only what JSON requires",
+			input:    `"Aé  \"\\\/ \b\f\n\r\t \u001f <&>"`,
+			expected: `"Aé` + " " + ` \"\\/ \b\f\n\r\t \u001f <&>"`,
+		},
+		{
+			name:     "formats numbers like ECMAScript",
+			input:    `[1.0, -0, 1e2, 0.000001, 1e-7, 1E21, 123456789012345678901, 1.5e300, -2.50]`,
+			expected: `[1,0,100,0.000001,1e-7,1e+21,123456789012345680000,1.5e+300,-2.5]`,
+		},
+		{
+			name:     "RFC 8785 example",
+			input:    `{"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001], "string": "€$\u000F\u000aA'B\"\\\\\"\/", "literals": [null, true, false]}`,
+			expected: `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := canonicaljson.Transform([]byte(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(result))
+		})
+	}
+}
+
+func TestTransformErrors(t *testing.T) {
+	for _, input := range []string{``, `{`, `{"a":1} {"b":2}`, `1e400`} {
+		_, err := canonicaljson.Transform([]byte(input))
+		assert.Error(t, err, input)
+	}
+}
+
+func TestDigest(t *testing.T) {
+	first, err := canonicaljson.Digest(map[string]any{"name": "com.example/server", "version": "1.0.0"})
+	require.NoError(t, err)
+	second, err := canonicaljson.Digest(struct {
+		Version string `json:"version"`
+		Name    string `json:"name"`
+	}{Version: "1.0.0", Name: "com.example/server"})
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Len(t, first, 64)
+}
@@ -0,0 +1,166 @@
+package transparency
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/pkg/canonicaljson"
+)
+
+// Log entry actions
+const (
+	ActionPublish = "publish"
+	ActionEdit    = "edit"
+)
+
+// SignatureAlgorithm is the only algorithm tree heads are signed with
+const SignatureAlgorithm = "ed25519"
+
+var (
+	// ErrUnsigned is returned when verifying a tree head without a signature
+	ErrUnsigned = errors.New("tree head is not signed")
+	// ErrInvalidSignature is returned when a tree head signature does not verify with the expected key
+	ErrInvalidSignature = errors.New("tree head signature is invalid")
+)
+
+// LogEntry records a server.json being stored by a publish or an edit. The leaf data of an
+// entry is its canonical JSON (RFC 8785) encoding.
+type LogEntry struct {
+	ServerName string    `json:"serverName" doc:"Server name" example:"com.example/my-server"`
+	Version    string    `json:"version" doc:"Server version" example:"1.0.0"`
+	Revision   int       `json:"revision" doc:"Revision of the server version the entry records" example:"1"`
+	Action     string    `json:"action" enum:"publish,edit" doc:"Whether the server.json was stored by a publish or an edit"`
+	SHA256     string    `json:"sha256" doc:"Hex-encoded SHA-256 of the canonical JSON (RFC 8785) encoding of the server.json"`
+	Timestamp  time.Time `json:"timestamp" doc:"When the entry was appended"`
+}
+
+// LeafData returns the leaf data of the entry
+func (e *LogEntry) LeafData() ([]byte, error) {
+	return canonicaljson.Marshal(e)
+}
+
+// TreeHead is the size and root hash of the log at a point in time
+type TreeHead struct {
+	TreeSize  int64     `json:"treeSize" doc:"Number of entries in the tree"`
+	RootHash  []byte    `json:"rootHash" doc:"Base64-encoded Merkle tree root hash"`
+	Timestamp time.Time `json:"timestamp" doc:"When the tree head was signed"`
+}
+
+// SignedTreeHead is a tree head with the signature over its canonical JSON encoding
+type SignedTreeHead struct {
+	TreeHead
+	Signature *TreeHeadSignature `json:"signature,omitempty" doc:"Signature over the canonical JSON encoding of treeSize, rootHash and timestamp"`
+}
+
+// TreeHeadSignature is an Ed25519 signature. PublicKey identifies the signing key; verifiers must
+// compare it to a key they trust.
+type TreeHeadSignature struct {
+	Algorithm string `json:"algorithm" doc:"Signature algorithm" example:"ed25519"`
+	PublicKey string `json:"publicKey" doc:"Base64-encoded public key of the signer"`
+	Value     string `json:"value" doc:"Base64-encoded signature"`
+}
+
+// InclusionProof proves that an entry is in the log
+type InclusionProof struct {
+	LogIndex int64          `json:"logIndex" doc:"Index of the entry in the log"`
+	Entry    LogEntry       `json:"entry" doc:"The log entry"`
+	Hashes   [][]byte       `json:"hashes" doc:"Base64-encoded audit path from the leaf to the root"`
+	TreeHead SignedTreeHead `json:"treeHead" doc:"Signed tree head the proof leads to"`
+}
+
+// ConsistencyProof proves that a tree head is a prefix of a later one
+type ConsistencyProof struct {
+	First  SignedTreeHead `json:"first" doc:"Signed head of the earlier tree"`
+	Second SignedTreeHead `json:"second" doc:"Signed head of the later tree"`
+	Hashes [][]byte       `json:"hashes" doc:"Base64-encoded consistency proof"`
+}
+
+// SignTreeHead timestamps and signs a tree head
+func SignTreeHead(head *SignedTreeHead, key ed25519.PrivateKey, now time.Time) error {
+	head.Timestamp = now.UTC()
+	payload, err := canonicaljson.Marshal(&head.TreeHead)
+	if err != nil {
+		return fmt.Errorf("failed to encode tree head: %w", err)
+	}
+	head.Signature = &TreeHeadSignature{
+		Algorithm: SignatureAlgorithm,
+		PublicKey: base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey)),
+		Value:     base64.StdEncoding.EncodeToString(ed25519.Sign(key, payload)),
+	}
+	return nil
+}
+
+// Verifier checks signed tree heads and proofs against the registry's public key
+type Verifier struct {
+	publicKey ed25519.PublicKey
+}
+
+// NewVerifier creates a verifier that trusts tree heads signed by publicKey
+func NewVerifier(publicKey ed25519.PublicKey) *Verifier {
+	return &Verifier{publicKey: publicKey}
+}
+
+// VerifyTreeHead checks the signature of a tree head
+func (v *Verifier) VerifyTreeHead(head *SignedTreeHead) error {
+	if head.Signature == nil {
+		return ErrUnsigned
+	}
+	if head.Signature.Algorithm != SignatureAlgorithm {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidSignature, head.Signature.Algorithm)
+	}
+	if head.Signature.PublicKey != base64.StdEncoding.EncodeToString(v.publicKey) {
+		return fmt.Errorf("%w: signed by an unexpected key", ErrInvalidSignature)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(head.Signature.Value)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	payload, err := canonicaljson.Marshal(&head.TreeHead)
+	if err != nil {
+		return fmt.Errorf("failed to encode tree head: %w", err)
+	}
+	if !ed25519.Verify(v.publicKey, payload, signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyInclusion checks that the proof's entry is in the log under a tree head signed by the
+// registry. If serverJSON is not nil, it also checks that the entry records exactly that server.json.
+func (v *Verifier) VerifyInclusion(proof *InclusionProof, serverJSON any) error {
+	if err := v.VerifyTreeHead(&proof.TreeHead); err != nil {
+		return err
+	}
+
+	if serverJSON != nil {
+		digest, err := canonicaljson.Digest(serverJSON)
+		if err != nil {
+			return fmt.Errorf("failed to digest server.json: %w", err)
+		}
+		if digest != proof.Entry.SHA256 {
+			return fmt.Errorf("%w: server.json does not match the logged digest", ErrInvalidProof)
+		}
+	}
+
+	leafData, err := proof.Entry.LeafData()
+	if err != nil {
+		return fmt.Errorf("failed to encode log entry: %w", err)
+	}
+	return VerifyInclusionProof(LeafHash(leafData), proof.LogIndex, proof.TreeHead.TreeSize, proof.Hashes, proof.TreeHead.RootHash)
+}
+
+// VerifyConsistency checks that both tree heads are signed by the registry and that the first
+// tree is a prefix of the second, i.e. that no logged entry was changed or removed in between
+func (v *Verifier) VerifyConsistency(proof *ConsistencyProof) error {
+	if err := v.VerifyTreeHead(&proof.First); err != nil {
+		return err
+	}
+	if err := v.VerifyTreeHead(&proof.Second); err != nil {
+		return err
+	}
+	return VerifyConsistencyProof(proof.First.TreeSize, proof.Second.TreeSize, proof.Hashes, proof.First.RootHash, proof.Second.RootHash)
+}
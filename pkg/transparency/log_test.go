@@ -0,0 +1,98 @@
+package transparency_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/pkg/canonicaljson"
+	"github.com/modelcontextprotocol/registry/pkg/transparency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifier(t *testing.T) {
+	ctx := context.Background()
+	signingKey := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	verifier := transparency.NewVerifier(signingKey.Public().(ed25519.PublicKey))
+
+	// A log of three entries, the second of which records the server.json below
+	serverJSON := map[string]any{"name": "com.example/server", "version": "1.0.0"}
+	digest, err := canonicaljson.Digest(serverJSON)
+	require.NoError(t, err)
+
+	tree := &memoryTree{}
+	var entries []transparency.LogEntry
+	for i, name := range []string{"com.example/other", "com.example/server", "com.example/third"} {
+		entry := transparency.LogEntry{
+			ServerName: name,
+			Version:    "1.0.0",
+			Revision:   1,
+			Action:     transparency.ActionPublish,
+			SHA256:     digest,
+			Timestamp:  time.Date(2025, 10, 1, 0, 0, i, 0, time.UTC),
+		}
+		leafData, err := entry.LeafData()
+		require.NoError(t, err)
+		tree.leaves = append(tree.leaves, transparency.LeafHash(leafData))
+		entries = append(entries, entry)
+	}
+
+	signedHead := func(t *testing.T, size int64) transparency.SignedTreeHead {
+		t.Helper()
+		root, err := transparency.RootHash(ctx, size, tree.subtree)
+		require.NoError(t, err)
+		head := transparency.SignedTreeHead{TreeHead: transparency.TreeHead{TreeSize: size, RootHash: root}}
+		require.NoError(t, transparency.SignTreeHead(&head, signingKey, time.Now()))
+		return head
+	}
+
+	hashes, err := transparency.ProveInclusion(ctx, 1, 3, tree.subtree)
+	require.NoError(t, err)
+	inclusion := &transparency.InclusionProof{
+		LogIndex: 1,
+		Entry:    entries[1],
+		Hashes:   hashes,
+		TreeHead: signedHead(t, 3),
+	}
+
+	t.Run("inclusion", func(t *testing.T) {
+		assert.NoError(t, verifier.VerifyInclusion(inclusion, serverJSON))
+		assert.NoError(t, verifier.VerifyInclusion(inclusion, nil))
+	})
+
+	t.Run("different server.json", func(t *testing.T) {
+		modified := map[string]any{"name": "com.example/server", "version": "1.0.0", "description": "Rewritten"}
+		assert.ErrorIs(t, verifier.VerifyInclusion(inclusion, modified), transparency.ErrInvalidProof)
+	})
+
+	t.Run("modified entry", func(t *testing.T) {
+		modified := *inclusion
+		modified.Entry.Revision = 2
+		assert.ErrorIs(t, verifier.VerifyInclusion(&modified, nil), transparency.ErrInvalidProof)
+	})
+
+	t.Run("tree head signed by another key", func(t *testing.T) {
+		otherKey := ed25519.NewKeyFromSeed(append(make([]byte, ed25519.SeedSize-1), 1))
+		other := *inclusion
+		require.NoError(t, transparency.SignTreeHead(&other.TreeHead, otherKey, time.Now()))
+		assert.ErrorIs(t, verifier.VerifyInclusion(&other, nil), transparency.ErrInvalidSignature)
+	})
+
+	t.Run("unsigned tree head", func(t *testing.T) {
+		unsigned := *inclusion
+		unsigned.TreeHead.Signature = nil
+		assert.ErrorIs(t, verifier.VerifyInclusion(&unsigned, nil), transparency.ErrUnsigned)
+	})
+
+	t.Run("consistency", func(t *testing.T) {
+		hashes, err := transparency.ProveConsistency(ctx, 1, 3, tree.subtree)
+		require.NoError(t, err)
+		proof := &transparency.ConsistencyProof{First: signedHead(t, 1), Second: signedHead(t, 3), Hashes: hashes}
+		assert.NoError(t, verifier.VerifyConsistency(proof))
+
+		proof.First.RootHash = tree.leaves[1]
+		assert.ErrorIs(t, verifier.VerifyConsistency(proof), transparency.ErrInvalidSignature)
+	})
+}
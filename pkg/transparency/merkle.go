@@ -0,0 +1,263 @@
+// Package transparency implements the Merkle tree of the registry transparency log, following
+// RFC 9162 (Certificate Transparency 2.0): hashing, proof generation and proof verification.
+// Clients use it to check that a server.json they were served is recorded in the log, and
+// monitors use it to check that the log only ever grows.
+package transparency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// ErrInvalidProof is returned when a proof does not verify
+var ErrInvalidProof = errors.New("invalid proof")
+
+// SubtreeHashFunc returns the hash of the complete subtree of 2^level leaves starting at leaf
+// index<<level. Proofs only ever need such aligned complete subtrees, which never change once
+// the log has grown past them, so they can be stored as the log is appended to.
+type SubtreeHashFunc func(ctx context.Context, level int, index int64) ([]byte, error)
+
+// LeafHash returns the hash of a log entry's leaf data
+func LeafHash(data []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{0x00})
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// NodeHash returns the hash of an interior node
+func NodeHash(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{0x01})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// EmptyRootHash is the root hash of an empty tree
+func EmptyRootHash() []byte {
+	sum := sha256.Sum256(nil)
+	return sum[:]
+}
+
+// RootHash returns the root hash of the tree of the first size leaves
+func RootHash(ctx context.Context, size int64, subtree SubtreeHashFunc) ([]byte, error) {
+	if size < 0 {
+		return nil, fmt.Errorf("invalid tree size %d", size)
+	}
+	if size == 0 {
+		return EmptyRootHash(), nil
+	}
+	return rangeHash(ctx, 0, size, subtree)
+}
+
+// ProveInclusion returns the audit path of the leaf at index in the tree of the first size leaves
+func ProveInclusion(ctx context.Context, index, size int64, subtree SubtreeHashFunc) ([][]byte, error) {
+	if index < 0 || index >= size {
+		return nil, fmt.Errorf("leaf index %d is outside the tree of size %d", index, size)
+	}
+	return inclusionPath(ctx, index, 0, size, subtree)
+}
+
+// ProveConsistency returns the proof that the tree of the first first leaves is a prefix of the
+// tree of the first second leaves
+func ProveConsistency(ctx context.Context, first, second int64, subtree SubtreeHashFunc) ([][]byte, error) {
+	if first < 0 || first > second {
+		return nil, fmt.Errorf("invalid tree sizes %d and %d", first, second)
+	}
+	if first == 0 || first == second {
+		return [][]byte{}, nil
+	}
+	return subproof(ctx, first, 0, second, true, subtree)
+}
+
+// rangeHash returns the hash of leaves [start, end). Like every range the proofs split a tree
+// into, it either is an aligned complete subtree or splits into one and a smaller remainder.
+func rangeHash(ctx context.Context, start, end int64, subtree SubtreeHashFunc) ([]byte, error) {
+	size := end - start
+	if size&(size-1) == 0 {
+		level := bits.TrailingZeros64(uint64(size))
+		return subtree(ctx, level, start>>level)
+	}
+
+	k := splitPoint(size)
+	left, err := rangeHash(ctx, start, start+k, subtree)
+	if err != nil {
+		return nil, err
+	}
+	right, err := rangeHash(ctx, start+k, end, subtree)
+	if err != nil {
+		return nil, err
+	}
+	return NodeHash(left, right), nil
+}
+
+func inclusionPath(ctx context.Context, index, start, end int64, subtree SubtreeHashFunc) ([][]byte, error) {
+	size := end - start
+	if size == 1 {
+		return [][]byte{}, nil
+	}
+
+	k := splitPoint(size)
+	var path [][]byte
+	var sibling []byte
+	var err error
+	if index < k {
+		if path, err = inclusionPath(ctx, index, start, start+k, subtree); err != nil {
+			return nil, err
+		}
+		sibling, err = rangeHash(ctx, start+k, end, subtree)
+	} else {
+		if path, err = inclusionPath(ctx, index-k, start+k, end, subtree); err != nil {
+			return nil, err
+		}
+		sibling, err = rangeHash(ctx, start, start+k, subtree)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(path, sibling), nil
+}
+
+// subproof is SUBPROOF of RFC 9162, section 2.1.4.1; complete reports whether the old tree is
+// known to the verifier as a whole, so its hash can be left out of the proof
+func subproof(ctx context.Context, first, start, end int64, complete bool, subtree SubtreeHashFunc) ([][]byte, error) {
+	size := end - start
+	if first == size {
+		if complete {
+			return [][]byte{}, nil
+		}
+		hash, err := rangeHash(ctx, start, end, subtree)
+		if err != nil {
+			return nil, err
+		}
+		return [][]byte{hash}, nil
+	}
+
+	k := splitPoint(size)
+	var proof [][]byte
+	var sibling []byte
+	var err error
+	if first <= k {
+		if proof, err = subproof(ctx, first, start, start+k, complete, subtree); err != nil {
+			return nil, err
+		}
+		sibling, err = rangeHash(ctx, start+k, end, subtree)
+	} else {
+		if proof, err = subproof(ctx, first-k, start+k, end, false, subtree); err != nil {
+			return nil, err
+		}
+		sibling, err = rangeHash(ctx, start, start+k, subtree)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(proof, sibling), nil
+}
+
+// splitPoint returns the largest power of two smaller than size
+func splitPoint(size int64) int64 {
+	return 1 << (bits.Len64(uint64(size-1)) - 1)
+}
+
+// VerifyInclusionProof checks that leafHash is the leaf at index of the tree of size leaves
+// with the given root hash, following RFC 9162, section 2.1.3.2
+func VerifyInclusionProof(leafHash []byte, index, size int64, proof [][]byte, rootHash []byte) error {
+	if index < 0 || index >= size {
+		return fmt.Errorf("%w: leaf index %d is outside the tree of size %d", ErrInvalidProof, index, size)
+	}
+
+	fn, sn := uint64(index), uint64(size-1)
+	r := leafHash
+	for _, p := range proof {
+		if sn == 0 {
+			return fmt.Errorf("%w: proof is too long", ErrInvalidProof)
+		}
+		if fn&1 == 1 || fn == sn {
+			r = NodeHash(p, r)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			r = NodeHash(r, p)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+
+	if sn != 0 {
+		return fmt.Errorf("%w: proof is too short", ErrInvalidProof)
+	}
+	if !bytes.Equal(r, rootHash) {
+		return fmt.Errorf("%w: root hash mismatch", ErrInvalidProof)
+	}
+	return nil
+}
+
+// VerifyConsistencyProof checks that the tree of first leaves with firstRoot is a prefix of the
+// tree of second leaves with secondRoot, following RFC 9162, section 2.1.4.2
+func VerifyConsistencyProof(first, second int64, proof [][]byte, firstRoot, secondRoot []byte) error {
+	switch {
+	case first < 0 || first > second:
+		return fmt.Errorf("%w: invalid tree sizes %d and %d", ErrInvalidProof, first, second)
+	case first == 0:
+		// The empty tree is a prefix of every tree
+		if len(proof) != 0 {
+			return fmt.Errorf("%w: proof must be empty", ErrInvalidProof)
+		}
+		return nil
+	case first == second:
+		if len(proof) != 0 {
+			return fmt.Errorf("%w: proof must be empty", ErrInvalidProof)
+		}
+		if !bytes.Equal(firstRoot, secondRoot) {
+			return fmt.Errorf("%w: root hash mismatch", ErrInvalidProof)
+		}
+		return nil
+	case len(proof) == 0:
+		return fmt.Errorf("%w: proof is empty", ErrInvalidProof)
+	}
+
+	// When the old tree is a complete subtree, its root is the starting point of the proof
+	if first&(first-1) == 0 {
+		proof = append([][]byte{firstRoot}, proof...)
+	}
+
+	fn, sn := uint64(first-1), uint64(second-1)
+	for fn&1 == 1 {
+		fn >>= 1
+		sn >>= 1
+	}
+
+	fr, sr := proof[0], proof[0]
+	for _, c := range proof[1:] {
+		if sn == 0 {
+			return fmt.Errorf("%w: proof is too long", ErrInvalidProof)
+		}
+		if fn&1 == 1 || fn == sn {
+			fr = NodeHash(c, fr)
+			sr = NodeHash(c, sr)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			sr = NodeHash(sr, c)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+
+	if sn != 0 {
+		return fmt.Errorf("%w: proof is too short", ErrInvalidProof)
+	}
+	if !bytes.Equal(fr, firstRoot) || !bytes.Equal(sr, secondRoot) {
+		return fmt.Errorf("%w: root hash mismatch", ErrInvalidProof)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package transparency_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/pkg/transparency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryTree computes subtree hashes directly from the leaves, as RFC 9162 defines them
+type memoryTree struct {
+	leaves [][]byte
+}
+
+func newMemoryTree(size int) *memoryTree {
+	tree := &memoryTree{}
+	for i := 0; i < size; i++ {
+		tree.leaves = append(tree.leaves, transparency.LeafHash([]byte(fmt.Sprintf("entry %d", i))))
+	}
+	return tree
+}
+
+func (m *memoryTree) hash(leaves [][]byte) []byte {
+	if len(leaves) == 0 {
+		sum := sha256.Sum256(nil)
+		return sum[:]
+	}
+	if len(leaves) == 1 {
+		return leaves[0]
+	}
+	k := 1
+	for k*2 < len(leaves) {
+		k *= 2
+	}
+	return transparency.NodeHash(m.hash(leaves[:k]), m.hash(leaves[k:]))
+}
+
+func (m *memoryTree) subtree(_ context.Context, level int, index int64) ([]byte, error) {
+	start := int(index) << level
+	end := start + 1<<level
+	if end > len(m.leaves) {
+		return nil, fmt.Errorf("subtree %d/%d is not complete", level, index)
+	}
+	return m.hash(m.leaves[start:end]), nil
+}
+
+func TestRootHash(t *testing.T) {
+	ctx := context.Background()
+	tree := newMemoryTree(70)
+
+	for size := 0; size <= len(tree.leaves); size++ {
+		root, err := transparency.RootHash(ctx, int64(size), tree.subtree)
+		require.NoError(t, err)
+		assert.Equal(t, tree.hash(tree.leaves[:size]), root, "size %d", size)
+	}
+}
+
+func TestInclusionProofs(t *testing.T) {
+	ctx := context.Background()
+	tree := newMemoryTree(40)
+
+	for size := int64(1); size <= int64(len(tree.leaves)); size++ {
+		root := tree.hash(tree.leaves[:size])
+		for index := int64(0); index < size; index++ {
+			proof, err := transparency.ProveInclusion(ctx, index, size, tree.subtree)
+			require.NoError(t, err)
+			require.NoError(t, transparency.VerifyInclusionProof(tree.leaves[index], index, size, proof, root), "index %d of size %d", index, size)
+
+			// The proof only holds for this leaf at this position
+			other := tree.leaves[(index+1)%size]
+			if size > 1 {
+				assert.ErrorIs(t, transparency.VerifyInclusionProof(other, index, size, proof, root), transparency.ErrInvalidProof)
+			}
+			if len(proof) > 0 {
+				assert.ErrorIs(t, transparency.VerifyInclusionProof(tree.leaves[index], index, size, proof[1:], root), transparency.ErrInvalidProof)
+			}
+		}
+	}
+
+	_, err := transparency.ProveInclusion(ctx, 5, 5, tree.subtree)
+	assert.Error(t, err)
+	assert.ErrorIs(t, transparency.VerifyInclusionProof(tree.leaves[0], 5, 5, nil, nil), transparency.ErrInvalidProof)
+}
+
+func TestConsistencyProofs(t *testing.T) {
+	ctx := context.Background()
+	tree := newMemoryTree(40)
+
+	for second := int64(0); second <= int64(len(tree.leaves)); second++ {
+		secondRoot := tree.hash(tree.leaves[:second])
+		for first := int64(0); first <= second; first++ {
+			firstRoot := tree.hash(tree.leaves[:first])
+			proof, err := transparency.ProveConsistency(ctx, first, second, tree.subtree)
+			require.NoError(t, err)
+			require.NoError(t, transparency.VerifyConsistencyProof(first, second, proof, firstRoot, secondRoot), "sizes %d and %d", first, second)
+
+			// A rewritten old tree is detected
+			if first > 0 && first < second {
+				rewritten := append([][]byte{}, tree.leaves[:first]...)
+				rewritten[0] = transparency.LeafHash([]byte("rewritten"))
+				err := transparency.VerifyConsistencyProof(first, second, proof, tree.hash(rewritten), secondRoot)
+				assert.ErrorIs(t, err, transparency.ErrInvalidProof, "sizes %d and %d", first, second)
+			}
+		}
+	}
+
+	_, err := transparency.ProveConsistency(ctx, 3, 2, tree.subtree)
+	assert.Error(t, err)
+}
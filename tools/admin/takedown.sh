@@ -4,9 +4,16 @@
 REGISTRY_URL="${REGISTRY_URL:-https://registry.modelcontextprotocol.io}"
 
 if [ -z "$SERVER_NAME" ] || [ -z "$REGISTRY_TOKEN" ]; then
-    echo "Usage: REGISTRY_TOKEN=<token> SERVER_NAME=<server-name> [VERSION=<version>] $0"
+    echo "Usage: REGISTRY_TOKEN=<token> SERVER_NAME=<server-name> [VERSION=<version> | REASON=<reason>] $0"
     echo "Example: REGISTRY_TOKEN=token SERVER_NAME=com.example/my-server ./takedown.sh"
     echo "Example: REGISTRY_TOKEN=token SERVER_NAME=com.example/my-server VERSION=1.0.0 ./takedown.sh"
+    echo "Example: REGISTRY_TOKEN=token SERVER_NAME=com.example/my-server REASON=\"Malware report\" ./takedown.sh"
+    exit 1
+fi
+
+# Reasons are recorded by the server-wide status endpoint only
+if [ -n "$VERSION" ] && [ -n "$REASON" ]; then
+    echo "REASON can only be given when taking down every version of a server (without VERSION)"
     exit 1
 fi
 
@@ -19,12 +26,18 @@ if [ -n "$VERSION" ]; then
     ENDPOINT="${REGISTRY_URL}/v0/servers/${ENCODED_SERVER_NAME}/versions/${VERSION}?status=deleted"
     echo "Marking version $VERSION of server $SERVER_NAME as deleted..."
 else
-    # Mark entire server as deleted (latest version)
+    # Mark every version of the server as deleted
     ENDPOINT="${REGISTRY_URL}/v0/servers/${ENCODED_SERVER_NAME}?status=deleted"
-    echo "Marking server $SERVER_NAME as deleted..."
+    echo "Marking all versions of server $SERVER_NAME as deleted..."
+fi
+
+# Record the reason for the takedown if given
+REASON_ARGS=()
+if [ -n "$REASON" ]; then
+    REASON_ARGS=(-G --data-urlencode "reason=${REASON}")
 fi
 
 # Update server status to deleted
-curl -X PUT "$ENDPOINT" \
+curl -X PUT "$ENDPOINT" "${REASON_ARGS[@]}" \
   -H "Authorization: Bearer ${REGISTRY_TOKEN}" \
   -H "Content-Type: application/json"
\ No newline at end of file